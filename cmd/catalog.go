@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jycamier/promener/internal/catalog"
+	"github.com/jycamier/promener/internal/domain"
+	"github.com/jycamier/promener/internal/signals"
+	"github.com/jycamier/promener/internal/validator"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	catalogSources  []string
+	catalogAddr     string
+	catalogInterval time.Duration
+)
+
+// catalogCmd represents the catalog command
+var catalogCmd = &cobra.Command{
+	Use:   "catalog",
+	Short: "Query the metrics of many specifications",
+}
+
+// catalogServeCmd represents the catalog serve command
+var catalogServeCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the catalog of many specifications as a JSON REST API",
+	Long: `Serve one catalog of the metrics of many specifications as a JSON REST API, for
+Grafana plugins, chatbots and other tools.
+
+Sources are resolved like Rego rule sources: local files or directories, Git
+repositories (github:org/repo@tag, gitlab:org/repo#branch, bitbucket:...) and
+HTTP(S) .cue files or .tar.gz/.zip archives. Every .cue file of a directory is
+loaded as a specification, files that are not valid specifications are skipped
+with a warning.
+
+The sources are reloaded at each --interval. When a source cannot be fetched,
+the previous catalog is kept.
+
+Endpoints:
+  GET /api/v1/services                              services
+  GET /api/v1/metrics?name=&label=&owner=&service=  search metrics
+  GET /api/v1/metrics/{name}                        a metric, one entry per service
+  GET /api/v1/metrics/{name}/services               services emitting a metric
+  GET /api/v1/deprecated                            deprecated metrics and replacements
+  GET /api/v1/status                                last reload
+
+Examples:
+  promener catalog serve -s ./specs
+  promener catalog serve -s github:acme/metrics-specs@v1.2.0 -s https://specs.acme.io/shop.tar.gz --interval 10m`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sources := viper.GetStringSlice("catalog.source")
+		addr := viper.GetString("catalog.addr")
+		interval := viper.GetDuration("catalog.interval")
+		rulesDirs := viper.GetStringSlice("rules")
+
+		if len(sources) == 0 {
+			return fmt.Errorf("at least one source is required (via --source flag or config file)")
+		}
+		if interval <= 0 {
+			return fmt.Errorf("--interval must be positive")
+		}
+
+		load := func(ctx context.Context) ([]*domain.Specification, error) {
+			return loadCatalogSources(ctx, sources, rulesDirs, interval)
+		}
+
+		// Setup context with signal handling for graceful shutdown
+		// Uses platform-specific signals (Unix: SIGINT+SIGTERM, Windows: only SIGINT)
+		ctx, stop := signal.NotifyContext(context.Background(), signals.Shutdown()...)
+		defer stop()
+
+		server := catalog.NewServer(load, os.Stderr)
+		if err := server.Reload(ctx); err != nil {
+			return err
+		}
+
+		listener, err := net.Listen("tcp", addr)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", addr, err)
+		}
+
+		httpServer := &http.Server{
+			Handler:           server,
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		go server.Run(ctx, interval)
+
+		serveErr := make(chan error, 1)
+		go func() { serveErr <- httpServer.Serve(listener) }()
+
+		fmt.Printf("✓ Serving the catalog on http://%s/api/v1\n", displayAddr(listener.Addr()))
+		fmt.Printf("🔄 Reloading every %s... Press Ctrl+C to stop\n", interval)
+
+		select {
+		case err := <-serveErr:
+			if !errors.Is(err, http.ErrServerClosed) {
+				return fmt.Errorf("server failed: %w", err)
+			}
+		case <-ctx.Done():
+			fmt.Printf("\n✓ Received shutdown signal, stopping server...\n")
+		}
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return httpServer.Shutdown(shutdownCtx)
+	},
+}
+
+// loadCatalogSources fetches the sources and loads their specifications. Fetched sources are
+// cached for one interval, so that each reload fetches them again.
+func loadCatalogSources(ctx context.Context, sources []string, rulesDirs []string, interval time.Duration) ([]*domain.Specification, error) {
+	resolver := validator.NewRuleSourceResolver()
+	resolver.SetCacheTTL(interval)
+
+	var specs []*domain.Specification
+	for _, source := range sources {
+		path, err := resolver.Resolve(ctx, source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch source %s: %w", source, err)
+		}
+
+		files, err := collectCueFiles(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read source %s: %w", source, err)
+		}
+
+		for _, file := range files {
+			spec, err := loadSpecFromInput(file, rulesDirs)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "⚠ Skipping %s: %v\n", file, err)
+				continue
+			}
+			specs = append(specs, spec)
+		}
+	}
+
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("no valid specification found in the sources")
+	}
+	return specs, nil
+}
+
+// collectCueFiles returns the file, or the .cue files of the directory, without the CUE module
+// and hidden directories
+func collectCueFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	err = filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if file != path && (d.Name() == "cue.mod" || strings.HasPrefix(d.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(file) == ".cue" {
+			files = append(files, file)
+		}
+		return nil
+	})
+	return files, err
+}
+
+func init() {
+	rootCmd.AddCommand(catalogCmd)
+	catalogCmd.AddCommand(catalogServeCmd)
+
+	catalogServeCmd.Flags().StringSliceVarP(&catalogSources, "source", "s", []string{}, "Specification source (local path, Git repository or HTTP URL) - can be specified multiple times")
+	catalogServeCmd.Flags().StringVar(&catalogAddr, "addr", ":8080", "Address to listen on")
+	catalogServeCmd.Flags().DurationVar(&catalogInterval, "interval", 5*time.Minute, "Interval between two reloads of the sources")
+
+	viper.BindPFlag("catalog.source", catalogServeCmd.Flags().Lookup("source"))
+	viper.BindPFlag("catalog.addr", catalogServeCmd.Flags().Lookup("addr"))
+	viper.BindPFlag("catalog.interval", catalogServeCmd.Flags().Lookup("interval"))
+}
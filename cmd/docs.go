@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jycamier/promener/internal/docgen"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	docsInputFiles []string
+	docsOutputFile string
+	docsFormat     string
+	docsTemplates  string
+)
+
+// docsCmd represents the docs command
+var docsCmd = &cobra.Command{
+	Use:   "docs",
+	Short: "Generate Markdown or AsciiDoc documentation from CUE specification",
+	Long: `Generate plain text documentation for your Prometheus metrics, to commit next to
+the code and render with GitHub, GitLab or Backstage TechDocs.
+
+The documentation has the same content as the HTML documentation: services,
+servers, golden signals, and metrics with their labels, constant labels,
+PromQL and alert examples and deprecation notes.
+
+The output is built from templates whose blocks (header, service, goldenSignals,
+metric) can be redefined with --templates, a glob pattern of template files
+parsed after the built-in ones, e.g. a file containing
+  {{define "metric"}}...{{end}}
+
+Input sources can be local CUE files, URIs (http/https), or files of Git
+repositories and archives, e.g. github:org/specs@v1.2.0//services/orders.cue.
+
+Examples:
+  # Markdown on the standard output
+  promener docs -i metrics.cue
+
+  # AsciiDoc file, aggregating several specifications
+  promener docs -i api.cue -i users.cue --format asciidoc -o docs/metrics.adoc
+
+  # With custom templates
+  promener docs -i metrics.cue -o METRICS.md --templates "docs/templates/*.tmpl"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inputFiles := viper.GetStringSlice("docs.input")
+		outputFile := viper.GetString("docs.output")
+		format := docgen.Format(viper.GetString("docs.format"))
+		templates := viper.GetString("docs.templates")
+		rulesDirs := viper.GetStringSlice("rules")
+
+		if len(inputFiles) == 0 {
+			return fmt.Errorf("at least one input file is required (via --input flag or config file)")
+		}
+
+		generator, err := docgen.New(format, templates)
+		if err != nil {
+			return err
+		}
+
+		spec, err := loadSpecs(inputFiles, rulesDirs)
+		if err != nil {
+			return err
+		}
+
+		if outputFile == "" {
+			doc, err := generator.Generate(spec)
+			if err != nil {
+				return fmt.Errorf("failed to generate documentation: %w", err)
+			}
+			_, err = os.Stdout.Write(doc)
+			return err
+		}
+
+		if err := generator.GenerateFile(spec, outputFile); err != nil {
+			return fmt.Errorf("failed to generate documentation: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "✓ Generated %s documentation: %s\n", format, outputFile)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(docsCmd)
+
+	docsCmd.Flags().StringSliceVarP(&docsInputFiles, "input", "i", []string{}, "Input CUE specification (file path, URI or Git source) - can be specified multiple times")
+	docsCmd.Flags().StringVarP(&docsOutputFile, "output", "o", "", "Output file (default: standard output)")
+	docsCmd.Flags().StringVarP(&docsFormat, "format", "f", string(docgen.FormatMarkdown), "Output format: markdown or asciidoc")
+	docsCmd.Flags().StringVar(&docsTemplates, "templates", "", "Glob pattern of template files redefining the blocks of the built-in templates")
+
+	viper.BindPFlag("docs.input", docsCmd.Flags().Lookup("input"))
+	viper.BindPFlag("docs.output", docsCmd.Flags().Lookup("output"))
+	viper.BindPFlag("docs.format", docsCmd.Flags().Lookup("format"))
+	viper.BindPFlag("docs.templates", docsCmd.Flags().Lookup("templates"))
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/jycamier/promener/internal/validator"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// envCmd represents the env command
+var envCmd = &cobra.Command{
+	Use:   "env [file.cue]",
+	Short: "List the environment variables read by the const labels of a specification",
+	Long: `List the environment variables referenced by the const labels of a specification
+with ${VAR} or ${VAR:default}, with their defaults and the const labels using them.
+
+A variable is required when at least one const label uses it without a default: the
+generated .NET and Node.js code throws when the metric is recorded while it is unset,
+and the generated Go code uses an empty value. Check the deployment manifests against this list.
+
+Examples:
+  promener env metrics.cue
+  promener env metrics.cue --format json | jq -r '.[] | select(.required) | .name'`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var cuePath string
+		if len(args) > 0 {
+			cuePath = args[0]
+		} else {
+			cuePath = viper.GetString("input")
+		}
+
+		if cuePath == "" {
+			return fmt.Errorf("input file is required (as argument, via --input flag or config file)")
+		}
+
+		format := viper.GetString("env.format")
+		if format != "text" && format != "json" {
+			return fmt.Errorf("unsupported format: %s (use text or json)", format)
+		}
+
+		specPath, cleanup, err := resolveInput(cuePath)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+
+		spec, result, err := validator.New().ValidateAndExtract(specPath)
+		if err != nil {
+			if result != nil && result.HasErrors() {
+				output, _ := validator.NewFormatter(validator.FormatText).Format(result)
+				fmt.Fprint(os.Stderr, output)
+			}
+			return err
+		}
+
+		usages := spec.EnvVars()
+		if format == "json" {
+			data, err := json.MarshalIndent(usages, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal environment variables: %w", err)
+			}
+			fmt.Println(string(data))
+			return nil
+		}
+
+		if len(usages) == 0 {
+			fmt.Println("✓ No environment variable referenced by the const labels")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tREQUIRED\tDEFAULTS\tCONST LABELS")
+		for _, usage := range usages {
+			required := "no"
+			if usage.Required {
+				required = "yes"
+			}
+			defaults := strings.Join(usage.Defaults, ", ")
+			if defaults == "" {
+				defaults = "-"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", usage.Name, required, defaults, strings.Join(usage.Labels, ", "))
+		}
+		return w.Flush()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(envCmd)
+
+	envCmd.Flags().String("format", "text", "Output format: text or json")
+	viper.BindPFlag("env.format", envCmd.Flags().Lookup("format"))
+}
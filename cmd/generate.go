@@ -43,7 +43,7 @@ func init() {
 	rootCmd.AddCommand(generateCmd)
 
 	// Persistent flags available to all subcommands
-	generateCmd.PersistentFlags().StringVarP(&inputFile, "input", "i", "", "Input CUE specification file")
+	generateCmd.PersistentFlags().StringVarP(&inputFile, "input", "i", "", "Input CUE specification (file path, URI or Git source)")
 	generateCmd.PersistentFlags().StringVarP(&outputDir, "output", "o", "", "Output directory")
 
 	viper.BindPFlag("input", generateCmd.PersistentFlags().Lookup("input"))
@@ -14,6 +14,7 @@ import (
 var (
 	dotnetNamespace  string
 	dotnetGenerateDI bool
+	dotnetMiddleware bool
 )
 
 // dotnetCmd represents the dotnet command
@@ -22,28 +23,39 @@ var dotnetCmd = &cobra.Command{
 	Short: "Generate .NET code for Prometheus metrics",
 	Long: `Generate .NET code for Prometheus metrics from a CUE specification file.
 Generates Metrics.cs and optionally Metrics.DependencyInjection.cs in the output directory.
+With --middleware, also generates ASP.NET Core middleware (MetricsMiddleware.cs) from the
+golden signals declaring an http label mapping.
 
 Examples:
   promener generate dotnet -i metrics.cue -o ./out
-  promener generate dotnet -i metrics.cue -o ./out --di`,
+  promener generate dotnet -i metrics.cue -o ./out --di
+  promener generate dotnet -i metrics.cue -o ./out --di --middleware`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Get values from Viper
 		inputFile := viper.GetString("input")
 		outputDir := viper.GetString("output")
 		packageName := viper.GetString("dotnet.package")
 		di := viper.GetBool("dotnet.di")
+		middleware := viper.GetBool("dotnet.middleware")
 
 		// Create output directory if it doesn't exist
 		if err := os.MkdirAll(outputDir, 0755); err != nil {
 			return fmt.Errorf("failed to create output directory: %w", err)
 		}
 
+		// Fetch remote specifications (URIs, Git and archive sources)
+		specPath, cleanup, err := resolveInput(inputFile)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+
 		// Validate and extract the CUE specification
-		v := validator.New()
-		if rules := viper.GetStringSlice("rules"); len(rules) > 0 {
-			v.SetRulesDirs(rules)
+		v, err := newValidator(viper.GetStringSlice("rules"))
+		if err != nil {
+			return err
 		}
-		spec, result, err := v.ValidateAndExtract(inputFile)
+		spec, result, err := v.ValidateAndExtract(specPath)
 		threshold := viper.GetString("severity_on_error")
 
 		if err != nil || result.Failed(threshold) {
@@ -82,6 +94,13 @@ Examples:
 			}
 		}
 
+		// Generate HTTP middleware if requested
+		if middleware {
+			if err := g.GenerateMiddleware(spec); err != nil {
+				return fmt.Errorf("failed to generate middleware: %w", err)
+			}
+		}
+
 		return nil
 	},
 }
@@ -91,7 +110,9 @@ func init() {
 
 	dotnetCmd.Flags().StringVarP(&dotnetNamespace, "package", "p", "", "Override namespace (optional)")
 	dotnetCmd.Flags().BoolVar(&dotnetGenerateDI, "di", false, "Generate dependency injection extensions (optional)")
+	dotnetCmd.Flags().BoolVar(&dotnetMiddleware, "middleware", false, "Generate ASP.NET Core middleware from golden signals with an http label mapping (optional)")
 
 	viper.BindPFlag("dotnet.package", dotnetCmd.Flags().Lookup("package"))
 	viper.BindPFlag("dotnet.di", dotnetCmd.Flags().Lookup("di"))
+	viper.BindPFlag("dotnet.middleware", dotnetCmd.Flags().Lookup("middleware"))
 }
@@ -12,9 +12,18 @@ import (
 )
 
 var (
-	goPackageName string
-	goGenerateDI  bool
-	goGenerateFx  bool
+	goPackageName        string
+	goGenerateDI         bool
+	goGenerateFx         bool
+	goGenerateWire       bool
+	goGenerateDig        bool
+	goTestHelpers        bool
+	goMiddleware         bool
+	goMiddlewareAdapters []string
+	goGRPC               bool
+	goOpenMetrics        bool
+	goImportPath         string
+	goValidationCache    int
 )
 
 // goCmd represents the go command
@@ -22,11 +31,26 @@ var goCmd = &cobra.Command{
 	Use:   "go",
 	Short: "Generate Go code for Prometheus metrics",
 	Long: `Generate Go code for Prometheus metrics from a CUE specification file.
-Generates metrics.go and optionally metrics_fx.go in the output directory.
+Generates metrics.go and optionally fx.go, wire.go or dig.go in the output directory.
+With --test-helpers, also generates a metricstest package with recording fakes.
+With --middleware, also generates net/http middleware from the golden signals
+declaring an http label mapping (plus chi/gin adapters with --middleware-adapters).
+With --grpc, also generates gRPC server and client interceptors feeding the
+metrics declaring a gRPC role (e.g. role: "grpc_server_handled").
+With --openmetrics, also generates an http.Handler serving the metrics in the
+OpenMetrics format with the # UNIT metadata of the metrics declaring a unit.
+With --validation-cache, label validators using a regexp or CEL remember the
+last accepted values in an LRU cache of the given size.
 
 Examples:
   promener generate go -i metrics.cue -o ./out
-  promener generate go -i metrics.cue -o ./out --di --fx`,
+  promener generate go -i metrics.cue -o ./out --di --fx
+  promener generate go -i metrics.cue -o ./out --di --wire
+  promener generate go -i metrics.cue -o ./out --test-helpers
+  promener generate go -i metrics.cue -o ./out --middleware --middleware-adapters chi
+  promener generate go -i metrics.cue -o ./out --grpc
+  promener generate go -i metrics.cue -o ./out --openmetrics
+  promener generate go -i metrics.cue -o ./out --validation-cache 256`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Get values from Viper
 		inputFile := viper.GetString("input")
@@ -34,10 +58,38 @@ Examples:
 		packageName := viper.GetString("go.package")
 		di := viper.GetBool("go.di")
 		fx := viper.GetBool("go.fx")
+		wire := viper.GetBool("go.wire")
+		dig := viper.GetBool("go.dig")
+		testHelpers := viper.GetBool("go.test_helpers")
+		importPath := viper.GetString("go.import_path")
+		middleware := viper.GetBool("go.middleware")
+		middlewareAdapters := viper.GetStringSlice("go.middleware_adapters")
+		grpc := viper.GetBool("go.grpc")
+		openMetrics := viper.GetBool("go.openmetrics")
+		validationCache := viper.GetInt("go.validation_cache")
 
 		// Validate DI flags
-		if di && !fx {
-			return fmt.Errorf("--di requires a DI framework flag (--fx)")
+		var frameworks []generator.DIFramework
+		if fx {
+			frameworks = append(frameworks, generator.DIFrameworkFx)
+		}
+		if wire {
+			frameworks = append(frameworks, generator.DIFrameworkWire)
+		}
+		if dig {
+			frameworks = append(frameworks, generator.DIFrameworkDig)
+		}
+		if di && len(frameworks) == 0 {
+			return fmt.Errorf("--di requires a DI framework flag (--fx, --wire or --dig)")
+		}
+		if len(frameworks) > 1 {
+			return fmt.Errorf("only one DI framework flag can be used (--fx, --wire or --dig)")
+		}
+		if len(middlewareAdapters) > 0 && !middleware {
+			return fmt.Errorf("--middleware-adapters requires --middleware")
+		}
+		if validationCache < 0 {
+			return fmt.Errorf("--validation-cache must not be negative, got %d", validationCache)
 		}
 
 		// Create output directory if it doesn't exist
@@ -45,12 +97,19 @@ Examples:
 			return fmt.Errorf("failed to create output directory: %w", err)
 		}
 
+		// Fetch remote specifications (URIs, Git and archive sources)
+		specPath, cleanup, err := resolveInput(inputFile)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+
 		// Validate and extract the CUE specification
-		v := validator.New()
-		if rules := viper.GetStringSlice("rules"); len(rules) > 0 {
-			v.SetRulesDirs(rules)
+		v, err := newValidator(viper.GetStringSlice("rules"))
+		if err != nil {
+			return err
 		}
-		spec, result, err := v.ValidateAndExtract(inputFile)
+		spec, result, err := v.ValidateAndExtract(specPath)
 		threshold := viper.GetString("severity_on_error")
 
 		if err != nil || result.Failed(threshold) {
@@ -75,16 +134,44 @@ Examples:
 		if err != nil {
 			return err
 		}
+		golangGenerator.SetValidationCacheSize(validationCache)
 		err = golangGenerator.GenerateMetrics(spec)
 		if err != nil {
 			return err
 		}
-		if di && fx {
+		if di {
+			golangGenerator.SetDIFramework(frameworks[0])
 			err = golangGenerator.GenerateDI(spec)
 			if err != nil {
 				return err
 			}
 		}
+		if middleware {
+			golangGenerator.SetMiddlewareAdapters(middlewareAdapters)
+			err = golangGenerator.GenerateMiddleware(spec)
+			if err != nil {
+				return err
+			}
+		}
+		if grpc {
+			err = golangGenerator.GenerateGRPC(spec)
+			if err != nil {
+				return err
+			}
+		}
+		if openMetrics {
+			err = golangGenerator.GenerateOpenMetrics(spec)
+			if err != nil {
+				return err
+			}
+		}
+		if testHelpers {
+			golangGenerator.SetImportPath(importPath)
+			err = golangGenerator.GenerateTestHelpers(spec)
+			if err != nil {
+				return err
+			}
+		}
 
 		return nil
 	},
@@ -96,8 +183,26 @@ func init() {
 	goCmd.Flags().StringVarP(&goPackageName, "package", "p", "", "Override package name (optional)")
 	goCmd.Flags().BoolVar(&goGenerateDI, "di", false, "Generate dependency injection code (requires a DI framework flag)")
 	goCmd.Flags().BoolVar(&goGenerateFx, "fx", false, "Use Uber FX framework for DI (use with --di)")
+	goCmd.Flags().BoolVar(&goGenerateWire, "wire", false, "Use Google Wire for DI (use with --di)")
+	goCmd.Flags().BoolVar(&goGenerateDig, "dig", false, "Use Uber dig container for DI (use with --di)")
+	goCmd.Flags().BoolVar(&goTestHelpers, "test-helpers", false, "Generate a metricstest package with recording fakes and assertions (optional)")
+	goCmd.Flags().BoolVar(&goMiddleware, "middleware", false, "Generate net/http middleware from golden signals with an http label mapping (optional)")
+	goCmd.Flags().StringSliceVar(&goMiddlewareAdapters, "middleware-adapters", nil, "Router adapters to generate with the middleware: chi, gin (optional)")
+	goCmd.Flags().BoolVar(&goGRPC, "grpc", false, "Generate gRPC interceptors from metrics declaring a gRPC role (optional)")
+	goCmd.Flags().BoolVar(&goOpenMetrics, "openmetrics", false, "Generate an http.Handler serving the metrics in the OpenMetrics format with their units (optional)")
+	goCmd.Flags().IntVar(&goValidationCache, "validation-cache", 0, "Size of the LRU cache of accepted values in regexp and CEL label validators, 0 to disable (optional)")
+	goCmd.Flags().StringVar(&goImportPath, "import-path", "", "Go import path of the output package (default: resolved from go.mod)")
 
 	viper.BindPFlag("go.package", goCmd.Flags().Lookup("package"))
 	viper.BindPFlag("go.di", goCmd.Flags().Lookup("di"))
 	viper.BindPFlag("go.fx", goCmd.Flags().Lookup("fx"))
+	viper.BindPFlag("go.wire", goCmd.Flags().Lookup("wire"))
+	viper.BindPFlag("go.dig", goCmd.Flags().Lookup("dig"))
+	viper.BindPFlag("go.test_helpers", goCmd.Flags().Lookup("test-helpers"))
+	viper.BindPFlag("go.import_path", goCmd.Flags().Lookup("import-path"))
+	viper.BindPFlag("go.middleware", goCmd.Flags().Lookup("middleware"))
+	viper.BindPFlag("go.middleware_adapters", goCmd.Flags().Lookup("middleware-adapters"))
+	viper.BindPFlag("go.grpc", goCmd.Flags().Lookup("grpc"))
+	viper.BindPFlag("go.openmetrics", goCmd.Flags().Lookup("openmetrics"))
+	viper.BindPFlag("go.validation_cache", goCmd.Flags().Lookup("validation-cache"))
 }
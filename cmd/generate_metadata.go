@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jycamier/promener/internal/catalog"
+	"github.com/jycamier/promener/internal/validator"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// metadataCmd represents the metadata command
+var metadataCmd = &cobra.Command{
+	Use:   "metadata",
+	Short: "Generate a machine-readable catalog of the metrics",
+	Long: `Generate a machine-readable catalog of the metrics from a CUE specification file.
+Generates in the output directory:
+  - metadata.json: every metric with its full name, owning service, labels, const labels,
+    deprecation, unit, stability, owner and SLO
+  - metadata.txt: the # HELP, # TYPE and # UNIT lines of every metric family in the
+    OpenMetrics text format
+
+Examples:
+  promener generate metadata -i metrics.cue -o ./catalog`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Get values from Viper
+		inputFile := viper.GetString("input")
+		outputDir := viper.GetString("output")
+
+		// Fetch remote specifications (URIs, Git and archive sources)
+		specPath, cleanup, err := resolveInput(inputFile)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+
+		// Validate and extract the CUE specification
+		v, err := newValidator(viper.GetStringSlice("rules"))
+		if err != nil {
+			return err
+		}
+		spec, result, err := v.ValidateAndExtract(specPath)
+		threshold := viper.GetString("severity_on_error")
+
+		if err != nil || result.Failed(threshold) {
+			if result != nil && result.HasErrors() {
+				// Format validation errors
+				formatter := validator.NewFormatter(validator.FormatText)
+				output, _ := formatter.Format(result)
+				fmt.Fprint(os.Stderr, output)
+			}
+			if result != nil && result.Failed(threshold) {
+				return fmt.Errorf("failed to validate specification (threshold: %s)", threshold)
+			}
+			return fmt.Errorf("failed to validate specification: %w", err)
+		}
+
+		if err := catalog.Build(spec).WriteFiles(outputDir); err != nil {
+			return fmt.Errorf("failed to generate metadata: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	generateCmd.AddCommand(metadataCmd)
+}
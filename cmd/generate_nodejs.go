@@ -13,6 +13,7 @@ import (
 
 var (
 	nodejsPackageName string
+	nodejsMiddleware  bool
 )
 
 // nodejsCmd represents the nodejs command
@@ -21,27 +22,38 @@ var nodejsCmd = &cobra.Command{
 	Short: "Generate Node.js code for Prometheus metrics",
 	Long: `Generate Node.js/TypeScript code for Prometheus metrics from a CUE specification file.
 Generates metrics.ts in the output directory.
+With --middleware, also generates Express middleware and Fastify hooks (middleware.ts)
+from the golden signals declaring an http label mapping.
 
 Examples:
   promener generate nodejs -i metrics.cue -o ./out
-  promener generate nodejs -i metrics.cue -o ./out -p myapp`,
+  promener generate nodejs -i metrics.cue -o ./out -p myapp
+  promener generate nodejs -i metrics.cue -o ./out --middleware`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Get values from Viper
 		inputFile := viper.GetString("input")
 		outputDir := viper.GetString("output")
 		packageName := viper.GetString("nodejs.package")
+		middleware := viper.GetBool("nodejs.middleware")
 
 		// Create output directory if it doesn't exist
 		if err := os.MkdirAll(outputDir, 0755); err != nil {
 			return fmt.Errorf("failed to create output directory: %w", err)
 		}
 
+		// Fetch remote specifications (URIs, Git and archive sources)
+		specPath, cleanup, err := resolveInput(inputFile)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+
 		// Validate and extract the CUE specification
-		v := validator.New()
-		if rules := viper.GetStringSlice("rules"); len(rules) > 0 {
-			v.SetRulesDirs(rules)
+		v, err := newValidator(viper.GetStringSlice("rules"))
+		if err != nil {
+			return err
 		}
-		spec, result, err := v.ValidateAndExtract(inputFile)
+		spec, result, err := v.ValidateAndExtract(specPath)
 		threshold := viper.GetString("severity_on_error")
 
 		if err != nil || result.Failed(threshold) {
@@ -73,6 +85,13 @@ Examples:
 			return fmt.Errorf("failed to generate code: %w", err)
 		}
 
+		// Generate HTTP middleware if requested
+		if middleware {
+			if err := g.GenerateMiddleware(spec); err != nil {
+				return fmt.Errorf("failed to generate middleware: %w", err)
+			}
+		}
+
 		return nil
 	},
 }
@@ -81,6 +100,8 @@ func init() {
 	generateCmd.AddCommand(nodejsCmd)
 
 	nodejsCmd.Flags().StringVarP(&nodejsPackageName, "package", "p", "", "Override package name (optional)")
+	nodejsCmd.Flags().BoolVar(&nodejsMiddleware, "middleware", false, "Generate Express middleware and Fastify hooks from golden signals with an http label mapping (optional)")
 
 	viper.BindPFlag("nodejs.package", nodejsCmd.Flags().Lookup("package"))
+	viper.BindPFlag("nodejs.middleware", nodejsCmd.Flags().Lookup("middleware"))
 }
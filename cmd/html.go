@@ -3,10 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
-	"io"
-	"net/http"
 	"net/url"
-	"os"
 	"os/signal"
 	"time"
 
@@ -22,6 +19,8 @@ var (
 	htmlInputFiles []string
 	htmlOutputFile string
 	htmlWatch      time.Duration
+	htmlSiteDir    string
+	htmlBaseURL    string
 )
 
 // isURI returns true if the input string is a valid absolute URI
@@ -30,52 +29,62 @@ func isURI(input string) bool {
 	return err == nil && u.IsAbs()
 }
 
-// loadSpecFromInput loads a spec from either a CUE file path or URI
+// loadSpecFromInput loads a spec from a CUE file path, a URI or a Git or archive source
 func loadSpecFromInput(input string, rulesDirs []string) (*domain.Specification, error) {
-	v := validator.New()
-	if len(rulesDirs) > 0 {
-		v.SetRulesDirs(rulesDirs)
+	v, err := newValidator(rulesDirs)
+	if err != nil {
+		return nil, err
 	}
 
-	if isURI(input) {
-		// Download CUE from URI to temporary file
-		resp, err := http.Get(input)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch URI: %w", err)
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("HTTP request failed with status %d", resp.StatusCode)
-		}
+	path, cleanup, err := resolveInput(input)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
 
-		// Create temp file for the downloaded CUE
-		tmpFile, err := os.CreateTemp("", "promener_*.cue")
-		if err != nil {
-			return nil, fmt.Errorf("failed to create temp file: %w", err)
+	spec, result, err := v.ValidateAndExtract(path)
+	if err != nil || result.Failed(viper.GetString("severity_on_error")) {
+		message := "validation failed"
+		if isURI(input) {
+			message += " for URI " + input
 		}
-		defer os.Remove(tmpFile.Name())
-		defer tmpFile.Close()
+		return nil, validationFailed(message, result, err)
+	}
+	return spec, nil
+}
 
-		// Write downloaded content
-		if _, err := io.Copy(tmpFile, resp.Body); err != nil {
-			return nil, fmt.Errorf("failed to write temp file: %w", err)
+// validationFailed returns an error with the formatted validation errors of the result, if any
+func validationFailed(message string, result *validator.ValidationResult, err error) error {
+	if result != nil && result.HasErrors() {
+		if output, formatErr := validator.NewFormatter(validator.FormatText).Format(result); formatErr == nil {
+			message += "\n" + output
 		}
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", message, err)
+	}
+	return fmt.Errorf("%s", message)
+}
 
-		// Validate and extract from temp file
-		spec, result, err := v.ValidateAndExtract(tmpFile.Name())
-		if err != nil || result.HasErrors() {
-			return nil, fmt.Errorf("validation failed for URI %s: %w", input, err)
+// loadSpecs loads the specification of a single input, or aggregates the services of several ones
+func loadSpecs(inputFiles []string, rulesDirs []string) (*domain.Specification, error) {
+	if len(inputFiles) == 1 {
+		spec, err := loadSpecFromInput(inputFiles[0], rulesDirs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load spec: %w", err)
 		}
 		return spec, nil
 	}
 
-	// Local file
-	spec, result, err := v.ValidateAndExtract(input)
-	if err != nil || result.HasErrors() {
-		return nil, fmt.Errorf("validation failed: %w", err)
+	builder := htmlgen.NewBuilder("Aggregated Metrics", "1.0.0")
+	for _, inputFile := range inputFiles {
+		spec, err := loadSpecFromInput(inputFile, rulesDirs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load spec %s: %w", inputFile, err)
+		}
+		builder.AddFromSpec(spec)
 	}
-	return spec, nil
+	return builder.Spec(), nil
 }
 
 // htmlCmd represents the html command
@@ -92,7 +101,14 @@ The HTML documentation includes:
 - Alertmanager alert rule examples
 - Detailed label descriptions
 
-Input sources can be local CUE files or URIs (http/https).
+Input sources can be local CUE files, URIs (http/https), or files of Git
+repositories and archives, e.g. github:org/specs@v1.2.0//services/orders.cue.
+
+With --site, a static multi-page site is generated in a directory instead of a
+single HTML file: an index of the services, one page per service and one page
+per metric with a permalink (services/<service>/metrics/<metric>/), breadcrumbs,
+a client-side search index and, with --base-url, a sitemap. Large aggregated
+specifications stay fast to load, and metric pages can be linked from alerts.
 
 Examples:
   # Single file
@@ -107,6 +123,9 @@ Examples:
   # Mix of files and URIs
   promener html -i metrics.cue -i https://example.com/remote.cue -o docs/metrics.html
 
+  # Static site, with a sitemap of its absolute URLs
+  promener html -i api.cue -i users.cue --site out/ --base-url https://metrics.example.com/
+
   # With watch mode
   promener html -i metrics.cue -o docs/metrics.html --watch 5s
   promener html -i api.cue -i users.cue -o docs/metrics.html --watch 5s`,
@@ -114,13 +133,26 @@ Examples:
 		inputFiles := viper.GetStringSlice("html.input")
 		outputFile := viper.GetString("html.output")
 		watch := viper.GetDuration("html.watch")
+		siteDir := viper.GetString("html.site")
+		baseURL := viper.GetString("html.base_url")
 		rulesDirs := viper.GetStringSlice("rules")
 
 		if len(inputFiles) == 0 {
 			return fmt.Errorf("at least one input file is required (via --input flag or config file)")
 		}
-		if outputFile == "" {
-			return fmt.Errorf("output file is required (via --output flag or config file)")
+		if siteDir != "" && outputFile != "" {
+			return fmt.Errorf("--output and --site are mutually exclusive")
+		}
+		if outputFile == "" && siteDir == "" {
+			return fmt.Errorf("output file is required (via --output flag or config file), or a site directory via --site")
+		}
+
+		output := outputFile
+		if siteDir != "" {
+			output = siteDir
+			if baseURL == "" {
+				fmt.Println("⚠ No --base-url given: sitemap.xml is not generated, the sitemap protocol requires absolute URLs")
+			}
 		}
 
 		generateHTML := func() error {
@@ -134,6 +166,17 @@ Examples:
 					return fmt.Errorf("failed to load spec: %w", err)
 				}
 
+				if siteDir != "" {
+					site, err := htmlgen.NewSiteGenerator(baseURL)
+					if err != nil {
+						return err
+					}
+					if err := site.GenerateSite(spec, siteDir); err != nil {
+						return fmt.Errorf("failed to generate HTML site: %w", err)
+					}
+					return nil
+				}
+
 				generator := htmlgen.NewGenerator()
 				if err := generator.GenerateFile(spec, outputFile); err != nil {
 					return fmt.Errorf("failed to generate HTML: %w", err)
@@ -149,6 +192,13 @@ Examples:
 					builder.AddFromSpec(spec)
 				}
 
+				if siteDir != "" {
+					if err := builder.BuildSite(siteDir, baseURL); err != nil {
+						return fmt.Errorf("failed to generate HTML site: %w", err)
+					}
+					return nil
+				}
+
 				if err := builder.Build(outputFile); err != nil {
 					return fmt.Errorf("failed to generate HTML: %w", err)
 				}
@@ -161,7 +211,7 @@ Examples:
 		if err := generateHTML(); err != nil {
 			return err
 		}
-		fmt.Printf("✓ Generated HTML documentation: %s\n", outputFile)
+		fmt.Printf("✓ Generated HTML documentation: %s\n", output)
 
 		// Watch mode
 		if watch > 0 {
@@ -185,7 +235,7 @@ Examples:
 						fmt.Printf("⚠ Error regenerating HTML: %v\n", err)
 						continue
 					}
-					fmt.Printf("✓ Regenerated HTML documentation: %s (%s)\n", outputFile, time.Now().Format("15:04:05"))
+					fmt.Printf("✓ Regenerated HTML documentation: %s (%s)\n", output, time.Now().Format("15:04:05"))
 				}
 			}
 		}
@@ -197,11 +247,15 @@ Examples:
 func init() {
 	rootCmd.AddCommand(htmlCmd)
 
-	htmlCmd.Flags().StringSliceVarP(&htmlInputFiles, "input", "i", []string{}, "Input CUE specification (file path or URI) - can be specified multiple times")
+	htmlCmd.Flags().StringSliceVarP(&htmlInputFiles, "input", "i", []string{}, "Input CUE specification (file path, URI or Git source) - can be specified multiple times")
 	htmlCmd.Flags().StringVarP(&htmlOutputFile, "output", "o", "", "Output HTML file")
 	htmlCmd.Flags().DurationVar(&htmlWatch, "watch", 0, "Watch for changes and regenerate (e.g., 5s, 1m)")
+	htmlCmd.Flags().StringVar(&htmlSiteDir, "site", "", "Output directory of a static multi-page site, instead of a single HTML file")
+	htmlCmd.Flags().StringVar(&htmlBaseURL, "base-url", "", "Absolute URL the site is published at, required to generate the sitemap (with --site)")
 
 	viper.BindPFlag("html.input", htmlCmd.Flags().Lookup("input"))
 	viper.BindPFlag("html.output", htmlCmd.Flags().Lookup("output"))
 	viper.BindPFlag("html.watch", htmlCmd.Flags().Lookup("watch"))
+	viper.BindPFlag("html.site", htmlCmd.Flags().Lookup("site"))
+	viper.BindPFlag("html.base_url", htmlCmd.Flags().Lookup("base-url"))
 }
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jycamier/promener/internal/migrate"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var migrateWrite bool
+
+// migrateCmd represents the migrate command
+var migrateCmd = &cobra.Command{
+	Use:   "migrate [file.cue]",
+	Short: "Upgrade a v1 Promener CUE specification to the schema v2",
+	Long: `Upgrade a Promener metrics specification from the schema v1 to the schema v2.
+
+The migration sets the version to 2.0.0 and declares the unit of the metrics
+whose name ends with a base unit (e.g. _seconds, or _bytes_total for counters),
+keeping the comments of the file. The migrated specification is printed on the
+standard output, or written back to the file with --write.
+
+Changes that cannot be made automatically (e.g. a unit that is not a base unit,
+or metrics imported from a CUE module) are reported on the standard error.
+
+Examples:
+  promener migrate metrics.cue > metrics.v2.cue
+  promener migrate metrics.cue --write`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var cuePath string
+		if len(args) > 0 {
+			cuePath = args[0]
+		} else {
+			cuePath = viper.GetString("input")
+		}
+
+		if cuePath == "" {
+			return fmt.Errorf("input file is required (as argument, via --input flag or config file)")
+		}
+
+		result, err := migrate.V1ToV2(cuePath)
+		if err != nil {
+			return err
+		}
+
+		for _, change := range result.Changes {
+			fmt.Fprintf(os.Stderr, "✓ %s\n", change)
+		}
+		for _, warning := range result.Warnings {
+			fmt.Fprintf(os.Stderr, "⚠ %s\n", warning)
+		}
+
+		if !migrateWrite {
+			fmt.Print(string(result.Source))
+			return nil
+		}
+
+		if err := os.WriteFile(cuePath, result.Source, 0644); err != nil {
+			return fmt.Errorf("failed to write the migrated specification: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "✓ Migrated %s to the schema v2\n", cuePath)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+
+	migrateCmd.Flags().BoolVarP(&migrateWrite, "write", "w", false, "Write the migrated specification back to the file instead of the standard output")
+}
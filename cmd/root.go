@@ -13,6 +13,8 @@ var (
 	cfgFile         string
 	rulesDirs       []string
 	severityOnError string
+	offline         bool
+	rulesQueries    []string
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -50,11 +52,15 @@ func init() {
 	cobra.OnInitialize(initConfig)
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is .promener.yaml)")
-	rootCmd.PersistentFlags().StringSliceVar(&rulesDirs, "rules", nil, "directories containing Rego rules for validation (repeatable)")
+	rootCmd.PersistentFlags().StringSliceVar(&rulesDirs, "rules", nil, "directories containing Rego rules for validation, or builtin for the embedded rules (repeatable)")
 	rootCmd.PersistentFlags().StringVar(&severityOnError, "severity-on-error", "error", "minimum severity level to trigger exit 1 (error, warning, info)")
+	rootCmd.PersistentFlags().StringSliceVar(&rulesQueries, "rules-query", nil, "Rego queries returning the policy results, e.g. data.acme.metrics.violations (repeatable, default data.PromenerPolicy.PromenerPolicy)")
+	rootCmd.PersistentFlags().BoolVar(&offline, "offline", false, "only use the cached content of remote rule and specification sources")
 
 	viper.BindPFlag("rules", rootCmd.PersistentFlags().Lookup("rules"))
 	viper.BindPFlag("severity_on_error", rootCmd.PersistentFlags().Lookup("severity-on-error"))
+	viper.BindPFlag("rules_queries", rootCmd.PersistentFlags().Lookup("rules-query"))
+	viper.BindPFlag("offline", rootCmd.PersistentFlags().Lookup("offline"))
 }
 
 func initConfig() {
@@ -0,0 +1,311 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jycamier/promener/internal/validator"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// rulesCmd represents the rules command
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "Manage the Rego rule sources",
+	Long: `Manage the Rego rule sources given with --rules or in the configuration file.
+
+Rules are tested with the test_ rules of their *_test.rego files, scaffolded with
+rules new, and written against the input document printed by rules input.
+
+Remote sources (Git repositories and HTTP downloads) are pinned in the promener.lock
+file, next to the configuration file: the commit of each Git source and the
+SHA-256 of each download. When the lock file exists, every command validating a
+specification rejects remote content that does not match it.`,
+}
+
+// rulesUpdateCmd represents the rules update command
+var rulesUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Fetch the remote rule sources and pin them in the lock file",
+	Long: `Fetch the latest content of the remote rule sources, ignoring the cache, and write
+the resolved commits and SHA-256 digests to the promener.lock file.
+
+Commit the lock file so that every build uses the same rules.
+
+Examples:
+  promener rules update
+  promener rules update --rules github:myorg/shared-rules@v1.0.0`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sources := viper.GetStringSlice("rules")
+		if len(sources) == 0 {
+			return fmt.Errorf("no rule source configured (via --rules flag or config file)")
+		}
+
+		resolver := validator.NewRuleSourceResolver()
+		resolver.SetCacheTTL(0)
+		for _, source := range sources {
+			if _, err := resolver.Load(context.Background(), source); err != nil {
+				return fmt.Errorf("failed to load rules from %s: %w", source, err)
+			}
+		}
+
+		lock := resolver.Resolved()
+		if len(lock.Sources) == 0 {
+			fmt.Println("✓ No remote rule source to lock")
+			return nil
+		}
+
+		path := lockPath()
+		if err := lock.Write(path); err != nil {
+			return err
+		}
+		for _, source := range lock.Sources {
+			fmt.Printf("✓ Locked %s at %s\n", source.Source, lockedContent(source))
+		}
+		fmt.Printf("✓ Wrote %s\n", path)
+		return nil
+	},
+}
+
+// rulesVerifyCmd represents the rules verify command
+var rulesVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check that the remote rule sources match the lock file",
+	Long: `Fetch the remote rule sources again, ignoring the cache, and check that their
+commits and SHA-256 digests match the promener.lock file. A moved branch or tag,
+or a modified archive, makes the verification fail.
+
+With --offline, the cached content is checked instead.
+
+Examples:
+  promener rules verify
+  promener rules verify --offline`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sources := viper.GetStringSlice("rules")
+		path := lockPath()
+
+		lock, err := readLock()
+		if err != nil {
+			return err
+		}
+		if lock == nil {
+			return fmt.Errorf("no lock file found at %s, run promener rules update", path)
+		}
+
+		resolver := validator.NewRuleSourceResolver()
+		resolver.SetCacheTTL(0)
+		resolver.SetOffline(viper.GetBool("offline"))
+		resolver.SetLock(lock)
+
+		failed := false
+		for _, source := range sources {
+			if _, err := resolver.Load(context.Background(), source); err != nil {
+				fmt.Printf("✗ %v\n", err)
+				failed = true
+			}
+		}
+
+		resolved := resolver.Resolved()
+		for _, locked := range lock.Sources {
+			if resolved.Find(locked.Source) == nil && !failed {
+				fmt.Printf("⚠ %s is locked but not used, run promener rules update\n", locked.Source)
+			}
+		}
+
+		if failed {
+			return fmt.Errorf("rule sources do not match %s", path)
+		}
+		fmt.Printf("✓ %d remote rule sources match %s\n", len(resolved.Sources), path)
+		return nil
+	},
+}
+
+// rulesTestCmd represents the rules test command
+var rulesTestCmd = &cobra.Command{
+	Use:   "test [sources...]",
+	Short: "Run the tests of the Rego rules",
+	Long: `Run the test_ rules of the Rego rule sources, usually written in *_test.rego files
+next to the rules, like opa test. The sources are the ones given with --rules or
+in the configuration file when none is given as argument.
+
+A test passes when all its expressions are true, e.g. a count of the results of
+PromenerPolicy with a mock input:
+
+  test_histogram_invalid if {
+      count(PromenerPolicy) == 1 with input as mock_input
+  }
+
+Use promener rules input to print the input document of a specification.
+
+Examples:
+  promener rules test
+  promener rules test ./rules
+  promener rules test ./rules --run histogram -v`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sources := args
+		if len(sources) == 0 {
+			sources = viper.GetStringSlice("rules")
+		}
+		if len(sources) == 0 {
+			return fmt.Errorf("no rule source configured (as argument, via --rules flag or config file)")
+		}
+
+		v, err := newValidator(sources)
+		if err != nil {
+			return err
+		}
+		results, err := v.TestRules(context.Background(), viper.GetString("rules_test.run"))
+		if err != nil {
+			return err
+		}
+		if len(results) == 0 {
+			fmt.Println("⚠ No rule test found")
+			return nil
+		}
+
+		verbose := viper.GetBool("rules_test.verbose")
+		passed, failed := 0, 0
+		for _, result := range results {
+			name := fmt.Sprintf("%s.%s", result.Package, result.Name)
+			switch {
+			case result.Skip:
+				fmt.Printf("⚠ SKIP %s (%s)\n", name, result.Location)
+				continue
+			case result.Pass():
+				passed++
+				if !verbose {
+					continue
+				}
+				fmt.Printf("✓ PASS %s (%s)\n", name, result.Location)
+			case result.Error != nil:
+				failed++
+				fmt.Printf("✗ ERROR %s (%s)\n  %v\n", name, result.Location, result.Error)
+			default:
+				failed++
+				fmt.Printf("✗ FAIL %s (%s)\n", name, result.Location)
+			}
+			if len(result.Output) > 0 {
+				fmt.Printf("  %s\n", strings.ReplaceAll(strings.TrimSpace(string(result.Output)), "\n", "\n  "))
+			}
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d rule tests failed", failed, passed+failed)
+		}
+		fmt.Printf("✓ %d rule tests passed\n", passed)
+		return nil
+	},
+}
+
+// rulesNewCmd represents the rules new command
+var rulesNewCmd = &cobra.Command{
+	Use:   "new <rule-id>",
+	Short: "Scaffold a Rego rule and its test",
+	Long: `Create a Rego rule and its test from a template, in the PromenerPolicy package.
+The rule iterates over the metrics with the get_metrics_common helper of
+utils.rego, which is written too when the directory does not have it.
+
+The rule id, in kebab-case, is returned in the rule_id of the results, so that
+the rule can be suppressed with @promener(ignore=<rule-id>). The files are named
+after it in snake_case and are never overwritten.
+
+Examples:
+  promener rules new gauge-count-suffix
+  promener rules new owner-required --dir ./policies --category ownership --severity error`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		files, err := validator.ScaffoldRule(viper.GetString("rules_new.dir"), validator.RuleScaffold{
+			RuleID:   args[0],
+			Category: viper.GetString("rules_new.category"),
+			Severity: viper.GetString("rules_new.severity"),
+		})
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			fmt.Printf("✓ Created %s\n", file)
+		}
+		return nil
+	},
+}
+
+// rulesInputCmd represents the rules input command
+var rulesInputCmd = &cobra.Command{
+	Use:   "input [file.cue]",
+	Short: "Print the input document of the Rego rules for a specification",
+	Long: `Print the JSON document given as input to the Rego rules when validating a
+specification: the specification exported from CUE, with the defaults of the
+schema applied. Use it to write rules and the mock inputs of their tests.
+
+Examples:
+  promener rules input metrics.cue
+  promener rules input metrics.cue | jq '.services[].metrics | keys'`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var cuePath string
+		if len(args) > 0 {
+			cuePath = args[0]
+		} else {
+			cuePath = viper.GetString("input")
+		}
+
+		if cuePath == "" {
+			return fmt.Errorf("input file is required (as argument, via --input flag or config file)")
+		}
+
+		specPath, cleanup, err := resolveInput(cuePath)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+
+		input, result, err := validator.New().RegoInput(specPath)
+		if err != nil {
+			if result != nil && result.HasErrors() {
+				output, _ := validator.NewFormatter(validator.FormatText).Format(result)
+				fmt.Fprint(os.Stderr, output)
+			}
+			return err
+		}
+
+		data, err := json.MarshalIndent(input, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal input: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	},
+}
+
+// lockedContent describes the pinned content of a source
+func lockedContent(source validator.LockedSource) string {
+	if source.Commit != "" {
+		return "commit " + source.Commit
+	}
+	return "sha256 " + source.SHA256
+}
+
+func init() {
+	rootCmd.AddCommand(rulesCmd)
+	rulesCmd.AddCommand(rulesUpdateCmd)
+	rulesCmd.AddCommand(rulesVerifyCmd)
+	rulesCmd.AddCommand(rulesTestCmd)
+	rulesCmd.AddCommand(rulesNewCmd)
+	rulesCmd.AddCommand(rulesInputCmd)
+
+	rulesTestCmd.Flags().String("run", "", "Run only the tests whose package and name match this regular expression")
+	rulesTestCmd.Flags().BoolP("verbose", "v", false, "Print the passing tests too")
+	viper.BindPFlag("rules_test.run", rulesTestCmd.Flags().Lookup("run"))
+	viper.BindPFlag("rules_test.verbose", rulesTestCmd.Flags().Lookup("verbose"))
+
+	rulesNewCmd.Flags().String("dir", "rules", "Directory of the rule files")
+	rulesNewCmd.Flags().String("category", "custom", "Category of the rule results")
+	rulesNewCmd.Flags().String("severity", "warning", "Severity of the rule results: error, warning or info")
+	viper.BindPFlag("rules_new.dir", rulesNewCmd.Flags().Lookup("dir"))
+	viper.BindPFlag("rules_new.category", rulesNewCmd.Flags().Lookup("category"))
+	viper.BindPFlag("rules_new.severity", rulesNewCmd.Flags().Lookup("severity"))
+}
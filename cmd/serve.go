@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/jycamier/promener/internal/docserver"
+	"github.com/jycamier/promener/internal/htmlgen"
+	"github.com/jycamier/promener/internal/signals"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	serveInputFiles []string
+	serveAddr       string
+)
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the HTML documentation with live reload",
+	Long: `Serve the HTML documentation of your Prometheus metrics over HTTP while you edit
+the specifications.
+
+The directories of the local inputs are watched: when a CUE file changes, the
+documentation is rebuilt and the open browsers reload, only if the page changed.
+Validation errors are shown in an overlay on top of the last successful build.
+URI inputs are loaded at each rebuild but not watched.
+
+Examples:
+  promener serve -i metrics.cue
+  promener serve -i api.cue -i users.cue --addr :8080`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		inputFiles := viper.GetStringSlice("serve.input")
+		addr := viper.GetString("serve.addr")
+		rulesDirs := viper.GetStringSlice("rules")
+
+		if len(inputFiles) == 0 {
+			return fmt.Errorf("at least one input file is required (via --input flag or config file)")
+		}
+
+		generator := htmlgen.NewGenerator()
+		build := func() ([]byte, error) {
+			spec, err := loadSpecs(inputFiles, rulesDirs)
+			if err != nil {
+				return nil, err
+			}
+			return generator.Generate(spec)
+		}
+
+		var watched []string
+		for _, input := range inputFiles {
+			if !isURI(input) {
+				watched = append(watched, input)
+			}
+		}
+
+		server := docserver.New(build, watched, os.Stderr)
+		server.Rebuild()
+
+		// Setup context with signal handling for graceful shutdown
+		// Uses platform-specific signals (Unix: SIGINT+SIGTERM, Windows: only SIGINT)
+		ctx, stop := signal.NotifyContext(context.Background(), signals.Shutdown()...)
+		defer stop()
+
+		listener, err := net.Listen("tcp", addr)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", addr, err)
+		}
+
+		// The event streams end with the base context, so that the shutdown does not wait for them
+		httpServer := &http.Server{
+			Handler:           server,
+			ReadHeaderTimeout: 10 * time.Second,
+			BaseContext:       func(net.Listener) context.Context { return ctx },
+		}
+
+		watchErr := make(chan error, 1)
+		go func() { watchErr <- server.Watch(ctx) }()
+
+		serveErr := make(chan error, 1)
+		go func() { serveErr <- httpServer.Serve(listener) }()
+
+		fmt.Printf("✓ Serving documentation on http://%s\n", displayAddr(listener.Addr()))
+		fmt.Printf("👀 Watching for changes... Press Ctrl+C to stop\n")
+
+		select {
+		case err := <-serveErr:
+			if !errors.Is(err, http.ErrServerClosed) {
+				return fmt.Errorf("server failed: %w", err)
+			}
+		case err := <-watchErr:
+			if err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			fmt.Printf("\n✓ Received shutdown signal, stopping server...\n")
+		}
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return httpServer.Shutdown(shutdownCtx)
+	},
+}
+
+// displayAddr returns the address to open in a browser, localhost when listening on all interfaces
+func displayAddr(addr net.Addr) string {
+	tcp, ok := addr.(*net.TCPAddr)
+	if !ok || !tcp.IP.IsUnspecified() {
+		return addr.String()
+	}
+	return fmt.Sprintf("localhost:%d", tcp.Port)
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().StringSliceVarP(&serveInputFiles, "input", "i", []string{}, "Input CUE specification (file path, URI or Git source) - can be specified multiple times")
+	serveCmd.Flags().StringVar(&serveAddr, "addr", ":8080", "Address to listen on")
+
+	viper.BindPFlag("serve.input", serveCmd.Flags().Lookup("input"))
+	viper.BindPFlag("serve.addr", serveCmd.Flags().Lookup("addr"))
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/jycamier/promener/internal/validator"
+	"github.com/spf13/viper"
+)
+
+// newValidator creates a validator with Rego rules and queries, whose remote sources must match the
+// lock file when it exists
+func newValidator(rulesDirs []string) (*validator.Validator, error) {
+	v := validator.New()
+	if len(rulesDirs) > 0 {
+		v.SetRulesDirs(rulesDirs)
+	}
+	if err := v.SetRulesQueries(viper.GetStringSlice("rules_queries")); err != nil {
+		return nil, err
+	}
+
+	lock, err := readLock()
+	if err != nil {
+		return nil, err
+	}
+	v.SetLock(lock)
+	v.SetOffline(viper.GetBool("offline"))
+	return v, nil
+}
+
+// lockPath returns the path of the lock file, next to the configuration file when one is used
+func lockPath() string {
+	if config := viper.ConfigFileUsed(); config != "" {
+		return filepath.Join(filepath.Dir(config), validator.LockFile)
+	}
+	return validator.LockFile
+}
+
+// readLock reads the lock file, nil when it does not exist
+func readLock() (*validator.Lock, error) {
+	lock, err := validator.ReadLock(lockPath())
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return lock, err
+}
+
+// resolveInput returns the local path of a specification input and a function removing its
+// temporary files. Git sources and archives, e.g. github:org/specs@v1.2.0//services/orders.cue,
+// are fetched like Rego rule sources and kept whole, so that the imports and the cue.mod of the
+// specification are found. Other URIs are downloaded to a temporary file.
+func resolveInput(input string) (string, func(), error) {
+	noop := func() {}
+	if !isURI(input) {
+		return input, noop, nil
+	}
+
+	parsed, err := validator.ParseSource(input)
+	if err != nil {
+		return "", noop, err
+	}
+	offline := viper.GetBool("offline")
+	if parsed.Type != validator.SourceGit && !parsed.IsArchive() && parsed.Path == "" {
+		if offline {
+			return "", noop, fmt.Errorf("cannot download %s with --offline", input)
+		}
+		path, err := downloadInput(input)
+		if err != nil {
+			return "", noop, err
+		}
+		return path, func() { os.Remove(path) }, nil
+	}
+
+	resolver := validator.NewRuleSourceResolver()
+	resolver.SetOffline(offline)
+	path, err := resolver.Resolve(context.Background(), input)
+	if err != nil {
+		return "", noop, fmt.Errorf("failed to fetch %s: %w", input, err)
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return "", noop, fmt.Errorf("%s is a directory, add the path of the specification file after //, e.g. %s//metrics.cue", input, input)
+	}
+	return path, noop, nil
+}
+
+// downloadInput downloads a CUE specification from a URI to a temporary file
+func downloadInput(uri string) (string, error) {
+	resp, err := http.Get(uri)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch URI: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("HTTP request failed with status %d", resp.StatusCode)
+	}
+
+	// Create temp file for the downloaded CUE
+	tmpFile, err := os.CreateTemp("", "promener_*.cue")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer tmpFile.Close()
+
+	// Write downloaded content
+	if _, err := io.Copy(tmpFile, resp.Body); err != nil {
+		os.Remove(tmpFile.Name())
+		return "", fmt.Errorf("failed to write temp file: %w", err)
+	}
+	return tmpFile.Name(), nil
+}
@@ -1,15 +1,21 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/jycamier/promener/internal/validator"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
-var vetFormat string
+var (
+	vetFormat    string
+	vetWorkspace bool
+)
 
 // vetCmd represents the vet command
 var vetCmd = &cobra.Command{
@@ -27,6 +33,15 @@ The corresponding embedded schema (e.g., v1, v2) is loaded and used for validati
 The validation results can be output in text (human-readable) or JSON format
 for integration with CI/CD pipelines.
 
+With --workspace, every .cue file of the given files, directories and remote
+sources (the current directory by default) is validated, and the specifications
+are checked together, as when they are aggregated by html or catalog serve:
+  - services defined by several specifications (error)
+  - metrics with the same name but different types or labels (error)
+  - metrics with the same name but a different help, unit, buckets or objectives (warning)
+  - metrics defined identically by several specifications (info), candidates
+    for a shared CUE package
+
 Examples:
   # Validate with text output
   promener vet metrics.cue
@@ -37,11 +52,26 @@ Examples:
   # Validate with JSON output for CI/CD
   promener vet metrics.cue --format json
 
+  # Validate a specification of a Git repository
+  promener vet github:myorg/specs@v1.2.0//services/orders.cue
+
+  # Validate all the specifications of directories and check their conflicts
+  promener vet --workspace ./services github:myorg/platform-specs@v2.0.0
+
   # Exit codes:
   #   0 - validation passed
   #   1 - validation failed`,
-	Args: cobra.MaximumNArgs(1),
+	Args: func(cmd *cobra.Command, args []string) error {
+		if viper.GetBool("vet.workspace") {
+			return nil
+		}
+		return cobra.MaximumNArgs(1)(cmd, args)
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if viper.GetBool("vet.workspace") {
+			return runVetWorkspace(args)
+		}
+
 		var cuePath string
 		if len(args) > 0 {
 			cuePath = args[0]
@@ -62,12 +92,19 @@ Examples:
 			return fmt.Errorf("invalid format: %s (must be 'text' or 'json')", formatStr)
 		}
 
+		// Fetch remote specifications (URIs, Git and archive sources)
+		specPath, cleanup, err := resolveInput(cuePath)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+
 		// Create validator and perform validation
-		v := validator.New()
-		if rules := viper.GetStringSlice("rules"); len(rules) > 0 {
-			v.SetRulesDirs(rules)
+		v, err := newValidator(viper.GetStringSlice("rules"))
+		if err != nil {
+			return err
 		}
-		result, err := v.Validate(cuePath)
+		result, err := v.Validate(specPath)
 
 		// Handle system errors
 		if err != nil && (result == nil || !result.HasErrors()) {
@@ -86,6 +123,7 @@ Examples:
 		// Exit with code 1 if validation failed based on severity threshold
 		threshold := viper.GetString("severity_on_error")
 		if result.Failed(threshold) {
+			cleanup()
 			os.Exit(1)
 		}
 
@@ -93,11 +131,102 @@ Examples:
 	},
 }
 
+// runVetWorkspace validates the specifications of the sources and checks them together
+func runVetWorkspace(sources []string) error {
+	format := validator.OutputFormat(viper.GetString("vet.format"))
+	if format != validator.FormatText && format != validator.FormatJSON {
+		return fmt.Errorf("invalid format: %s (must be 'text' or 'json')", format)
+	}
+	if len(sources) == 0 {
+		sources = []string{"."}
+	}
+
+	v, err := newValidator(viper.GetStringSlice("rules"))
+	if err != nil {
+		return err
+	}
+
+	result := &validator.ValidationResult{}
+	var specs []validator.WorkspaceSpec
+	for _, source := range sources {
+		files, err := workspaceFiles(source)
+		if err != nil {
+			return err
+		}
+		for _, file := range files {
+			spec, fileResult, err := v.ValidateAndExtract(file.path)
+			if err != nil && (fileResult == nil || !fileResult.HasErrors()) {
+				return fmt.Errorf("validation error in %s: %w", file.name, err)
+			}
+			result.Merge(file.name, fileResult)
+			if spec != nil {
+				specs = append(specs, validator.WorkspaceSpec{File: file.name, Spec: spec})
+			}
+		}
+	}
+	if len(specs) == 0 && !result.HasErrors() {
+		return fmt.Errorf("no specification found in %v", sources)
+	}
+	result.WorkspaceErrors = validator.CheckWorkspace(specs)
+
+	output, err := validator.NewFormatter(format).Format(result)
+	if err != nil {
+		return fmt.Errorf("failed to format output: %w", err)
+	}
+	fmt.Print(output)
+
+	if result.Failed(viper.GetString("severity_on_error")) {
+		os.Exit(1)
+	}
+	return nil
+}
+
+// workspaceFile is a specification file of a workspace, named after its source
+type workspaceFile struct {
+	name string
+	path string
+}
+
+// workspaceFiles returns the .cue files of a local path or a remote source. The files of remote
+// sources are named after the source and their path inside it.
+func workspaceFiles(source string) ([]workspaceFile, error) {
+	root := source
+	if isURI(source) {
+		resolver := validator.NewRuleSourceResolver()
+		resolver.SetOffline(viper.GetBool("offline"))
+		path, err := resolver.Resolve(context.Background(), source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s: %w", source, err)
+		}
+		root = path
+	}
+
+	paths, err := collectCueFiles(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", source, err)
+	}
+
+	files := make([]workspaceFile, 0, len(paths))
+	for _, path := range paths {
+		name := path
+		if root != source {
+			name = source
+			if rel, err := filepath.Rel(root, path); err == nil && rel != "." {
+				name = strings.TrimSuffix(source, "/") + "//" + filepath.ToSlash(rel)
+			}
+		}
+		files = append(files, workspaceFile{name: name, path: path})
+	}
+	return files, nil
+}
+
 func init() {
 	rootCmd.AddCommand(vetCmd)
 
 	// Define flags
 	vetCmd.Flags().StringVarP(&vetFormat, "format", "f", "text", "Output format: text or json")
+	vetCmd.Flags().BoolVar(&vetWorkspace, "workspace", false, "Validate all the specifications of the given files, directories and sources, and check their conflicts")
 
 	viper.BindPFlag("vet.format", vetCmd.Flags().Lookup("format"))
+	viper.BindPFlag("vet.workspace", vetCmd.Flags().Lookup("workspace"))
 }
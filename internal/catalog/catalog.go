@@ -0,0 +1,241 @@
+// Package catalog builds a machine-readable catalog of the metrics of a specification, serialized
+// as JSON or as OpenMetrics metadata (# HELP, # TYPE and # UNIT lines), and serves the catalog of
+// several specifications as a JSON REST API.
+package catalog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/jycamier/promener/internal/domain"
+)
+
+const (
+	// JSONFile is the name of the JSON catalog written by WriteFiles
+	JSONFile = "metadata.json"
+	// OpenMetricsFile is the name of the OpenMetrics metadata file written by WriteFiles
+	OpenMetricsFile = "metadata.txt"
+)
+
+// Catalog lists the metrics of a specification
+type Catalog struct {
+	Version string        `json:"version"`
+	Info    InfoEntry     `json:"info"`
+	Metrics []MetricEntry `json:"metrics"`
+}
+
+// InfoEntry describes the specification of the catalog
+type InfoEntry struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// MetricEntry is a metric of the catalog
+type MetricEntry struct {
+	FullName    string            `json:"fullName"`
+	Service     string            `json:"service"`
+	Key         string            `json:"key"` // Key of the metric in the service
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace"`
+	Subsystem   string            `json:"subsystem"`
+	Type        string            `json:"type"`
+	Help        string            `json:"help"`
+	Unit        string            `json:"unit,omitempty"`
+	Labels      []LabelEntry      `json:"labels"`
+	ConstLabels []ConstLabelEntry `json:"constLabels,omitempty"`
+	Deprecated  *DeprecatedEntry  `json:"deprecated,omitempty"`
+	Stability   string            `json:"stability,omitempty"`
+	Owner       *OwnerEntry       `json:"owner,omitempty"`
+	Since       string            `json:"since,omitempty"`
+	SLO         *SLOEntry         `json:"slo,omitempty"`
+}
+
+// LabelEntry is a label of a metric of the catalog
+type LabelEntry struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Inherited   string `json:"inherited,omitempty"`
+	Shared      string `json:"shared,omitempty"` // Name of the shared label of the library
+}
+
+// ConstLabelEntry is a constant label of a metric of the catalog. Value is the raw value of the
+// specification, environment variables (${VAR:default}) are not expanded.
+type ConstLabelEntry struct {
+	Name        string `json:"name"`
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+}
+
+// DeprecatedEntry is the deprecation of a metric of the catalog
+type DeprecatedEntry struct {
+	Since      string `json:"since,omitempty"`
+	ReplacedBy string `json:"replacedBy,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// OwnerEntry is the owner of a metric of the catalog
+type OwnerEntry struct {
+	Team    string `json:"team"`
+	Contact string `json:"contact,omitempty"`
+}
+
+// SLOEntry is the service level objective of a metric of the catalog
+type SLOEntry struct {
+	Objective   float64 `json:"objective"`
+	Window      string  `json:"window"`
+	Description string  `json:"description,omitempty"`
+}
+
+// Build builds the catalog of a specification, sorted by full name then service
+func Build(spec *domain.Specification) *Catalog {
+	c := &Catalog{
+		Version: spec.Version,
+		Info: InfoEntry{
+			Title:       spec.Info.Title,
+			Description: spec.Info.Description,
+			Version:     spec.Info.Version,
+		},
+		Metrics: []MetricEntry{},
+	}
+
+	for serviceName, service := range spec.Services {
+		for key, metric := range service.Metrics {
+			c.Metrics = append(c.Metrics, newMetricEntry(serviceName, key, metric))
+		}
+	}
+
+	slices.SortFunc(c.Metrics, func(a, b MetricEntry) int {
+		if n := strings.Compare(a.FullName, b.FullName); n != 0 {
+			return n
+		}
+		return strings.Compare(a.Service, b.Service)
+	})
+	return c
+}
+
+func newMetricEntry(serviceName, key string, metric domain.Metric) MetricEntry {
+	if metric.Name == "" {
+		metric.Name = key
+	}
+
+	m := MetricEntry{
+		FullName:  metric.FullName(),
+		Service:   serviceName,
+		Key:       key,
+		Name:      metric.Name,
+		Namespace: metric.Namespace,
+		Subsystem: metric.Subsystem,
+		Type:      string(metric.Type),
+		Help:      metric.Help,
+		Unit:      metric.Unit,
+		Labels:    []LabelEntry{},
+		Stability: string(metric.Stability),
+		Since:     metric.Since,
+	}
+
+	for _, label := range metric.Labels {
+		m.Labels = append(m.Labels, LabelEntry{
+			Name:        label.Name,
+			Description: label.Description,
+			Inherited:   label.Inherited,
+			Shared:      label.Ref,
+		})
+	}
+
+	for _, constLabel := range metric.ConstLabels {
+		m.ConstLabels = append(m.ConstLabels, ConstLabelEntry{
+			Name:        constLabel.Name,
+			Value:       constLabel.Value,
+			Description: constLabel.Description,
+		})
+	}
+
+	if metric.Deprecated != nil {
+		m.Deprecated = &DeprecatedEntry{
+			Since:      metric.Deprecated.Since,
+			ReplacedBy: metric.Deprecated.ReplacedBy,
+			Reason:     metric.Deprecated.Reason,
+		}
+	}
+
+	if metric.Owner != nil {
+		m.Owner = &OwnerEntry{Team: metric.Owner.Team, Contact: metric.Owner.Contact}
+	}
+
+	if metric.SLO != nil {
+		m.SLO = &SLOEntry{
+			Objective:   metric.SLO.Objective,
+			Window:      metric.SLO.Window,
+			Description: metric.SLO.Description,
+		}
+	}
+
+	return m
+}
+
+// JSON returns the indented JSON catalog
+func (c *Catalog) JSON() ([]byte, error) {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal catalog to JSON: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// OpenMetrics returns the metadata of the metric families in the OpenMetrics text format, without
+// samples. Counter families are named without their _total suffix, and the unit is only written
+// when the family name ends with it, as required by OpenMetrics. A metric exposed by several
+// services is written once.
+func (c *Catalog) OpenMetrics() []byte {
+	var buf bytes.Buffer
+	seen := make(map[string]bool)
+	for _, metric := range c.Metrics {
+		family := metric.FullName
+		if metric.Type == string(domain.MetricTypeCounter) {
+			family = strings.TrimSuffix(family, "_total")
+		}
+		if seen[family] {
+			continue
+		}
+		seen[family] = true
+
+		fmt.Fprintf(&buf, "# HELP %s %s\n", family, escapeHelp(metric.Help))
+		fmt.Fprintf(&buf, "# TYPE %s %s\n", family, metric.Type)
+		if metric.Unit != "" && strings.HasSuffix(family, "_"+metric.Unit) {
+			fmt.Fprintf(&buf, "# UNIT %s %s\n", family, metric.Unit)
+		}
+	}
+	buf.WriteString("# EOF\n")
+	return buf.Bytes()
+}
+
+// WriteFiles writes the JSON catalog and the OpenMetrics metadata in dir
+func (c *Catalog) WriteFiles(dir string) error {
+	data, err := c.JSON()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, JSONFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", JSONFile, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, OpenMetricsFile), c.OpenMetrics(), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", OpenMetricsFile, err)
+	}
+	return nil
+}
+
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+// escapeHelp escapes the backslashes, line feeds and double quotes of a help text
+func escapeHelp(help string) string {
+	return helpEscaper.Replace(help)
+}
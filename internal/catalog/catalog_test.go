@@ -0,0 +1,135 @@
+package catalog
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jycamier/promener/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSpec() *domain.Specification {
+	requests := domain.Metric{
+		Namespace: "http",
+		Subsystem: "server",
+		Type:      domain.MetricTypeCounter,
+		Help:      "Total \"HTTP\" requests\nby method",
+		Labels: domain.Labels{
+			{Name: "method", Description: "HTTP method", Ref: "method"},
+		},
+		ConstLabels: domain.ConstLabels{
+			{Name: "region", Value: "${REGION:eu-west-1}"},
+		},
+	}
+
+	return &domain.Specification{
+		Version: "2.0.0",
+		Info:    domain.Info{Title: "Shop", Version: "1.0.0"},
+		Services: map[string]domain.Service{
+			"orders": {
+				Metrics: map[string]domain.Metric{
+					"requests_total": requests,
+					"request_duration_seconds": {
+						Namespace: "http",
+						Subsystem: "server",
+						Type:      domain.MetricTypeHistogram,
+						Help:      "Request duration",
+						Unit:      "seconds",
+						Stability: domain.StabilityStable,
+						Owner:     &domain.Owner{Team: "orders"},
+						SLO:       &domain.SLO{Objective: 0.99, Window: "30d"},
+					},
+				},
+			},
+			"carts": {
+				Metrics: map[string]domain.Metric{
+					"requests_total": requests,
+					"legacy_size": {
+						Namespace:  "carts",
+						Type:       domain.MetricTypeGauge,
+						Help:       "Cart size",
+						Deprecated: &domain.Deprecated{Since: "1.2.0", ReplacedBy: "carts_items"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	c := Build(testSpec())
+
+	assert.Equal(t, "2.0.0", c.Version)
+	assert.Equal(t, "Shop", c.Info.Title)
+
+	var names []string
+	for _, metric := range c.Metrics {
+		names = append(names, metric.Service+"/"+metric.FullName)
+	}
+	assert.Equal(t, []string{
+		"carts/carts_legacy_size",
+		"orders/http_server_request_duration_seconds",
+		"carts/http_server_requests_total",
+		"orders/http_server_requests_total",
+	}, names)
+
+	legacy := c.Metrics[0]
+	assert.Equal(t, "legacy_size", legacy.Name)
+	assert.Equal(t, &DeprecatedEntry{Since: "1.2.0", ReplacedBy: "carts_items"}, legacy.Deprecated)
+	assert.Empty(t, legacy.Labels)
+
+	duration := c.Metrics[1]
+	assert.Equal(t, "stable", duration.Stability)
+	assert.Equal(t, &OwnerEntry{Team: "orders"}, duration.Owner)
+	assert.Equal(t, &SLOEntry{Objective: 0.99, Window: "30d"}, duration.SLO)
+
+	requests := c.Metrics[3]
+	assert.Equal(t, []LabelEntry{{Name: "method", Description: "HTTP method", Shared: "method"}}, requests.Labels)
+	assert.Equal(t, []ConstLabelEntry{{Name: "region", Value: "${REGION:eu-west-1}"}}, requests.ConstLabels)
+}
+
+func TestCatalog_OpenMetrics(t *testing.T) {
+	expected := `# HELP carts_legacy_size Cart size
+# TYPE carts_legacy_size gauge
+# HELP http_server_request_duration_seconds Request duration
+# TYPE http_server_request_duration_seconds histogram
+# UNIT http_server_request_duration_seconds seconds
+# HELP http_server_requests Total \"HTTP\" requests\nby method
+# TYPE http_server_requests counter
+# EOF
+`
+	assert.Equal(t, expected, string(Build(testSpec()).OpenMetrics()))
+}
+
+func TestCatalog_OpenMetrics_UnitNotInName(t *testing.T) {
+	spec := &domain.Specification{
+		Version: "1.0.0",
+		Services: map[string]domain.Service{
+			"api": {
+				Metrics: map[string]domain.Metric{
+					"latency": {Namespace: "http", Type: domain.MetricTypeHistogram, Help: "Latency", Unit: "seconds"},
+				},
+			},
+		},
+	}
+
+	assert.NotContains(t, string(Build(spec).OpenMetrics()), "# UNIT")
+}
+
+func TestCatalog_WriteFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+	require.NoError(t, Build(testSpec()).WriteFiles(dir))
+
+	data, err := os.ReadFile(filepath.Join(dir, JSONFile))
+	require.NoError(t, err)
+	var decoded Catalog
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Len(t, decoded.Metrics, 4)
+
+	text, err := os.ReadFile(filepath.Join(dir, OpenMetricsFile))
+	require.NoError(t, err)
+	assert.Contains(t, string(text), "# EOF\n")
+}
@@ -0,0 +1,179 @@
+package catalog
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/jycamier/promener/internal/domain"
+)
+
+// Index is the searchable catalog of the metrics of several specifications
+type Index struct {
+	services []ServiceEntry
+	metrics  []MetricEntry
+	byName   map[string][]int // Full name -> indexes of the metrics emitted under this name
+}
+
+// ServiceEntry is a service of the index
+type ServiceEntry struct {
+	Name          string `json:"name"`
+	Title         string `json:"title"`
+	Description   string `json:"description,omitempty"`
+	Version       string `json:"version"`
+	Specification string `json:"specification"` // Title of the specification defining the service
+	Metrics       int    `json:"metrics"`
+}
+
+// DeprecationEntry is a deprecated metric of the index with its replacement, when the metric it
+// is replaced by is in the index
+type DeprecationEntry struct {
+	FullName    string       `json:"fullName"`
+	Service     string       `json:"service"`
+	Since       string       `json:"since,omitempty"`
+	ReplacedBy  string       `json:"replacedBy,omitempty"`
+	Reason      string       `json:"reason,omitempty"`
+	Replacement *MetricEntry `json:"replacement,omitempty"`
+}
+
+// Query filters the metrics of the index. Empty fields match all metrics.
+type Query struct {
+	Name    string // Case-insensitive substring of the full name
+	Label   string // Name of a label or a constant label
+	Owner   string // Case-insensitive team or contact of the owner
+	Service string // Name of the service
+}
+
+// NewIndex indexes the metrics of the specifications. Services are sorted by name and metrics by
+// full name then service.
+func NewIndex(specs []*domain.Specification) *Index {
+	idx := &Index{
+		services: []ServiceEntry{},
+		metrics:  []MetricEntry{},
+		byName:   make(map[string][]int),
+	}
+
+	for _, spec := range specs {
+		for name, service := range spec.Services {
+			idx.services = append(idx.services, ServiceEntry{
+				Name:          name,
+				Title:         service.Info.Title,
+				Description:   service.Info.Description,
+				Version:       service.Info.Version,
+				Specification: spec.Info.Title,
+				Metrics:       len(service.Metrics),
+			})
+		}
+		idx.metrics = append(idx.metrics, Build(spec).Metrics...)
+	}
+
+	slices.SortFunc(idx.services, func(a, b ServiceEntry) int {
+		if n := strings.Compare(a.Name, b.Name); n != 0 {
+			return n
+		}
+		return strings.Compare(a.Specification, b.Specification)
+	})
+	slices.SortStableFunc(idx.metrics, func(a, b MetricEntry) int {
+		if n := strings.Compare(a.FullName, b.FullName); n != 0 {
+			return n
+		}
+		return strings.Compare(a.Service, b.Service)
+	})
+
+	for i, metric := range idx.metrics {
+		idx.byName[metric.FullName] = append(idx.byName[metric.FullName], i)
+	}
+	return idx
+}
+
+// Services returns the services of the index
+func (idx *Index) Services() []ServiceEntry {
+	return idx.services
+}
+
+// Len returns the number of metrics of the index
+func (idx *Index) Len() int {
+	return len(idx.metrics)
+}
+
+// Search returns the metrics matching the query
+func (idx *Index) Search(q Query) []MetricEntry {
+	result := []MetricEntry{}
+	for _, metric := range idx.metrics {
+		if q.matches(metric) {
+			result = append(result, metric)
+		}
+	}
+	return result
+}
+
+func (q Query) matches(m MetricEntry) bool {
+	if q.Name != "" && !strings.Contains(strings.ToLower(m.FullName), strings.ToLower(q.Name)) {
+		return false
+	}
+	if q.Service != "" && m.Service != q.Service {
+		return false
+	}
+	if q.Owner != "" {
+		if m.Owner == nil || (!strings.EqualFold(m.Owner.Team, q.Owner) && !strings.EqualFold(m.Owner.Contact, q.Owner)) {
+			return false
+		}
+	}
+	if q.Label != "" {
+		found := slices.ContainsFunc(m.Labels, func(l LabelEntry) bool { return l.Name == q.Label }) ||
+			slices.ContainsFunc(m.ConstLabels, func(l ConstLabelEntry) bool { return l.Name == q.Label })
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Metric returns the definitions of a metric, one per service emitting it
+func (idx *Index) Metric(fullName string) []MetricEntry {
+	result := []MetricEntry{}
+	for _, i := range idx.byName[fullName] {
+		result = append(result, idx.metrics[i])
+	}
+	return result
+}
+
+// Emitters returns the names of the services emitting a metric
+func (idx *Index) Emitters(fullName string) []string {
+	result := []string{}
+	for _, i := range idx.byName[fullName] {
+		if service := idx.metrics[i].Service; !slices.Contains(result, service) {
+			result = append(result, service)
+		}
+	}
+	return result
+}
+
+// Deprecated returns the deprecated metrics. The replacement is searched by full name, in the
+// service of the deprecated metric first.
+func (idx *Index) Deprecated() []DeprecationEntry {
+	result := []DeprecationEntry{}
+	for _, metric := range idx.metrics {
+		if metric.Deprecated == nil {
+			continue
+		}
+		entry := DeprecationEntry{
+			FullName:   metric.FullName,
+			Service:    metric.Service,
+			Since:      metric.Deprecated.Since,
+			ReplacedBy: metric.Deprecated.ReplacedBy,
+			Reason:     metric.Deprecated.Reason,
+		}
+		if replacements := idx.Metric(metric.Deprecated.ReplacedBy); len(replacements) > 0 {
+			replacement := replacements[0]
+			for _, r := range replacements {
+				if r.Service == metric.Service {
+					replacement = r
+					break
+				}
+			}
+			entry.Replacement = &replacement
+		}
+		result = append(result, entry)
+	}
+	return result
+}
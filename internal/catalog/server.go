@@ -0,0 +1,170 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jycamier/promener/internal/domain"
+)
+
+// LoadFunc loads the specifications served by the catalog
+type LoadFunc func(ctx context.Context) ([]*domain.Specification, error)
+
+// Status describes the last reload of the catalog
+type Status struct {
+	LoadedAt  *time.Time `json:"loadedAt,omitempty"` // Time of the last successful reload
+	CheckedAt *time.Time `json:"checkedAt,omitempty"`
+	Services  int        `json:"services"`
+	Metrics   int        `json:"metrics"`
+	Error     string     `json:"error,omitempty"` // Error of the last reload, if it failed
+}
+
+// Server serves the index of the loaded specifications as a JSON REST API:
+//
+//	GET /api/v1/services                                  services
+//	GET /api/v1/metrics?name=&label=&owner=&service=      metrics matching the query
+//	GET /api/v1/metrics/{name}                            definitions of a metric, one per service
+//	GET /api/v1/metrics/{name}/services                   services emitting a metric
+//	GET /api/v1/deprecated                                deprecated metrics and their replacements
+//	GET /api/v1/status                                    last reload
+type Server struct {
+	load LoadFunc
+	log  io.Writer
+	mux  *http.ServeMux
+
+	mu     sync.RWMutex
+	index  *Index
+	status Status
+}
+
+// NewServer creates a catalog server with an empty index, loaded by Reload. Reload results are
+// logged to log.
+func NewServer(load LoadFunc, log io.Writer) *Server {
+	s := &Server{
+		load:  load,
+		log:   log,
+		mux:   http.NewServeMux(),
+		index: NewIndex(nil),
+	}
+
+	s.mux.HandleFunc("GET /api/v1/services", s.handleServices)
+	s.mux.HandleFunc("GET /api/v1/metrics", s.handleSearch)
+	s.mux.HandleFunc("GET /api/v1/metrics/{name}", s.handleMetric)
+	s.mux.HandleFunc("GET /api/v1/metrics/{name}/services", s.handleEmitters)
+	s.mux.HandleFunc("GET /api/v1/deprecated", s.handleDeprecated)
+	s.mux.HandleFunc("GET /api/v1/status", s.handleStatus)
+	return s
+}
+
+// Reload loads the specifications and replaces the index. When the load fails, the previous
+// index is kept so that an unreachable source does not empty the catalog.
+func (s *Server) Reload(ctx context.Context) error {
+	specs, err := s.load(ctx)
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.status.CheckedAt = &now
+	if err != nil {
+		s.status.Error = err.Error()
+		fmt.Fprintf(s.log, "✗ Failed to reload the catalog (%s): %v\n", now.Format("15:04:05"), err)
+		return err
+	}
+
+	s.index = NewIndex(specs)
+	s.status = Status{
+		LoadedAt:  &now,
+		CheckedAt: &now,
+		Services:  len(s.index.Services()),
+		Metrics:   s.index.Len(),
+	}
+	fmt.Fprintf(s.log, "✓ Loaded %d services and %d metrics (%s)\n", s.status.Services, s.status.Metrics, now.Format("15:04:05"))
+	return nil
+}
+
+// Run reloads the catalog at each interval, until the context is done
+func (s *Server) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = s.Reload(ctx)
+		}
+	}
+}
+
+// ServeHTTP serves the API
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// current returns the index being served
+func (s *Server) current() *Index {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.index
+}
+
+func (s *Server) handleServices(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.current().Services())
+}
+
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	writeJSON(w, http.StatusOK, s.current().Search(Query{
+		Name:    params.Get("name"),
+		Label:   params.Get("label"),
+		Owner:   params.Get("owner"),
+		Service: params.Get("service"),
+	}))
+}
+
+func (s *Server) handleMetric(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	metrics := s.current().Metric(name)
+	if len(metrics) == 0 {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("metric %s not found", name))
+		return
+	}
+	writeJSON(w, http.StatusOK, metrics)
+}
+
+func (s *Server) handleEmitters(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	services := s.current().Emitters(name)
+	if len(services) == 0 {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("metric %s not found", name))
+		return
+	}
+	writeJSON(w, http.StatusOK, services)
+}
+
+func (s *Server) handleDeprecated(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.current().Deprecated())
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	status := s.status
+	s.mu.RUnlock()
+	writeJSON(w, http.StatusOK, status)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	writeJSON(w, code, map[string]string{"error": message})
+}
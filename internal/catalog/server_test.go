@@ -0,0 +1,160 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jycamier/promener/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func billingSpec() *domain.Specification {
+	return &domain.Specification{
+		Version: "2.0.0",
+		Info:    domain.Info{Title: "Billing", Version: "1.0.0"},
+		Services: map[string]domain.Service{
+			"invoices": {
+				Info: domain.Info{Title: "Invoices", Version: "3.1.0"},
+				Metrics: map[string]domain.Metric{
+					"items": {
+						Namespace: "carts",
+						Type:      domain.MetricTypeGauge,
+						Help:      "Cart items",
+						Owner:     &domain.Owner{Team: "Billing", Contact: "#billing"},
+						Labels:    domain.Labels{{Name: "currency"}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+
+	s := NewServer(func(ctx context.Context) ([]*domain.Specification, error) {
+		return []*domain.Specification{testSpec(), billingSpec()}, nil
+	}, io.Discard)
+	require.NoError(t, s.Reload(context.Background()))
+	return s
+}
+
+func getJSON(t *testing.T, s *Server, target string, code int, v any) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	require.Equal(t, code, rec.Code, rec.Body.String())
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), v))
+}
+
+func TestServer_Services(t *testing.T) {
+	var services []ServiceEntry
+	getJSON(t, newTestServer(t), "/api/v1/services", http.StatusOK, &services)
+
+	require.Len(t, services, 3)
+	assert.Equal(t, "carts", services[0].Name)
+	assert.Equal(t, "Shop", services[0].Specification)
+	assert.Equal(t, 2, services[0].Metrics)
+	assert.Equal(t, ServiceEntry{Name: "invoices", Title: "Invoices", Version: "3.1.0", Specification: "Billing", Metrics: 1}, services[1])
+}
+
+func fullNames(metrics []MetricEntry) []string {
+	names := []string{}
+	for _, metric := range metrics {
+		names = append(names, metric.Service+"/"+metric.FullName)
+	}
+	return names
+}
+
+func TestServer_Search(t *testing.T) {
+	s := newTestServer(t)
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"invoices/carts_items", "carts/carts_legacy_size", "orders/http_server_request_duration_seconds", "carts/http_server_requests_total", "orders/http_server_requests_total"}},
+		{"name=REQUESTS", []string{"carts/http_server_requests_total", "orders/http_server_requests_total"}},
+		{"name=requests&service=orders", []string{"orders/http_server_requests_total"}},
+		{"label=method", []string{"carts/http_server_requests_total", "orders/http_server_requests_total"}},
+		{"label=region", []string{"carts/http_server_requests_total", "orders/http_server_requests_total"}},
+		{"owner=billing", []string{"invoices/carts_items"}},
+		{"owner=%23billing", []string{"invoices/carts_items"}},
+		{"owner=payments", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			var metrics []MetricEntry
+			getJSON(t, s, "/api/v1/metrics?"+tt.query, http.StatusOK, &metrics)
+			assert.Equal(t, tt.want, fullNames(metrics))
+		})
+	}
+}
+
+func TestServer_Metric(t *testing.T) {
+	s := newTestServer(t)
+
+	var metrics []MetricEntry
+	getJSON(t, s, "/api/v1/metrics/http_server_requests_total", http.StatusOK, &metrics)
+	assert.Equal(t, []string{"carts/http_server_requests_total", "orders/http_server_requests_total"}, fullNames(metrics))
+
+	var services []string
+	getJSON(t, s, "/api/v1/metrics/http_server_requests_total/services", http.StatusOK, &services)
+	assert.Equal(t, []string{"carts", "orders"}, services)
+
+	var body map[string]string
+	getJSON(t, s, "/api/v1/metrics/unknown_total", http.StatusNotFound, &body)
+	assert.Equal(t, "metric unknown_total not found", body["error"])
+	getJSON(t, s, "/api/v1/metrics/unknown_total/services", http.StatusNotFound, &body)
+}
+
+func TestServer_Deprecated(t *testing.T) {
+	var deprecated []DeprecationEntry
+	getJSON(t, newTestServer(t), "/api/v1/deprecated", http.StatusOK, &deprecated)
+
+	require.Len(t, deprecated, 1)
+	assert.Equal(t, "carts_legacy_size", deprecated[0].FullName)
+	assert.Equal(t, "1.2.0", deprecated[0].Since)
+	assert.Equal(t, "carts_items", deprecated[0].ReplacedBy)
+	require.NotNil(t, deprecated[0].Replacement)
+	assert.Equal(t, "invoices", deprecated[0].Replacement.Service)
+}
+
+func TestServer_Reload(t *testing.T) {
+	var loadErr error
+	s := NewServer(func(ctx context.Context) ([]*domain.Specification, error) {
+		if loadErr != nil {
+			return nil, loadErr
+		}
+		return []*domain.Specification{testSpec()}, nil
+	}, io.Discard)
+
+	var status Status
+	getJSON(t, s, "/api/v1/status", http.StatusOK, &status)
+	assert.Nil(t, status.LoadedAt, "not loaded yet")
+
+	require.NoError(t, s.Reload(context.Background()))
+	getJSON(t, s, "/api/v1/status", http.StatusOK, &status)
+	assert.NotNil(t, status.LoadedAt)
+	assert.Equal(t, 2, status.Services)
+	assert.Equal(t, 4, status.Metrics)
+
+	loadErr = errors.New("failed to clone")
+	require.Error(t, s.Reload(context.Background()))
+	status = Status{}
+	getJSON(t, s, "/api/v1/status", http.StatusOK, &status)
+	assert.Equal(t, "failed to clone", status.Error)
+	assert.Equal(t, 4, status.Metrics, "previous catalog kept")
+
+	var services []ServiceEntry
+	getJSON(t, s, "/api/v1/services", http.StatusOK, &services)
+	assert.Len(t, services, 2)
+}
@@ -0,0 +1,179 @@
+// Package docgen renders the documentation of a specification as Markdown or AsciiDoc, from the
+// same models as the HTML documentation.
+package docgen
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/jycamier/promener/internal/domain"
+	"github.com/jycamier/promener/internal/htmlgen"
+)
+
+//go:embed templates/*.tmpl
+var templatesFS embed.FS
+
+// Format is the markup of the generated documentation
+type Format string
+
+const (
+	FormatMarkdown Format = "markdown"
+	FormatAsciiDoc Format = "asciidoc"
+)
+
+// Formats lists the supported formats
+var Formats = []Format{FormatMarkdown, FormatAsciiDoc}
+
+// IsValid checks if the format is supported
+func (f Format) IsValid() bool {
+	switch f {
+	case FormatMarkdown, FormatAsciiDoc:
+		return true
+	}
+	return false
+}
+
+// Extension returns the file extension of the format
+func (f Format) Extension() string {
+	if f == FormatAsciiDoc {
+		return ".adoc"
+	}
+	return ".md"
+}
+
+// TemplateData contains data for the documentation templates
+type TemplateData struct {
+	Info     domain.Info
+	Services []htmlgen.ServiceJSON
+}
+
+// Generator renders the documentation of a specification
+type Generator struct {
+	tmpl   *template.Template
+	format Format
+}
+
+// New creates a new documentation generator for a format. The templates of the files matching
+// overrides (a glob pattern, e.g. docs/templates/*.tmpl) are parsed after the built-in ones, so
+// that they can redefine any of their blocks (e.g. {{define "metric"}}); it can be empty.
+func New(format Format, overrides string) (*Generator, error) {
+	if !format.IsValid() {
+		return nil, fmt.Errorf("unsupported format %q (expected markdown or asciidoc)", format)
+	}
+
+	name := string(format) + ".tmpl"
+	tmpl, err := template.New(name).Funcs(funcMap(format)).ParseFS(templatesFS, "templates/"+name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s template: %w", format, err)
+	}
+
+	if overrides != "" {
+		files, err := filepath.Glob(overrides)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template pattern %q: %w", overrides, err)
+		}
+		if len(files) == 0 {
+			return nil, fmt.Errorf("no template matches %q", overrides)
+		}
+		if tmpl, err = tmpl.ParseFiles(files...); err != nil {
+			return nil, fmt.Errorf("failed to parse templates %q: %w", overrides, err)
+		}
+	}
+
+	return &Generator{tmpl: tmpl, format: format}, nil
+}
+
+// Generate renders the documentation of a specification
+func (g *Generator) Generate(spec *domain.Specification) ([]byte, error) {
+	data := TemplateData{
+		Info:     spec.Info,
+		Services: htmlgen.ConvertServicesToJSON(spec),
+	}
+
+	var buf bytes.Buffer
+	if err := g.tmpl.ExecuteTemplate(&buf, string(g.format)+".tmpl", data); err != nil {
+		return nil, fmt.Errorf("failed to execute %s template: %w", g.format, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// GenerateFile renders the documentation and writes it to a file
+func (g *Generator) GenerateFile(spec *domain.Specification, outputPath string) error {
+	doc, err := g.Generate(spec)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(outputPath, doc, 0644); err != nil {
+		return fmt.Errorf("failed to write documentation file: %w", err)
+	}
+	return nil
+}
+
+func funcMap(format Format) template.FuncMap {
+	return template.FuncMap{
+		"cell": func(s string) string {
+			return tableCell(format, s)
+		},
+		"anchor": func(s string) string {
+			return anchor(format, s)
+		},
+		"join": strings.Join,
+		"indent": func(spaces int, s string) string {
+			return strings.ReplaceAll(s, "\n", "\n"+strings.Repeat(" ", spaces))
+		},
+		"percent": func(objective float64) string {
+			return strconv.FormatFloat(objective*100, 'f', -1, 64) + "%"
+		},
+		"signals": func(signals htmlgen.GoldenSignalsJSON) []namedSignal {
+			var result []namedSignal
+			for _, s := range []namedSignal{
+				{"Latency", signals.Latency},
+				{"Errors", signals.Errors},
+				{"Traffic", signals.Traffic},
+				{"Saturation", signals.Saturation},
+			} {
+				if s.Signal != nil {
+					result = append(result, s)
+				}
+			}
+			return result
+		},
+	}
+}
+
+// namedSignal is a golden signal with its name, to range over the signals of a topic
+type namedSignal struct {
+	Name   string
+	Signal *htmlgen.GoldenSignalJSON
+}
+
+// tableCell escapes the cell separators and line breaks of a table cell
+func tableCell(format Format, s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	if format == FormatMarkdown {
+		return strings.ReplaceAll(s, "\n", "<br>")
+	}
+	return strings.ReplaceAll(s, "\n", " +\n")
+}
+
+// anchor returns the id of a section: the slug GitHub and GitLab generate for a Markdown heading
+// (punctuation removed, spaces replaced by hyphens), or an AsciiDoc block id
+func anchor(format Format, s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_', r == '-':
+			b.WriteRune(r)
+		case r == ' ' || format == FormatAsciiDoc:
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
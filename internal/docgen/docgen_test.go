@@ -0,0 +1,111 @@
+package docgen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jycamier/promener/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSpec() *domain.Specification {
+	return &domain.Specification{
+		Version: "1.0.0",
+		Info:    domain.Info{Title: "Shop Metrics", Version: "1.0.0"},
+		Services: map[string]domain.Service{
+			"orders": {
+				Info:    domain.Info{Title: "Order Service", Version: "2.0.0"},
+				Servers: []domain.Server{{URL: "https://prometheus.example.com", Description: "Prometheus"}},
+				Metrics: map[string]domain.Metric{
+					"requests_total": {
+						Namespace: "http",
+						Subsystem: "server",
+						Type:      domain.MetricTypeCounter,
+						Help:      "Requests | by status",
+						Labels:    domain.Labels{{Name: "status", Description: "HTTP status"}},
+						ConstLabels: domain.ConstLabels{
+							{Name: "region", Value: "${REGION:eu-west-1}"},
+						},
+						Examples: domain.Examples{
+							PromQL: []domain.PromQLExample{{Query: "sum(rate(http_server_requests_total[5m]))", Description: "Request rate"}},
+							Alerts: []domain.AlertExample{{Name: "HighErrorRate", Expr: "rate(http_server_requests_total{status=~\"5..\"}[5m]) > 1", For: "5m", Severity: "critical"}},
+						},
+					},
+					"request_count": {
+						Namespace:  "http",
+						Subsystem:  "server",
+						Type:       domain.MetricTypeCounter,
+						Help:       "Request count",
+						Deprecated: &domain.Deprecated{Since: "1.5.0", ReplacedBy: "http_server_requests_total"},
+					},
+				},
+				GoldenSignals: map[string]domain.GoldenSignals{
+					"http/server": {
+						Traffic: &domain.GoldenSignal{Description: "Request volume", Metrics: []string{"requests_total"}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGenerator_Markdown(t *testing.T) {
+	g, err := New(FormatMarkdown, "")
+	require.NoError(t, err)
+
+	doc, err := g.Generate(testSpec())
+	require.NoError(t, err)
+
+	out := string(doc)
+	assert.Contains(t, out, "# Shop Metrics\n")
+	assert.Contains(t, out, "- [Order Service](#order-service) (2 metrics)")
+	assert.Contains(t, out, "- [Prometheus](https://prometheus.example.com)")
+	assert.Contains(t, out, "#### http/server\n\n**Traffic**: Request volume\n\n- Metrics: `requests_total`")
+	assert.Contains(t, out, "| [`http_server_requests_total`](#http_server_requests_total) | counter | Requests \\| by status |")
+	assert.Contains(t, out, "| [`http_server_request_count`](#http_server_request_count) | counter | Request count **(deprecated)** |")
+	assert.Contains(t, out, "> **Deprecated** since 1.5.0. Replaced by `http_server_requests_total`.")
+	assert.Contains(t, out, "| `status` | HTTP status |")
+	assert.Contains(t, out, "| `region` | `${REGION:eu-west-1}` |  |")
+	assert.Contains(t, out, "Request rate:\n\n```promql\nsum(rate(http_server_requests_total[5m]))\n```")
+	assert.Contains(t, out, "- **HighErrorRate** (critical), for 5m")
+}
+
+func TestGenerator_AsciiDoc(t *testing.T) {
+	g, err := New(FormatAsciiDoc, "")
+	require.NoError(t, err)
+
+	doc, err := g.Generate(testSpec())
+	require.NoError(t, err)
+
+	out := string(doc)
+	assert.Contains(t, out, "= Shop Metrics\n:toc:")
+	assert.Contains(t, out, "[[orders]]\n== Order Service")
+	assert.Contains(t, out, "* https://prometheus.example.com[Prometheus]")
+	assert.Contains(t, out, "|<<http_server_requests_total,`http_server_requests_total`>> |counter |Requests \\| by status")
+	assert.Contains(t, out, "WARNING: Deprecated since 1.5.0. Replaced by `http_server_requests_total`.")
+	assert.Contains(t, out, "[source,promql]\n----\nsum(rate(http_server_requests_total[5m]))\n----")
+}
+
+func TestGenerator_Overrides(t *testing.T) {
+	dir := t.TempDir()
+	override := `{{define "metric"}}* {{.FullName}} ({{.Type}}){{end}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "metric.tmpl"), []byte(override), 0644))
+
+	g, err := New(FormatMarkdown, filepath.Join(dir, "*.tmpl"))
+	require.NoError(t, err)
+
+	doc, err := g.Generate(testSpec())
+	require.NoError(t, err)
+	assert.Contains(t, string(doc), "* http_server_requests_total (counter)")
+	assert.NotContains(t, string(doc), "#### `http_server_requests_total`")
+
+	_, err = New(FormatMarkdown, filepath.Join(dir, "*.missing"))
+	assert.ErrorContains(t, err, "no template matches")
+}
+
+func TestNew_InvalidFormat(t *testing.T) {
+	_, err := New("html", "")
+	assert.ErrorContains(t, err, `unsupported format "html"`)
+}
@@ -0,0 +1,257 @@
+// Package docserver serves the HTML documentation over HTTP, rebuilds it when the specifications
+// change on disk and reloads the browsers with server-sent events.
+package docserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+const (
+	// EventsPath is the path of the server-sent events stream notifying the reloads
+	EventsPath = "/_promener/events"
+
+	// debounce groups the file system events of a save (editors often write, rename and chmod)
+	debounce = 100 * time.Millisecond
+
+	// keepAlive is the interval of the comments sent to keep idle event streams open through proxies
+	keepAlive = 30 * time.Second
+)
+
+// BuildFunc builds the HTML documentation
+type BuildFunc func() ([]byte, error)
+
+// Server serves the last build of the documentation. When the build fails, it serves the last
+// successful build with an overlay showing the error, or an error page before the first success.
+type Server struct {
+	build BuildFunc
+	paths []string
+	log   io.Writer
+
+	mu      sync.RWMutex
+	built   bool
+	html    []byte // Last successful build
+	err     error  // Error of the last build
+	sum     [sha256.Size]byte
+	clients map[chan struct{}]struct{}
+}
+
+// New creates a new documentation server. paths are the local files to watch, the directory
+// of each one is watched so that the files it imports are watched too. Build results are logged
+// to log.
+func New(build BuildFunc, paths []string, log io.Writer) *Server {
+	return &Server{
+		build:   build,
+		paths:   paths,
+		log:     log,
+		clients: make(map[chan struct{}]struct{}),
+	}
+}
+
+// Rebuild builds the documentation and notifies the browsers if the page changed, i.e. the
+// HTML differs from the previous build or the build started or stopped failing
+func (s *Server) Rebuild() bool {
+	html, err := s.build()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var sum [sha256.Size]byte
+	if err == nil {
+		sum = sha256.Sum256(html)
+	}
+
+	changed := !s.built || (err == nil) != (s.err == nil)
+	if err == nil {
+		changed = changed || sum != s.sum
+	} else {
+		changed = changed || err.Error() != s.err.Error()
+	}
+	if !changed {
+		return false
+	}
+
+	s.built = true
+	s.err = err
+	if err == nil {
+		s.html, s.sum = html, sum
+		fmt.Fprintf(s.log, "✓ Rebuilt documentation (%s)\n", time.Now().Format("15:04:05"))
+	} else {
+		fmt.Fprintf(s.log, "✗ Build failed (%s), the error is shown in the browser\n", time.Now().Format("15:04:05"))
+	}
+
+	for client := range s.clients {
+		select {
+		case client <- struct{}{}:
+		default: // a reload is already pending
+		}
+	}
+	return true
+}
+
+// ServeHTTP serves the documentation and the reload events
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case EventsPath:
+		s.serveEvents(w, r)
+	case "/", "/index.html":
+		s.servePage(w)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (s *Server) servePage(w http.ResponseWriter) {
+	s.mu.RLock()
+	html, err := s.html, s.err
+	s.mu.RUnlock()
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+
+	var overlay bytes.Buffer
+	if err != nil {
+		if execErr := overlayTemplate.Execute(&overlay, err.Error()); execErr != nil {
+			http.Error(w, execErr.Error(), http.StatusInternalServerError)
+			return
+		}
+		if len(html) == 0 {
+			html = []byte("<!DOCTYPE html><html><head><meta charset=\"UTF-8\"><title>Build failed</title></head><body></body></html>")
+		}
+	}
+	overlay.WriteString(reloadScript)
+
+	_, _ = w.Write(inject(html, overlay.Bytes()))
+}
+
+// inject inserts content before the closing body tag of the page
+func inject(html, content []byte) []byte {
+	index := bytes.LastIndex(html, []byte("</body>"))
+	if index < 0 {
+		return append(append([]byte{}, html...), content...)
+	}
+	result := make([]byte, 0, len(html)+len(content))
+	result = append(result, html[:index]...)
+	result = append(result, content...)
+	return append(result, html[index:]...)
+}
+
+func (s *Server) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	client := make(chan struct{}, 1)
+	s.mu.Lock()
+	s.clients[client] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.clients, client)
+		s.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	ticker := time.NewTicker(keepAlive)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-client:
+			fmt.Fprint(w, "event: reload\ndata: {}\n\n")
+			flusher.Flush()
+		case <-ticker.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+// Watch rebuilds the documentation when a CUE file changes in the directories of the watched
+// paths, until the context is done
+func (s *Server) Watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	dirs := make(map[string]bool)
+	for _, path := range s.paths {
+		dir, err := filepath.Abs(filepath.Dir(path))
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w", path, err)
+		}
+		if dirs[dir] {
+			continue
+		}
+		if err := watcher.Add(dir); err != nil {
+			return fmt.Errorf("failed to watch %s: %w", dir, err)
+		}
+		dirs[dir] = true
+	}
+
+	var timer <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if strings.HasSuffix(event.Name, ".cue") && !event.Has(fsnotify.Chmod) {
+				timer = time.After(debounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Fprintf(s.log, "⚠ File watcher error: %v\n", err)
+		case <-timer:
+			timer = nil
+			s.Rebuild()
+		}
+	}
+}
+
+// reloadScript reloads the page when the server notifies a new build, and once the server is back
+// after a restart
+const reloadScript = `<script>
+(function () {
+    var events = new EventSource("` + EventsPath + `");
+    var lost = false;
+    events.addEventListener("reload", function () { window.location.reload(); });
+    events.onerror = function () { lost = true; };
+    events.onopen = function () { if (lost) { window.location.reload(); } };
+})();
+</script>
+`
+
+var overlayTemplate = template.Must(template.New("overlay").Parse(`<div id="promener-error-overlay" style="position:fixed;inset:0;z-index:9999;overflow:auto;background:rgba(17,24,39,0.92);color:#f9fafb;font-family:ui-monospace,SFMono-Regular,Menlo,monospace;padding:2rem">
+    <div style="max-width:64rem;margin:0 auto">
+        <h2 style="color:#f87171;font-size:1.25rem;font-weight:bold;margin-bottom:1rem">Failed to build the documentation</h2>
+        <pre style="white-space:pre-wrap;font-size:0.875rem;line-height:1.5">{{.}}</pre>
+        <p style="color:#9ca3af;margin-top:1.5rem;font-size:0.875rem">The page reloads when the specification is fixed.</p>
+    </div>
+</div>
+`))
@@ -0,0 +1,180 @@
+package docserver
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const page = "<!DOCTYPE html><html><body><h1>Metrics</h1></body></html>"
+
+// fakeBuild returns the page or the error set by the test, and counts the builds
+type fakeBuild struct {
+	html   atomic.Value
+	err    atomic.Value
+	builds atomic.Int32
+}
+
+func newFakeBuild(html string) *fakeBuild {
+	b := &fakeBuild{}
+	b.set(html, nil)
+	return b
+}
+
+func (b *fakeBuild) set(html string, err error) {
+	b.html.Store(html)
+	b.err.Store(errBox{err})
+}
+
+type errBox struct{ err error }
+
+func (b *fakeBuild) build() ([]byte, error) {
+	b.builds.Add(1)
+	if err := b.err.Load().(errBox).err; err != nil {
+		return nil, err
+	}
+	return []byte(b.html.Load().(string)), nil
+}
+
+func get(t *testing.T, s *Server) string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	return rec.Body.String()
+}
+
+func TestServer_Rebuild(t *testing.T) {
+	b := newFakeBuild(page)
+	s := New(b.build, nil, io.Discard)
+
+	assert.True(t, s.Rebuild(), "first build")
+	assert.False(t, s.Rebuild(), "same HTML")
+
+	b.set(strings.Replace(page, "Metrics", "Docs", 1), nil)
+	assert.True(t, s.Rebuild(), "new HTML")
+
+	b.set("", errors.New("invalid metric"))
+	assert.True(t, s.Rebuild(), "build failing")
+	assert.False(t, s.Rebuild(), "same error")
+
+	b.set("", errors.New("other error"))
+	assert.True(t, s.Rebuild(), "new error")
+
+	b.set(strings.Replace(page, "Metrics", "Docs", 1), nil)
+	assert.True(t, s.Rebuild(), "build fixed with the last successful HTML")
+}
+
+func TestServer_ServePage(t *testing.T) {
+	b := newFakeBuild(page)
+	s := New(b.build, nil, io.Discard)
+	s.Rebuild()
+
+	body := get(t, s)
+	assert.Contains(t, body, "<h1>Metrics</h1>")
+	assert.Contains(t, body, `new EventSource("/_promener/events")`)
+	assert.True(t, strings.HasSuffix(body, "</script>\n</body></html>"), "script injected before </body>")
+	assert.NotContains(t, body, "promener-error-overlay")
+
+	b.set("", errors.New("metric <name> is invalid"))
+	s.Rebuild()
+	body = get(t, s)
+	assert.Contains(t, body, "<h1>Metrics</h1>", "last successful build")
+	assert.Contains(t, body, "promener-error-overlay")
+	assert.Contains(t, body, "metric &lt;name&gt; is invalid")
+}
+
+func TestServer_ServePage_FirstBuildFailing(t *testing.T) {
+	b := newFakeBuild("")
+	b.set("", errors.New("CUE validation failed"))
+	s := New(b.build, nil, io.Discard)
+	s.Rebuild()
+
+	body := get(t, s)
+	assert.Contains(t, body, "<title>Build failed</title>")
+	assert.Contains(t, body, "CUE validation failed")
+}
+
+func TestServer_NotFound(t *testing.T) {
+	s := New(newFakeBuild(page).build, nil, io.Discard)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/favicon.ico", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+// readEvent reads the stream until the next event and returns its name
+func readEvent(t *testing.T, r *bufio.Reader) string {
+	t.Helper()
+
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		if name, ok := strings.CutPrefix(line, "event: "); ok {
+			return strings.TrimSpace(name)
+		}
+	}
+}
+
+func TestServer_Events(t *testing.T) {
+	b := newFakeBuild(page)
+	s := New(b.build, nil, io.Discard)
+	s.Rebuild()
+
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + EventsPath)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, ": connected\n", line)
+
+	b.set(strings.Replace(page, "Metrics", "Docs", 1), nil)
+	require.True(t, s.Rebuild())
+	assert.Equal(t, "reload", readEvent(t, reader))
+}
+
+func TestServer_Watch(t *testing.T) {
+	dir := t.TempDir()
+	spec := filepath.Join(dir, "metrics.cue")
+	require.NoError(t, os.WriteFile(spec, []byte("package main\n"), 0644))
+
+	b := newFakeBuild(page)
+	s := New(b.build, []string{spec}, io.Discard)
+	s.Rebuild()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- s.Watch(ctx) }()
+	defer func() {
+		cancel()
+		require.NoError(t, <-done)
+	}()
+
+	// Wait for the watcher to be registered before writing
+	time.Sleep(50 * time.Millisecond)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "labels.cue"), []byte("package main\n"), 0644))
+
+	assert.Eventually(t, func() bool { return b.builds.Load() == 2 }, 2*time.Second, 10*time.Millisecond)
+	time.Sleep(2 * debounce)
+	assert.Equal(t, int32(2), b.builds.Load(), "only CUE files trigger a build")
+}
@@ -0,0 +1,165 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ResolveReferences expands the metric templates named by extends and the shared labels named by
+// labelRefs into every metric of the specification, so that consumers get fully defined metrics.
+// A metric keeps its own fields over the ones of its template, and its own labels (shared ones first)
+// replace the template labels of the same name.
+func (s *Specification) ResolveReferences() error {
+	for serviceName, service := range s.Services {
+		for key, metric := range service.Metrics {
+			resolved, err := s.resolveMetric(metric, nil)
+			if err != nil {
+				return fmt.Errorf("service %s: metric %s: %w", serviceName, key, err)
+			}
+			service.Metrics[key] = resolved
+		}
+	}
+	return nil
+}
+
+// SharedLabelUsage counts the resolved metrics using each label of the library
+func (s *Specification) SharedLabelUsage() map[string]int {
+	usage := make(map[string]int)
+	for _, service := range s.Services {
+		for _, metric := range service.Metrics {
+			for _, label := range metric.Labels {
+				if label.Ref != "" {
+					usage[label.Ref]++
+				}
+			}
+		}
+	}
+	return usage
+}
+
+// resolveMetric resolves a metric or a metric template, chain holds the templates being resolved
+// to detect cycles between templates
+func (s *Specification) resolveMetric(metric Metric, chain []string) (Metric, error) {
+	own, err := s.ownLabels(metric)
+	if err != nil {
+		return Metric{}, err
+	}
+
+	if metric.Extends == "" {
+		metric.Labels = own
+		return metric, nil
+	}
+
+	if slices.Contains(chain, metric.Extends) {
+		return Metric{}, fmt.Errorf("metric templates form a cycle: %s", strings.Join(append(chain, metric.Extends), " -> "))
+	}
+	template, ok := s.MetricTemplates[metric.Extends]
+	if !ok {
+		return Metric{}, fmt.Errorf("unknown metric template %q", metric.Extends)
+	}
+	base, err := s.resolveMetric(template, append(chain, metric.Extends))
+	if err != nil {
+		return Metric{}, err
+	}
+
+	metric.inherit(base)
+	metric.Labels = mergeLabels(base.Labels, own)
+	return metric, nil
+}
+
+// ownLabels returns the shared labels referenced by the metric followed by its inline labels
+func (s *Specification) ownLabels(metric Metric) (Labels, error) {
+	labels := make(Labels, 0, len(metric.LabelRefs)+len(metric.Labels))
+	for _, ref := range metric.LabelRefs {
+		index := slices.IndexFunc(s.Labels, func(label LabelDefinition) bool { return label.Name == ref })
+		if index < 0 {
+			return nil, fmt.Errorf("unknown shared label %q", ref)
+		}
+		label := s.Labels[index]
+		label.Ref = ref
+		labels = append(labels, label)
+	}
+	for _, label := range metric.Labels {
+		if slices.ContainsFunc(labels, func(other LabelDefinition) bool { return other.Name == label.Name }) {
+			return nil, fmt.Errorf("label %q is both referenced in labelRefs and defined in labels", label.Name)
+		}
+		labels = append(labels, label)
+	}
+	return labels, nil
+}
+
+// inherit fills the fields the metric leaves empty with the ones of its resolved template
+func (m *Metric) inherit(base Metric) {
+	if m.Namespace == "" {
+		m.Namespace = base.Namespace
+	}
+	if m.Subsystem == "" {
+		m.Subsystem = base.Subsystem
+	}
+	if m.Type == "" {
+		m.Type = base.Type
+	}
+	if m.Help == "" {
+		m.Help = base.Help
+	}
+	if len(m.Buckets) == 0 {
+		m.Buckets = base.Buckets
+	}
+	if len(m.Objectives) == 0 {
+		m.Objectives = base.Objectives
+	}
+	if len(m.Examples.PromQL) == 0 && len(m.Examples.Alerts) == 0 {
+		m.Examples = base.Examples
+	}
+	if m.Deprecated == nil {
+		m.Deprecated = base.Deprecated
+	}
+	if m.Role == "" {
+		m.Role = base.Role
+	}
+	if m.Unit == "" {
+		m.Unit = base.Unit
+	}
+	if m.Semantics == "" {
+		m.Semantics = base.Semantics
+	}
+	if m.Stability == "" {
+		m.Stability = base.Stability
+	}
+	if m.Owner == nil {
+		m.Owner = base.Owner
+	}
+	if m.Since == "" {
+		m.Since = base.Since
+	}
+	if m.SLO == nil {
+		m.SLO = base.SLO
+	}
+
+	constLabels := slices.Clone(base.ConstLabels)
+	for _, label := range m.ConstLabels {
+		index := slices.IndexFunc(constLabels, func(other ConstLabelDefinition) bool { return other.Name == label.Name })
+		if index < 0 {
+			constLabels = append(constLabels, label)
+		} else {
+			constLabels[index] = label
+		}
+	}
+	m.ConstLabels = constLabels
+}
+
+// mergeLabels returns the inherited labels, replaced by the own labels of the same name,
+// followed by the other own labels
+func mergeLabels(inherited, own Labels) Labels {
+	labels := slices.Clone(inherited)
+	for _, label := range own {
+		index := slices.IndexFunc(labels, func(other LabelDefinition) bool { return other.Name == label.Name })
+		if index < 0 {
+			labels = append(labels, label)
+		} else {
+			labels[index] = label
+		}
+	}
+	return labels
+}
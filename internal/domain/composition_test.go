@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCompositionSpec(metrics map[string]Metric) *Specification {
+	return &Specification{
+		Labels: Labels{
+			{Name: "http_method", Description: "HTTP method", Validations: []string{"value in ['GET', 'POST']"}},
+			{Name: "route", Description: "Route template"},
+		},
+		MetricTemplates: map[string]Metric{
+			"http_server_base": {
+				Namespace:   "http",
+				Subsystem:   "server",
+				LabelRefs:   []string{"http_method", "route"},
+				ConstLabels: ConstLabels{{Name: "app", Value: "shop"}, {Name: "env", Value: "prod"}},
+			},
+			"http_server_latency": {
+				Extends: "http_server_base",
+				Type:    MetricTypeHistogram,
+				Unit:    UnitSeconds,
+				Buckets: []float64{0.1, 1},
+			},
+		},
+		Services: map[string]Service{
+			"api": {Metrics: metrics},
+		},
+	}
+}
+
+func TestSpecification_ResolveReferences(t *testing.T) {
+	spec := newCompositionSpec(map[string]Metric{
+		"request_duration_seconds": {
+			Extends:     "http_server_latency",
+			Help:        "Request duration",
+			Buckets:     []float64{0.5},
+			Labels:      Labels{{Name: "route", Description: "Matched route"}, {Name: "status"}},
+			ConstLabels: ConstLabels{{Name: "env", Value: "staging"}},
+		},
+		"requests_total": {
+			Namespace: "http",
+			Subsystem: "server",
+			Type:      MetricTypeCounter,
+			Help:      "Requests",
+			LabelRefs: []string{"http_method"},
+		},
+	})
+
+	require.NoError(t, spec.ResolveReferences())
+
+	duration := spec.Services["api"].Metrics["request_duration_seconds"]
+	assert.Equal(t, "http", duration.Namespace)
+	assert.Equal(t, "server", duration.Subsystem)
+	assert.Equal(t, MetricTypeHistogram, duration.Type)
+	assert.Equal(t, UnitSeconds, duration.Unit)
+	assert.Equal(t, []float64{0.5}, duration.Buckets, "own fields are kept")
+	assert.Equal(t, []string{"http_method", "route", "status"}, duration.Labels.ToStringSlice())
+	assert.Equal(t, "http_method", duration.Labels[0].Ref)
+	assert.Equal(t, []string{"value in ['GET', 'POST']"}, duration.Labels[0].Validations)
+	assert.Equal(t, LabelDefinition{Name: "route", Description: "Matched route"}, duration.Labels[1], "own labels replace the template ones")
+	assert.Equal(t, map[string]string{"app": "shop", "env": "staging"}, duration.ConstLabels.ToMap())
+
+	requests := spec.Services["api"].Metrics["requests_total"]
+	assert.Equal(t, []string{"http_method"}, requests.Labels.ToStringSlice())
+
+	assert.Equal(t, map[string]int{"http_method": 2}, spec.SharedLabelUsage())
+}
+
+func TestSpecification_ResolveReferences_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		metric  Metric
+		cycle   bool
+		wantErr string
+	}{
+		{
+			name:    "unknown template",
+			metric:  Metric{Extends: "missing"},
+			wantErr: `unknown metric template "missing"`,
+		},
+		{
+			name:    "unknown shared label",
+			metric:  Metric{LabelRefs: []string{"tenant"}},
+			wantErr: `unknown shared label "tenant"`,
+		},
+		{
+			name:    "label both referenced and defined",
+			metric:  Metric{LabelRefs: []string{"route"}, Labels: Labels{{Name: "route"}}},
+			wantErr: `label "route" is both referenced in labelRefs and defined in labels`,
+		},
+		{
+			name:    "template cycle",
+			metric:  Metric{Extends: "a"},
+			cycle:   true,
+			wantErr: "metric templates form a cycle: a -> b -> a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := newCompositionSpec(map[string]Metric{"requests_total": tt.metric})
+			if tt.cycle {
+				spec.MetricTemplates["a"] = Metric{Extends: "b"}
+				spec.MetricTemplates["b"] = Metric{Extends: "a"}
+			}
+
+			err := spec.ResolveReferences()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
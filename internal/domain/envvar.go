@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var envVarRegex = regexp.MustCompile(`^\$\{([^:}]+)(?::([^}]*))?\}$`)
+
+// envVarNameRegex matches the environment variable names usable in the generated code
+// (process.env.NAME in Node.js) and in shells
+var envVarNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// EnvVarRef is a reference to an environment variable in a const label value, ${NAME} or
+// ${NAME:default}
+type EnvVarRef struct {
+	Name    string
+	Default string // Empty string if no default provided
+}
+
+// ParseEnvVarRef returns the environment variable referenced by a const label value, and false
+// if the value is a literal
+func ParseEnvVarRef(value string) (EnvVarRef, bool) {
+	matches := envVarRegex.FindStringSubmatch(value)
+	if matches == nil {
+		return EnvVarRef{}, false
+	}
+	return EnvVarRef{Name: matches[1], Default: matches[2]}, true
+}
+
+// ValidateEnvVarRef returns an error if a const label value is a malformed environment variable
+// reference, e.g. "${REGION" or "eu-${REGION}", which ParseEnvVarRef reads as a literal, or if
+// the name of the variable cannot be used in the generated code.
+func ValidateEnvVarRef(value string) error {
+	ref, ok := ParseEnvVarRef(value)
+	if !ok {
+		if strings.Contains(value, "${") {
+			return fmt.Errorf("malformed environment variable reference %q: the whole value must be ${VAR} or ${VAR:default}", value)
+		}
+		return nil
+	}
+	if !envVarNameRegex.MatchString(ref.Name) {
+		return fmt.Errorf("invalid environment variable name %q in %q: expected letters, digits and underscores", ref.Name, value)
+	}
+	return nil
+}
+
+// EnvVarUsage is an environment variable read by the generated code for const label values
+type EnvVarUsage struct {
+	Name     string   `json:"name"`
+	Required bool     `json:"required"`           // Used without a default by at least one const label
+	Defaults []string `json:"defaults,omitempty"` // Distinct defaults of the const labels giving one
+	Labels   []string `json:"labels"`             // Paths of the const labels, services[s].metrics[k].constLabels[l]
+}
+
+// EnvVars returns the environment variables of the const labels of the specification, sorted by
+// name. An empty default counts as no default, like in the generated code.
+func (s *Specification) EnvVars() []EnvVarUsage {
+	usages := map[string]*EnvVarUsage{}
+	for _, serviceName := range slices.Sorted(maps.Keys(s.Services)) {
+		service := s.Services[serviceName]
+		for _, key := range slices.Sorted(maps.Keys(service.Metrics)) {
+			for _, label := range service.Metrics[key].ConstLabels {
+				ref, ok := ParseEnvVarRef(label.Value)
+				if !ok {
+					continue
+				}
+
+				usage, ok := usages[ref.Name]
+				if !ok {
+					usage = &EnvVarUsage{Name: ref.Name}
+					usages[ref.Name] = usage
+				}
+				usage.Labels = append(usage.Labels, fmt.Sprintf("services[%s].metrics[%s].constLabels[%s]", serviceName, key, label.Name))
+				if ref.Default == "" {
+					usage.Required = true
+				} else if !slices.Contains(usage.Defaults, ref.Default) {
+					usage.Defaults = append(usage.Defaults, ref.Default)
+				}
+			}
+		}
+	}
+
+	result := make([]EnvVarUsage, 0, len(usages))
+	for _, name := range slices.Sorted(maps.Keys(usages)) {
+		result = append(result, *usages[name])
+	}
+	return result
+}
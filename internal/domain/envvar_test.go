@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateEnvVarRef(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr string
+	}{
+		{name: "literal value", value: "production"},
+		{name: "env var without default", value: "${ENVIRONMENT}"},
+		{name: "env var with default", value: "${ENVIRONMENT:production}"},
+		{
+			name:    "unterminated reference",
+			value:   "${ENVIRONMENT",
+			wantErr: `malformed environment variable reference "${ENVIRONMENT": the whole value must be ${VAR} or ${VAR:default}`,
+		},
+		{
+			name:    "reference in a literal",
+			value:   "eu-${REGION}",
+			wantErr: `malformed environment variable reference "eu-${REGION}": the whole value must be ${VAR} or ${VAR:default}`,
+		},
+		{
+			name:    "invalid name",
+			value:   "${DEPLOY-ENV}",
+			wantErr: `invalid environment variable name "DEPLOY-ENV" in "${DEPLOY-ENV}": expected letters, digits and underscores`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEnvVarRef(tt.value)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestSpecification_EnvVars(t *testing.T) {
+	metric := func(labels ...ConstLabelDefinition) Metric {
+		return Metric{ConstLabels: labels}
+	}
+	spec := &Specification{
+		Services: map[string]Service{
+			"orders": {Metrics: map[string]Metric{
+				"requests_total": metric(
+					ConstLabelDefinition{Name: "env", Value: "${ENVIRONMENT:production}"},
+					ConstLabelDefinition{Name: "team", Value: "orders"},
+				),
+			}},
+			"payments": {Metrics: map[string]Metric{
+				"errors_total": metric(
+					ConstLabelDefinition{Name: "env", Value: "${ENVIRONMENT:staging}"},
+					ConstLabelDefinition{Name: "region", Value: "${REGION}"},
+				),
+				"requests_total": metric(
+					ConstLabelDefinition{Name: "env", Value: "${ENVIRONMENT:production}"},
+					ConstLabelDefinition{Name: "region", Value: "${REGION:}"},
+				),
+			}},
+		},
+	}
+
+	assert.Equal(t, []EnvVarUsage{
+		{
+			Name:     "ENVIRONMENT",
+			Defaults: []string{"production", "staging"},
+			Labels: []string{
+				"services[orders].metrics[requests_total].constLabels[env]",
+				"services[payments].metrics[errors_total].constLabels[env]",
+				"services[payments].metrics[requests_total].constLabels[env]",
+			},
+		},
+		{
+			Name:     "REGION",
+			Required: true,
+			Labels: []string{
+				"services[payments].metrics[errors_total].constLabels[region]",
+				"services[payments].metrics[requests_total].constLabels[region]",
+			},
+		},
+	}, spec.EnvVars())
+}
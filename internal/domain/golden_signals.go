@@ -21,10 +21,45 @@ type GoldenSignal struct {
 	Thresholds     *Thresholds     `yaml:"thresholds,omitempty"`
 }
 
+// HTTPLabelMapping declares which labels of the golden signal metrics receive
+// the request method, route template and status code in generated HTTP middleware
+type HTTPLabelMapping struct {
+	Method      string `yaml:"method,omitempty"`
+	Route       string `yaml:"route,omitempty"`
+	StatusCode  string `yaml:"statusCode,omitempty"`
+	StatusClass string `yaml:"statusClass,omitempty"`
+}
+
+// Source returns the request attribute mapped to the label, or an empty string if the label is not mapped
+func (m *HTTPLabelMapping) Source(label string) string {
+	switch label {
+	case "":
+		return ""
+	case m.Method:
+		return HTTPSourceMethod
+	case m.Route:
+		return HTTPSourceRoute
+	case m.StatusCode:
+		return HTTPSourceStatusCode
+	case m.StatusClass:
+		return HTTPSourceStatusClass
+	}
+	return ""
+}
+
+// Request attributes that can be mapped to a label
+const (
+	HTTPSourceMethod      = "method"
+	HTTPSourceRoute       = "route"
+	HTTPSourceStatusCode  = "statusCode"
+	HTTPSourceStatusClass = "statusClass"
+)
+
 // GoldenSignals groups the four golden signals
 type GoldenSignals struct {
-	Latency    *GoldenSignal `yaml:"latency,omitempty"`
-	Errors     *GoldenSignal `yaml:"errors,omitempty"`
-	Traffic    *GoldenSignal `yaml:"traffic,omitempty"`
-	Saturation *GoldenSignal `yaml:"saturation,omitempty"`
+	HTTP       *HTTPLabelMapping `yaml:"http,omitempty"`
+	Latency    *GoldenSignal     `yaml:"latency,omitempty"`
+	Errors     *GoldenSignal     `yaml:"errors,omitempty"`
+	Traffic    *GoldenSignal     `yaml:"traffic,omitempty"`
+	Saturation *GoldenSignal     `yaml:"saturation,omitempty"`
 }
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestHTTPLabelMapping_Source(t *testing.T) {
+	mapping := &HTTPLabelMapping{
+		Method:     "method",
+		Route:      "path",
+		StatusCode: "status",
+	}
+
+	tests := []struct {
+		label string
+		want  string
+	}{
+		{label: "method", want: HTTPSourceMethod},
+		{label: "path", want: HTTPSourceRoute},
+		{label: "status", want: HTTPSourceStatusCode},
+		{label: "service", want: ""},
+		{label: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.label, func(t *testing.T) {
+			assert.Equal(t, tt.want, mapping.Source(tt.label))
+		})
+	}
+}
+
+func TestGoldenSignals_UnmarshalHTTPMapping(t *testing.T) {
+	input := `
+http:
+  method: method
+  route: path
+  statusClass: status_class
+traffic:
+  description: Request volume
+  metrics: [requests_total]
+`
+	var signals GoldenSignals
+	require.NoError(t, yaml.Unmarshal([]byte(input), &signals))
+
+	require.NotNil(t, signals.HTTP)
+	assert.Equal(t, "method", signals.HTTP.Method)
+	assert.Equal(t, "path", signals.HTTP.Route)
+	assert.Equal(t, "status_class", signals.HTTP.StatusClass)
+	assert.Empty(t, signals.HTTP.StatusCode)
+	assert.Equal(t, HTTPSourceStatusClass, signals.HTTP.Source("status_class"))
+}
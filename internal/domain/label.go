@@ -12,6 +12,7 @@ type LabelDefinition struct {
 	Description string   `yaml:"description,omitempty"`
 	Validations []string `yaml:"validations,omitempty"`
 	Inherited   string   `yaml:"inherited,omitempty"` // Documentation for labels added via relabeling
+	Ref         string   `yaml:"ref,omitempty"`       // Name of the shared label it was resolved from (labelRefs)
 }
 
 // Labels can be either a simple array of strings or a map with descriptions
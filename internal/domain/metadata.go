@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Units are the base units of the schema v2, following the Prometheus naming conventions
+var Units = []string{UnitSeconds, "bytes", "ratio", "celsius", "volts", "amperes", "joules", "grams", "meters"}
+
+// Stability is the stability level of a metric, following the Kubernetes metrics stability framework
+type Stability string
+
+const (
+	// StabilityAlpha marks a metric that can change or be removed at any time
+	StabilityAlpha Stability = "alpha"
+	// StabilityBeta marks a metric whose name and labels only change after a deprecation period
+	StabilityBeta Stability = "beta"
+	// StabilityStable marks a metric whose name and labels are guaranteed not to change
+	StabilityStable Stability = "stable"
+)
+
+// IsValid checks if the stability level is valid
+func (s Stability) IsValid() bool {
+	switch s {
+	case StabilityAlpha, StabilityBeta, StabilityStable:
+		return true
+	}
+	return false
+}
+
+// Owner identifies the team responsible for a metric
+type Owner struct {
+	Team    string `yaml:"team"`
+	Contact string `yaml:"contact,omitempty"` // e.g. a Slack channel or an email address
+}
+
+// String returns the team followed by its contact, if any
+func (o Owner) String() string {
+	if o.Contact == "" {
+		return o.Team
+	}
+	return fmt.Sprintf("%s (%s)", o.Team, o.Contact)
+}
+
+// SLO is a service level objective measured with a metric
+type SLO struct {
+	Objective   float64 `yaml:"objective"` // Target ratio of good events, e.g. 0.999
+	Window      string  `yaml:"window"`    // Prometheus duration of the compliance window, e.g. 30d
+	Description string  `yaml:"description,omitempty"`
+}
+
+// String describes the objective, e.g. "99.9% over 30d"
+func (s SLO) String() string {
+	objective := strconv.FormatFloat(s.Objective*100, 'f', -1, 64) + "% over " + s.Window
+	if s.Description == "" {
+		return objective
+	}
+	return objective + ": " + s.Description
+}
+
+var durationRegex = regexp.MustCompile(`^([0-9]+(ms|s|m|h|d|w|y))+$`)
+
+// validateMetadata checks the stability, owner and SLO of the metric
+func (m *Metric) validateMetadata() error {
+	if m.Stability != "" && !m.Stability.IsValid() {
+		return fmt.Errorf("invalid metric stability: %s (expected alpha, beta or stable)", m.Stability)
+	}
+	if m.Owner != nil && m.Owner.Team == "" {
+		return fmt.Errorf("metric owner requires a team")
+	}
+	if m.SLO != nil {
+		if m.SLO.Objective <= 0 || m.SLO.Objective >= 1 {
+			return fmt.Errorf("slo objective must be between 0 and 1 exclusive, got %v", m.SLO.Objective)
+		}
+		if !durationRegex.MatchString(m.SLO.Window) {
+			return fmt.Errorf("invalid slo window: %q (expected a Prometheus duration, e.g. 30d)", m.SLO.Window)
+		}
+	}
+	return nil
+}
+
+// ValidateUnit checks that the unit is a base unit and that the metric name ends with it
+// (followed by _total for counters), as required by the schema v2
+func (m *Metric) ValidateUnit() error {
+	if m.Unit == "" {
+		return nil
+	}
+	if !slices.Contains(Units, m.Unit) {
+		return fmt.Errorf("invalid metric unit: %s (expected one of %s)", m.Unit, strings.Join(Units, ", "))
+	}
+
+	suffix := "_" + m.Unit
+	if m.Type == MetricTypeCounter {
+		suffix += "_total"
+	}
+	if !strings.HasSuffix(m.Name, suffix) {
+		return fmt.Errorf("metric name %s must end with %s to match its unit %s", m.Name, suffix, m.Unit)
+	}
+	return nil
+}
+
+// SuffixUnit returns the base unit the metric name ends with, before the _total suffix of counters,
+// or an empty string
+func (m *Metric) SuffixUnit() string {
+	name := m.Name
+	if m.Type == MetricTypeCounter {
+		name = strings.TrimSuffix(name, "_total")
+	}
+	for _, unit := range Units {
+		if strings.HasSuffix(name, "_"+unit) {
+			return unit
+		}
+	}
+	return ""
+}
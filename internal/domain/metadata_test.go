@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetric_ValidateUnit(t *testing.T) {
+	tests := []struct {
+		name    string
+		metric  Metric
+		wantErr string
+	}{
+		{name: "no unit", metric: Metric{Name: "requests", Type: MetricTypeGauge}},
+		{name: "histogram in seconds", metric: Metric{Name: "request_duration_seconds", Type: MetricTypeHistogram, Unit: "seconds"}},
+		{name: "counter in bytes", metric: Metric{Name: "sent_bytes_total", Type: MetricTypeCounter, Unit: "bytes"}},
+		{
+			name:    "unknown unit",
+			metric:  Metric{Name: "request_duration_milliseconds", Type: MetricTypeHistogram, Unit: "milliseconds"},
+			wantErr: "invalid metric unit: milliseconds",
+		},
+		{
+			name:    "name without the unit suffix",
+			metric:  Metric{Name: "request_duration", Type: MetricTypeHistogram, Unit: "seconds"},
+			wantErr: "metric name request_duration must end with _seconds to match its unit seconds",
+		},
+		{
+			name:    "counter without _total",
+			metric:  Metric{Name: "sent_bytes", Type: MetricTypeCounter, Unit: "bytes"},
+			wantErr: "must end with _bytes_total",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.metric.ValidateUnit()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestMetric_SuffixUnit(t *testing.T) {
+	assert.Equal(t, "seconds", (&Metric{Name: "request_duration_seconds", Type: MetricTypeHistogram}).SuffixUnit())
+	assert.Equal(t, "bytes", (&Metric{Name: "sent_bytes_total", Type: MetricTypeCounter}).SuffixUnit())
+	assert.Equal(t, "ratio", (&Metric{Name: "cache_hit_ratio", Type: MetricTypeGauge}).SuffixUnit())
+	assert.Empty(t, (&Metric{Name: "sent_bytes_total", Type: MetricTypeGauge}).SuffixUnit())
+	assert.Empty(t, (&Metric{Name: "requests_total", Type: MetricTypeCounter}).SuffixUnit())
+}
+
+func TestMetric_ValidateMetadata(t *testing.T) {
+	base := Metric{Name: "payments_total", Namespace: "payments", Subsystem: "api", Type: MetricTypeCounter, Help: "Payments"}
+
+	tests := []struct {
+		name    string
+		modify  func(*Metric)
+		wantErr string
+	}{
+		{
+			name: "valid metadata",
+			modify: func(m *Metric) {
+				m.Stability = StabilityStable
+				m.Owner = &Owner{Team: "payments", Contact: "#payments"}
+				m.Since = "1.0.0"
+				m.SLO = &SLO{Objective: 0.999, Window: "30d"}
+			},
+		},
+		{
+			name:    "invalid stability",
+			modify:  func(m *Metric) { m.Stability = "ga" },
+			wantErr: "invalid metric stability: ga",
+		},
+		{
+			name:    "owner without team",
+			modify:  func(m *Metric) { m.Owner = &Owner{Contact: "#payments"} },
+			wantErr: "metric owner requires a team",
+		},
+		{
+			name:    "slo objective as a percentage",
+			modify:  func(m *Metric) { m.SLO = &SLO{Objective: 99.9, Window: "30d"} },
+			wantErr: "slo objective must be between 0 and 1",
+		},
+		{
+			name:    "invalid slo window",
+			modify:  func(m *Metric) { m.SLO = &SLO{Objective: 0.99, Window: "1 month"} },
+			wantErr: "invalid slo window",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metric := base
+			tt.modify(&metric)
+			err := metric.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestMetadata_String(t *testing.T) {
+	assert.Equal(t, "payments", Owner{Team: "payments"}.String())
+	assert.Equal(t, "payments (#payments)", Owner{Team: "payments", Contact: "#payments"}.String())
+	assert.Equal(t, "99.9% over 30d", SLO{Objective: 0.999, Window: "30d"}.String())
+	assert.Equal(t, "99% over 7d: Fast requests", SLO{Objective: 0.99, Window: "7d", Description: "Fast requests"}.String())
+}
+
+func TestSpecification_Validate_UnitSuffixV2(t *testing.T) {
+	newSpec := func(version string) *Specification {
+		return &Specification{
+			Version: version,
+			Info:    Info{Title: "Test", Version: "1.0.0"},
+			Services: map[string]Service{
+				"api": {
+					Info: Info{Title: "API", Version: "1.0.0"},
+					Metrics: map[string]Metric{
+						"request_duration": {Namespace: "http", Subsystem: "server", Type: MetricTypeHistogram, Help: "Duration", Unit: "seconds", Buckets: []float64{1}},
+					},
+				},
+			},
+		}
+	}
+
+	assert.NoError(t, newSpec("1.0.0").Validate(), "v1 units are not checked")
+	assert.ErrorContains(t, newSpec("2.0.0").Validate(), "must end with _seconds")
+	assert.Equal(t, 2, newSpec("2.1").MajorVersion())
+	assert.Zero(t, newSpec("latest").MajorVersion())
+}
@@ -27,6 +27,21 @@ type Metric struct {
 	ConstLabels ConstLabels         `yaml:"constLabels,omitempty"`
 	Examples    Examples            `yaml:"examples,omitempty"`
 	Deprecated  *Deprecated         `yaml:"deprecated,omitempty"`
+	Role        MetricRole          `yaml:"role,omitempty"`
+	Unit        string              `yaml:"unit,omitempty"`
+	Semantics   MetricSemantics     `yaml:"semantics,omitempty"`
+	Extends     string              `yaml:"extends,omitempty"`   // Metric template it inherits from
+	LabelRefs   []string            `yaml:"labelRefs,omitempty"` // Shared labels it uses
+	Stability   Stability           `yaml:"stability,omitempty"`
+	Owner       *Owner              `yaml:"owner,omitempty"`
+	Since       string              `yaml:"since,omitempty"` // Version of the specification introducing the metric
+	SLO         *SLO                `yaml:"slo,omitempty"`
+}
+
+// HasTimer returns true if the metric observes durations in seconds (Time<Metric> helpers).
+// Timestamp and in-flight gauges are excluded.
+func (m *Metric) HasTimer() bool {
+	return m.Unit == UnitSeconds && m.Type != MetricTypeCounter && m.Semantics == ""
 }
 
 // GetLabelNames returns just the label names as a string slice for backward compatibility
@@ -97,6 +112,23 @@ func (m *Metric) Validate() error {
 		}
 	}
 
+	if m.Role != "" && !m.Role.IsValid() {
+		return fmt.Errorf("invalid metric role: %s", m.Role)
+	}
+
+	if m.Semantics != "" {
+		if !m.Semantics.IsValid() {
+			return fmt.Errorf("invalid metric semantics: %s", m.Semantics)
+		}
+		if m.Type != MetricTypeGauge {
+			return fmt.Errorf("metric semantics %s requires a gauge, got %s", m.Semantics, m.Type)
+		}
+	}
+
+	if err := m.validateMetadata(); err != nil {
+		return err
+	}
+
 	// Type-specific validation
 	if m.Type == MetricTypeHistogram && len(m.Buckets) == 0 {
 		return fmt.Errorf("histogram metrics require buckets")
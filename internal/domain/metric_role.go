@@ -0,0 +1,38 @@
+package domain
+
+import "strings"
+
+// MetricRole binds a metric to generated instrumentation code (e.g. gRPC interceptors)
+type MetricRole string
+
+const (
+	MetricRoleGRPCServerStarted         MetricRole = "grpc_server_started"
+	MetricRoleGRPCServerHandled         MetricRole = "grpc_server_handled"
+	MetricRoleGRPCServerHandlingSeconds MetricRole = "grpc_server_handling_seconds"
+	MetricRoleGRPCServerInFlight        MetricRole = "grpc_server_in_flight"
+	MetricRoleGRPCServerMsgReceived     MetricRole = "grpc_server_msg_received"
+	MetricRoleGRPCServerMsgSent         MetricRole = "grpc_server_msg_sent"
+	MetricRoleGRPCClientStarted         MetricRole = "grpc_client_started"
+	MetricRoleGRPCClientHandled         MetricRole = "grpc_client_handled"
+	MetricRoleGRPCClientHandlingSeconds MetricRole = "grpc_client_handling_seconds"
+	MetricRoleGRPCClientInFlight        MetricRole = "grpc_client_in_flight"
+	MetricRoleGRPCClientMsgReceived     MetricRole = "grpc_client_msg_received"
+	MetricRoleGRPCClientMsgSent         MetricRole = "grpc_client_msg_sent"
+)
+
+// IsValid checks if the metric role is valid
+func (r MetricRole) IsValid() bool {
+	switch r {
+	case MetricRoleGRPCServerStarted, MetricRoleGRPCServerHandled, MetricRoleGRPCServerHandlingSeconds,
+		MetricRoleGRPCServerInFlight, MetricRoleGRPCServerMsgReceived, MetricRoleGRPCServerMsgSent,
+		MetricRoleGRPCClientStarted, MetricRoleGRPCClientHandled, MetricRoleGRPCClientHandlingSeconds,
+		MetricRoleGRPCClientInFlight, MetricRoleGRPCClientMsgReceived, MetricRoleGRPCClientMsgSent:
+		return true
+	}
+	return false
+}
+
+// IsGRPC returns true if the role is recorded by the generated gRPC interceptors
+func (r MetricRole) IsGRPC() bool {
+	return r.IsValid() && strings.HasPrefix(string(r), "grpc_")
+}
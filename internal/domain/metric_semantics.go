@@ -0,0 +1,23 @@
+package domain
+
+// UnitSeconds is the metric unit enabling the generated timer helpers
+const UnitSeconds = "seconds"
+
+// MetricSemantics describes what a gauge measures, enabling the matching generated helpers
+type MetricSemantics string
+
+const (
+	// MetricSemanticsInFlight marks a gauge counting concurrent work (Track<Metric>InFlight helpers)
+	MetricSemanticsInFlight MetricSemantics = "in_flight"
+	// MetricSemanticsTimestamp marks a gauge holding a Unix timestamp (SetToCurrentTime<Metric> helpers)
+	MetricSemanticsTimestamp MetricSemantics = "timestamp"
+)
+
+// IsValid checks if the metric semantics is valid
+func (s MetricSemantics) IsValid() bool {
+	switch s {
+	case MetricSemanticsInFlight, MetricSemanticsTimestamp:
+		return true
+	}
+	return false
+}
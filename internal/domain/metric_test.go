@@ -202,6 +202,69 @@ func TestMetric_Validate(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid role",
+			metric: Metric{
+				Name:      "handled_total",
+				Namespace: "grpc",
+				Subsystem: "server",
+				Type:      MetricTypeCounter,
+				Help:      "Test",
+				Role:      MetricRoleGRPCServerHandled,
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid semantics",
+			metric: Metric{
+				Name:      "requests_in_flight",
+				Namespace: "http",
+				Subsystem: "server",
+				Type:      MetricTypeGauge,
+				Help:      "Test",
+				Semantics: MetricSemanticsInFlight,
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid semantics",
+			metric: Metric{
+				Name:      "requests_in_flight",
+				Namespace: "http",
+				Subsystem: "server",
+				Type:      MetricTypeGauge,
+				Help:      "Test",
+				Semantics: "concurrency",
+			},
+			wantErr: true,
+			errMsg:  "invalid metric semantics",
+		},
+		{
+			name: "semantics on a counter",
+			metric: Metric{
+				Name:      "requests_total",
+				Namespace: "http",
+				Subsystem: "server",
+				Type:      MetricTypeCounter,
+				Help:      "Test",
+				Semantics: MetricSemanticsTimestamp,
+			},
+			wantErr: true,
+			errMsg:  "requires a gauge",
+		},
+		{
+			name: "invalid role",
+			metric: Metric{
+				Name:      "handled_total",
+				Namespace: "grpc",
+				Subsystem: "server",
+				Type:      MetricTypeCounter,
+				Help:      "Test",
+				Role:      "http_handled",
+			},
+			wantErr: true,
+			errMsg:  "invalid metric role",
+		},
 	}
 
 	for _, tt := range tests {
@@ -262,3 +325,43 @@ func TestMetricType_IsValid(t *testing.T) {
 		})
 	}
 }
+
+func TestMetric_HasTimer(t *testing.T) {
+	tests := []struct {
+		name   string
+		metric Metric
+		want   bool
+	}{
+		{
+			name:   "histogram in seconds",
+			metric: Metric{Type: MetricTypeHistogram, Unit: UnitSeconds},
+			want:   true,
+		},
+		{
+			name:   "gauge in seconds",
+			metric: Metric{Type: MetricTypeGauge, Unit: UnitSeconds},
+			want:   true,
+		},
+		{
+			name:   "timestamp gauge",
+			metric: Metric{Type: MetricTypeGauge, Unit: UnitSeconds, Semantics: MetricSemanticsTimestamp},
+			want:   false,
+		},
+		{
+			name:   "counter in seconds",
+			metric: Metric{Type: MetricTypeCounter, Unit: UnitSeconds},
+			want:   false,
+		},
+		{
+			name:   "histogram in bytes",
+			metric: Metric{Type: MetricTypeHistogram, Unit: "bytes"},
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.metric.HasTimer())
+		})
+	}
+}
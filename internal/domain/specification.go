@@ -1,12 +1,20 @@
 package domain
 
-import "fmt"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 // Specification represents the complete metrics specification (like OpenAPI spec)
 type Specification struct {
 	Version  string             `yaml:"version"`
 	Info     Info               `yaml:"info"`
 	Services map[string]Service `yaml:"services"`
+
+	// Reusable definitions, expanded into the metrics by ResolveReferences
+	Labels          Labels            `yaml:"labels,omitempty"`          // Shared labels referenced by labelRefs
+	MetricTemplates map[string]Metric `yaml:"metricTemplates,omitempty"` // Partial metrics referenced by extends
 }
 
 // Info contains metadata about the metrics specification
@@ -54,8 +62,24 @@ func (s *Specification) Validate() error {
 			if err := metric.Validate(); err != nil {
 				return fmt.Errorf("service %s: invalid metric %s: %w", serviceName, name, err)
 			}
+			if s.MajorVersion() >= 2 {
+				if err := metric.ValidateUnit(); err != nil {
+					return fmt.Errorf("service %s: invalid metric %s: %w", serviceName, name, err)
+				}
+			}
 		}
 	}
 
 	return nil
 }
+
+// MajorVersion returns the major version of the schema used by the specification (e.g. 2 for "2.0.0"),
+// or 0 if the version is invalid
+func (s *Specification) MajorVersion() int {
+	major, _, _ := strings.Cut(s.Version, ".")
+	version, err := strconv.Atoi(major)
+	if err != nil {
+		return 0
+	}
+	return version
+}
@@ -0,0 +1,217 @@
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/jycamier/promener/internal/validator"
+)
+
+var updateBudgets = flag.Bool("update-budgets", false, "rewrite testdata/benchmark/budgets.json from the measured benchmarks")
+
+const (
+	benchmarkSpec    = "../../testdata/benchmark.cue"
+	benchmarkDir     = "testdata/benchmark"
+	benchmarkBudgets = "testdata/benchmark/budgets.json"
+
+	// budgetHeadroom is applied to the measured ns/op when updating the budgets,
+	// so that the suite doesn't fail on a slower or busier machine
+	budgetHeadroom = 3
+)
+
+// benchmarkBudget is the maximum cost allowed for a benchmark of the generated code
+type benchmarkBudget struct {
+	NsPerOp     float64 `json:"ns_per_op"`
+	AllocsPerOp int64   `json:"allocs_per_op"`
+}
+
+// benchmarkLine matches a result line of go test -bench -benchmem (the -GOMAXPROCS suffix is dropped)
+var benchmarkLine = regexp.MustCompile(`^(Benchmark\w+?)(?:-\d+)?\s+\d+\s+([\d.]+) ns/op\s+[\d.]+ B/op\s+(\d+) allocs/op`)
+
+// TestGeneratedCodeBenchmarks generates the Go code of testdata/benchmark.cue, compiles it in a
+// temporary module with the benchmarks of testdata/benchmark, and fails when a benchmark goes over
+// its budget. Run with -update-budgets after an intended change of the hot path cost.
+func TestGeneratedCodeBenchmarks(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping the generated code benchmarks in short mode")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not found")
+	}
+
+	spec, _, err := validator.New().ValidateAndExtract(benchmarkSpec)
+	if err != nil {
+		t.Fatalf("failed to load %s: %v", benchmarkSpec, err)
+	}
+
+	moduleDir := t.TempDir()
+	packageDir := filepath.Join(moduleDir, "metrics")
+	gen, err := NewGolangGenerator("metrics", packageDir)
+	if err != nil {
+		t.Fatalf("NewGolangGenerator() error = %v", err)
+	}
+	if err := gen.GenerateMetrics(spec); err != nil {
+		t.Fatalf("GolangGenerator.GenerateMetrics() error = %v", err)
+	}
+	writeBenchmarkModule(t, moduleDir, packageDir)
+
+	cmd := exec.Command(goBin, "test", "-run", "^$", "-bench", ".", "-benchmem", "-benchtime", "200ms", ".")
+	cmd.Dir = packageDir
+	// The generated code only requires prometheus and cel-go, resolve their dependencies from go.sum
+	cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("failed to run the benchmarks of the generated code: %v\n%s", err, output)
+	}
+
+	results := parseBenchmarkResults(t, output)
+	if len(results) == 0 {
+		t.Fatalf("no benchmark results in the output:\n%s", output)
+	}
+	for _, name := range sortedKeys(results) {
+		t.Logf("%s: %.1f ns/op, %d allocs/op", name, results[name].NsPerOp, results[name].AllocsPerOp)
+	}
+
+	if *updateBudgets {
+		writeBenchmarkBudgets(t, results)
+		return
+	}
+
+	budgets := readBenchmarkBudgets(t)
+	for _, name := range sortedKeys(results) {
+		result := results[name]
+		budget, ok := budgets[name]
+		if !ok {
+			t.Errorf("%s has no budget in %s (run with -update-budgets)", name, benchmarkBudgets)
+			continue
+		}
+		if result.NsPerOp > budget.NsPerOp {
+			t.Errorf("%s: %.1f ns/op is over the budget of %.0f ns/op", name, result.NsPerOp, budget.NsPerOp)
+		}
+		if result.AllocsPerOp > budget.AllocsPerOp {
+			t.Errorf("%s: %d allocs/op is over the budget of %d allocs/op", name, result.AllocsPerOp, budget.AllocsPerOp)
+		}
+	}
+	for _, name := range sortedKeys(budgets) {
+		if _, ok := results[name]; !ok {
+			t.Errorf("%s has a budget but was not run", name)
+		}
+	}
+}
+
+// writeBenchmarkModule turns moduleDir into a Go module requiring the versions of prometheus
+// and cel-go used by promener, and copies the benchmarks next to the generated code
+func writeBenchmarkModule(t *testing.T, moduleDir, packageDir string) {
+	t.Helper()
+
+	benchmarks, err := os.ReadFile(filepath.Join(benchmarkDir, "metrics_bench_test.go"))
+	if err != nil {
+		t.Fatalf("failed to read the benchmarks: %v", err)
+	}
+	writeGeneratedModule(t, "promenerbench", moduleDir, nil, map[string][]byte{
+		filepath.Join(packageDir, "metrics_bench_test.go"): benchmarks,
+	})
+}
+
+// writeGeneratedModule turns moduleDir into a Go module requiring the versions of prometheus and
+// cel-go used by promener and the extra modules, and writes the files
+func writeGeneratedModule(t *testing.T, name, moduleDir string, extraRequires []string, files map[string][]byte) {
+	t.Helper()
+
+	goMod, err := os.ReadFile("../../go.mod")
+	if err != nil {
+		t.Fatalf("failed to read go.mod: %v", err)
+	}
+	var requires []string
+	for _, module := range []string{"github.com/google/cel-go", "github.com/prometheus/client_golang"} {
+		match := regexp.MustCompile(`(?m)^\s*` + regexp.QuoteMeta(module) + `\s+(v\S+)`).FindSubmatch(goMod)
+		if match == nil {
+			t.Fatalf("go.mod does not require %s", module)
+		}
+		requires = append(requires, fmt.Sprintf("\t%s %s", module, match[1]))
+	}
+	for _, module := range extraRequires {
+		requires = append(requires, "\t"+module)
+	}
+	goVersion := regexp.MustCompile(`(?m)^go\s+(\S+)`).FindSubmatch(goMod)
+	if goVersion == nil {
+		t.Fatal("go.mod has no go directive")
+	}
+
+	files[filepath.Join(moduleDir, "go.mod")] = fmt.Appendf(nil, "module %s\n\ngo %s\n\nrequire (\n%s\n)\n", name, goVersion[1], strings.Join(requires, "\n"))
+	if files[filepath.Join(moduleDir, "go.sum")], err = os.ReadFile("../../go.sum"); err != nil {
+		t.Fatalf("failed to read go.sum: %v", err)
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+}
+
+func parseBenchmarkResults(t *testing.T, output []byte) map[string]benchmarkBudget {
+	t.Helper()
+	results := make(map[string]benchmarkBudget)
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		match := benchmarkLine.FindStringSubmatch(scanner.Text())
+		if match == nil {
+			continue
+		}
+		nsPerOp, err := strconv.ParseFloat(match[2], 64)
+		if err != nil {
+			t.Fatalf("invalid ns/op in %q: %v", scanner.Text(), err)
+		}
+		allocsPerOp, err := strconv.ParseInt(match[3], 10, 64)
+		if err != nil {
+			t.Fatalf("invalid allocs/op in %q: %v", scanner.Text(), err)
+		}
+		results[match[1]] = benchmarkBudget{NsPerOp: nsPerOp, AllocsPerOp: allocsPerOp}
+	}
+	return results
+}
+
+func readBenchmarkBudgets(t *testing.T) map[string]benchmarkBudget {
+	t.Helper()
+	content, err := os.ReadFile(benchmarkBudgets)
+	if err != nil {
+		t.Fatalf("failed to read the budgets: %v", err)
+	}
+	var budgets map[string]benchmarkBudget
+	if err := json.Unmarshal(content, &budgets); err != nil {
+		t.Fatalf("failed to parse %s: %v", benchmarkBudgets, err)
+	}
+	return budgets
+}
+
+func writeBenchmarkBudgets(t *testing.T, results map[string]benchmarkBudget) {
+	t.Helper()
+	budgets := make(map[string]benchmarkBudget, len(results))
+	for name, result := range results {
+		budgets[name] = benchmarkBudget{
+			// Round up to ten nanoseconds to keep the budgets readable
+			NsPerOp:     math.Ceil(result.NsPerOp*budgetHeadroom/10) * 10,
+			AllocsPerOp: result.AllocsPerOp,
+		}
+	}
+	content, err := json.MarshalIndent(budgets, "", "  ")
+	if err != nil {
+		t.Fatalf("failed to encode the budgets: %v", err)
+	}
+	if err := os.WriteFile(benchmarkBudgets, append(content, '\n'), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", benchmarkBudgets, err)
+	}
+	t.Logf("updated %s", benchmarkBudgets)
+}
@@ -1,7 +1,10 @@
 package generator
 
 import (
+	"fmt"
 	"sort"
+	"strings"
+	"unicode"
 
 	"github.com/jycamier/promener/internal/domain"
 )
@@ -22,10 +25,13 @@ func NewCommonTemplateDataBuilder() *CommonTemplateDataBuilder {
 // BuildTemplateData creates the base template data with namespace/subsystem organization
 func (b *CommonTemplateDataBuilder) BuildTemplateData(spec *domain.Specification, packageName string) *TemplateData {
 	nsMap := make(map[string]map[string][]MetricData)
+	metricUnits := make(map[string]string)
 
-	// Group metrics by namespace and subsystem
-	for _, service := range spec.Services {
-		for key, metric := range service.Metrics {
+	// Group metrics by namespace and subsystem, in key order so that the generated code is stable
+	for _, serviceName := range sortedKeys(spec.Services) {
+		service := spec.Services[serviceName]
+		for _, key := range sortedKeys(service.Metrics) {
+			metric := service.Metrics[key]
 			if metric.Name == "" {
 				metric.Name = key
 			}
@@ -62,23 +68,58 @@ func (b *CommonTemplateDataBuilder) BuildTemplateData(spec *domain.Specification
 				MethodName:       toCamelCase(metric.Name),
 				FullName:         metric.FullName(),
 				Deprecated:       metric.Deprecated,
+				HasTimer:         metric.HasTimer(),
+				HasInFlight:      metric.Semantics == domain.MetricSemanticsInFlight,
+				InFlightName:     strings.TrimSuffix(toCamelCase(metric.Name), "InFlight") + "InFlight",
+				HasCurrentTime:   metric.Semantics == domain.MetricSemanticsTimestamp,
+				Metadata:         metricMetadata(metric),
 			})
+			// Like in catalog.OpenMetrics, only the names ending with the unit get one: expfmt.WithUnit
+			// renames the families missing the suffix, which the v1 specifications don't require
+			family := metric.FullName()
+			if metric.Type == domain.MetricTypeCounter {
+				family = strings.TrimSuffix(family, "_total")
+			}
+			if metric.Unit != "" && strings.HasSuffix(family, "_"+metric.Unit) {
+				metricUnits[metric.FullName()] = metric.Unit
+			}
 		}
 	}
 
 	// Build namespaces structure
 	var namespaces []Namespace
-	for nsName, subsystems := range nsMap {
+	for _, nsName := range sortedKeys(nsMap) {
+		subsystems := nsMap[nsName]
 		var ssList []Subsystem
-		for ssName, metrics := range subsystems {
+		required := make(map[string]ConfigField)
+		for _, ssName := range sortedKeys(subsystems) {
+			usesConfig := false
+			for _, metric := range subsystems[ssName] {
+				for _, value := range metric.ConstLabels {
+					usesConfig = usesConfig || value.IsEnvVar
+					if value.IsEnvVar && value.DefaultValue == "" {
+						required[value.EnvVar] = ConfigField{
+							EnvVar:    value.EnvVar,
+							FieldName: configFieldName(value.EnvVar),
+							Required:  true,
+						}
+					}
+				}
+			}
 			ssList = append(ssList, Subsystem{
-				Name:    ssName,
-				Metrics: metrics,
+				Name:       ssName,
+				Metrics:    subsystems[ssName],
+				UsesConfig: usesConfig,
 			})
 		}
+		var requiredConfig []ConfigField
+		for _, envVar := range sortedKeys(required) {
+			requiredConfig = append(requiredConfig, required[envVar])
+		}
 		namespaces = append(namespaces, Namespace{
-			Name:       nsName,
-			Subsystems: ssList,
+			Name:           nsName,
+			Subsystems:     ssList,
+			RequiredConfig: requiredConfig,
 		})
 	}
 
@@ -97,13 +138,63 @@ func (b *CommonTemplateDataBuilder) BuildTemplateData(spec *domain.Specification
 		}
 	}
 
+	var configFields []ConfigField
+	for _, usage := range spec.EnvVars() {
+		configFields = append(configFields, ConfigField{
+			EnvVar:    usage.Name,
+			FieldName: configFieldName(usage.Name),
+			Required:  usage.Required,
+			Defaults:  usage.Defaults,
+		})
+	}
+
 	return &TemplateData{
 		PackageName:     packageName,
 		Info:            spec.Info,
 		Namespaces:      namespaces,
 		NeedsOsImport:   needsOs,
 		NeedsHelperFunc: needsHelper,
+		ConfigFields:    configFields,
+		MetricUnits:     metricUnits,
+	}
+}
+
+// metricMetadata returns the documentation lines of the metric unit, stability, owner, since and SLO
+func metricMetadata(metric domain.Metric) []string {
+	var lines []string
+	if metric.Unit != "" {
+		lines = append(lines, "Unit: "+metric.Unit)
+	}
+	if metric.Stability != "" {
+		lines = append(lines, "Stability: "+strings.ToUpper(string(metric.Stability)))
+	}
+	if metric.Owner != nil {
+		lines = append(lines, "Owner: "+metric.Owner.String())
 	}
+	if metric.Since != "" {
+		lines = append(lines, "Since: "+metric.Since)
+	}
+	if metric.SLO != nil {
+		lines = append(lines, "SLO: "+metric.SLO.String())
+	}
+	return lines
+}
+
+// checkConfigFields returns an error if environment variables map to the same config field, e.g.
+// APP_REGION and APP__REGION, or to a name that is not an identifier, e.g. _1, since the
+// generated config would not compile
+func checkConfigFields(fields []ConfigField) error {
+	envVars := make(map[string]string, len(fields))
+	for _, field := range fields {
+		if field.FieldName == "" || !unicode.IsLetter(rune(field.FieldName[0])) {
+			return fmt.Errorf("environment variable %s cannot be a config field: its name must start with a letter after the leading underscores", field.EnvVar)
+		}
+		if other, ok := envVars[field.FieldName]; ok {
+			return fmt.Errorf("environment variables %s and %s map to the same config field %s: rename one of them", other, field.EnvVar, field.FieldName)
+		}
+		envVars[field.FieldName] = field.EnvVar
+	}
+	return nil
 }
 
 // EnrichMetrics applies a transformation function to all metrics in the template data.
@@ -9,7 +9,8 @@ import (
 
 // GoTemplateDataBuilder wraps CommonTemplateDataBuilder with Go-specific logic
 type GoTemplateDataBuilder struct {
-	common *CommonTemplateDataBuilder
+	common              *CommonTemplateDataBuilder
+	validationCacheSize int
 }
 
 // NewGoTemplateDataBuilder creates a new Go-specific builder
@@ -19,6 +20,12 @@ func NewGoTemplateDataBuilder() *GoTemplateDataBuilder {
 	}
 }
 
+// SetValidationCacheSize sets the size of the LRU cache of accepted values
+// generated for the label validators using regexps or CEL (0 disables the cache)
+func (b *GoTemplateDataBuilder) SetValidationCacheSize(size int) {
+	b.validationCacheSize = size
+}
+
 // BuildTemplateData builds template data with Go-specific enrichment
 func (b *GoTemplateDataBuilder) BuildTemplateData(spec *domain.Specification, packageName string) *TemplateData {
 	data := b.common.BuildTemplateData(spec, packageName)
@@ -35,21 +42,39 @@ func (b *GoTemplateDataBuilder) BuildTemplateData(spec *domain.Specification, pa
 			metric.OptsType = "CounterOpts"
 			metric.VecType = "Counter"
 			metric.Constructor = "prometheus.NewCounter"
+			metric.CurriedVecType = "*prometheus.CounterVec"
+			metric.BoundType = "prometheus.Counter"
+			metric.Operations = []MetricOperation{{Name: "Inc"}, {Name: "Add", WithValue: true}}
 		case domain.MetricTypeGauge:
 			metric.SimpleType = "Gauge"
 			metric.OptsType = "GaugeOpts"
 			metric.VecType = "Gauge"
 			metric.Constructor = "prometheus.NewGauge"
+			metric.CurriedVecType = "*prometheus.GaugeVec"
+			metric.BoundType = "prometheus.Gauge"
+			metric.Operations = []MetricOperation{
+				{Name: "Set", WithValue: true},
+				{Name: "Inc"},
+				{Name: "Dec"},
+				{Name: "Add", WithValue: true},
+				{Name: "Sub", WithValue: true},
+			}
 		case domain.MetricTypeHistogram:
 			metric.SimpleType = "Histogram"
 			metric.OptsType = "HistogramOpts"
 			metric.VecType = "Histogram"
 			metric.Constructor = "prometheus.NewHistogram"
+			metric.CurriedVecType = "prometheus.ObserverVec"
+			metric.BoundType = "prometheus.Observer"
+			metric.Operations = []MetricOperation{{Name: "Observe", WithValue: true}}
 		case domain.MetricTypeSummary:
 			metric.SimpleType = "Summary"
 			metric.OptsType = "SummaryOpts"
 			metric.VecType = "Summary"
 			metric.Constructor = "prometheus.NewSummary"
+			metric.CurriedVecType = "prometheus.ObserverVec"
+			metric.BoundType = "prometheus.Observer"
+			metric.Operations = []MetricOperation{{Name: "Observe", WithValue: true}}
 		}
 
 		// Override with Vec types if metric has labels
@@ -71,8 +96,28 @@ func (b *GoTemplateDataBuilder) BuildTemplateData(spec *domain.Specification, pa
 		metric.MethodParams = strings.Join(params, ", ")
 		metric.MethodArgs = strings.Join(args, ", ")
 
+		// <Metric>For binds all labels but the last one, which is left to the returned handle
+		if labels := domain.Labels(metric.LabelDefinitions).NonInheritedLabels(); len(labels) > 0 {
+			last := len(labels) - 1
+			metric.CurriedLabels = labels[:last]
+			metric.CurriedParams = strings.Join(params[:last], ", ")
+			metric.CurriedArgs = strings.Join(args[:last], ", ")
+			metric.LastLabel = labels[last]
+			metric.LastParam = args[last]
+		}
+
+		metric.Validators = BuildGoLabelValidators(metric, b.validationCacheSize)
+
 		return nil
 	})
 
+	kinds := validationKinds(data)
+	data.HasValidations = len(kinds) > 0
+	data.NeedsCEL = kinds[ValidationKindCEL]
+	data.NeedsRegexp = kinds[ValidationKindMatches]
+	data.NeedsStrings = kinds[ValidationKindStartsWith]
+	data.NeedsUTF8 = kinds[ValidationKindSize]
+	data.NeedsCache = b.validationCacheSize > 0 && (data.NeedsCEL || data.NeedsRegexp)
+
 	return data
 }
@@ -0,0 +1,139 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/jycamier/promener/internal/domain"
+)
+
+// gRPC labels filled by the generated interceptors, keyed by label name.
+// The values are the Go identifiers holding the label value in the generated code.
+var grpcLabelSources = map[string]string{
+	"grpc_type":    "grpcType",
+	"grpc_service": "grpcService",
+	"grpc_method":  "grpcMethod",
+	"grpc_code":    "grpcCode",
+}
+
+// grpcRoleSpec describes how the interceptors record the metrics of a role
+type grpcRoleSpec struct {
+	types   []domain.MetricType
+	hasCode bool // grpc_code is only known once the call completes
+	field   func(side *GRPCInterceptors) *[]MiddlewareMetric
+}
+
+var grpcRoles = map[domain.MetricRole]grpcRoleSpec{
+	domain.MetricRoleGRPCServerStarted:         {[]domain.MetricType{domain.MetricTypeCounter}, false, grpcStarted},
+	domain.MetricRoleGRPCServerHandled:         {[]domain.MetricType{domain.MetricTypeCounter}, true, grpcHandled},
+	domain.MetricRoleGRPCServerHandlingSeconds: {[]domain.MetricType{domain.MetricTypeHistogram, domain.MetricTypeSummary}, true, grpcHandlingSeconds},
+	domain.MetricRoleGRPCServerInFlight:        {[]domain.MetricType{domain.MetricTypeGauge}, false, grpcInFlight},
+	domain.MetricRoleGRPCServerMsgReceived:     {[]domain.MetricType{domain.MetricTypeCounter}, false, grpcMsgReceived},
+	domain.MetricRoleGRPCServerMsgSent:         {[]domain.MetricType{domain.MetricTypeCounter}, false, grpcMsgSent},
+	domain.MetricRoleGRPCClientStarted:         {[]domain.MetricType{domain.MetricTypeCounter}, false, grpcStarted},
+	domain.MetricRoleGRPCClientHandled:         {[]domain.MetricType{domain.MetricTypeCounter}, true, grpcHandled},
+	domain.MetricRoleGRPCClientHandlingSeconds: {[]domain.MetricType{domain.MetricTypeHistogram, domain.MetricTypeSummary}, true, grpcHandlingSeconds},
+	domain.MetricRoleGRPCClientInFlight:        {[]domain.MetricType{domain.MetricTypeGauge}, false, grpcInFlight},
+	domain.MetricRoleGRPCClientMsgReceived:     {[]domain.MetricType{domain.MetricTypeCounter}, false, grpcMsgReceived},
+	domain.MetricRoleGRPCClientMsgSent:         {[]domain.MetricType{domain.MetricTypeCounter}, false, grpcMsgSent},
+}
+
+func grpcStarted(side *GRPCInterceptors) *[]MiddlewareMetric         { return &side.Started }
+func grpcHandled(side *GRPCInterceptors) *[]MiddlewareMetric         { return &side.Handled }
+func grpcHandlingSeconds(side *GRPCInterceptors) *[]MiddlewareMetric { return &side.HandlingSeconds }
+func grpcInFlight(side *GRPCInterceptors) *[]MiddlewareMetric        { return &side.InFlight }
+func grpcMsgReceived(side *GRPCInterceptors) *[]MiddlewareMetric     { return &side.MsgReceived }
+func grpcMsgSent(side *GRPCInterceptors) *[]MiddlewareMetric         { return &side.MsgSent }
+
+// BuildGRPCInterceptors builds the server and client interceptors from the metrics declaring a gRPC role.
+// Every label of these metrics must be one of grpc_type, grpc_service, grpc_method or grpc_code, and
+// grpc_code is only allowed on the handled and handling_seconds roles. Sides without metrics are omitted.
+func BuildGRPCInterceptors(spec *domain.Specification) ([]GRPCInterceptors, error) {
+	server := GRPCInterceptors{Side: "Server"}
+	client := GRPCInterceptors{Side: "Client"}
+
+	for _, serviceName := range sortedKeys(spec.Services) {
+		service := spec.Services[serviceName]
+		for _, key := range sortedKeys(service.Metrics) {
+			metric := service.Metrics[key]
+			if !metric.Role.IsGRPC() {
+				continue
+			}
+			if metric.Name == "" {
+				metric.Name = key
+			}
+			role := grpcRoles[metric.Role]
+
+			if !slices.Contains(role.types, metric.Type) {
+				return nil, fmt.Errorf("metric %q with role %s is a %s, expected %s", metric.FullName(), metric.Role, metric.Type, joinMetricTypes(role.types))
+			}
+
+			mm := newMiddlewareMetric(metric)
+			for _, label := range metric.Labels.NonInheritedLabels() {
+				source, ok := grpcLabelSources[label.Name]
+				if !ok {
+					return nil, fmt.Errorf("metric %q with role %s: label %q is not filled by the gRPC interceptors (expected one of %s)", metric.FullName(), metric.Role, label.Name, grpcLabelNames())
+				}
+				if label.Name == "grpc_code" && !role.hasCode {
+					return nil, fmt.Errorf("metric %q with role %s: label %q is only known once the call completes", metric.FullName(), metric.Role, label.Name)
+				}
+				mm.Labels = append(mm.Labels, mm.newLabel(label, source))
+			}
+
+			side := &server
+			if isGRPCClientRole(metric.Role) {
+				side = &client
+			}
+			metrics := role.field(side)
+			if slices.ContainsFunc(*metrics, mm.sameMetric) {
+				continue
+			}
+			*metrics = append(*metrics, mm)
+		}
+	}
+
+	var result []GRPCInterceptors
+	for _, side := range []GRPCInterceptors{server, client} {
+		if side.empty() {
+			continue
+		}
+		for _, metrics := range [][]MiddlewareMetric{side.Handled, side.HandlingSeconds} {
+			for _, metric := range metrics {
+				if slices.ContainsFunc(metric.Labels, func(label MiddlewareLabel) bool { return label.Name == "grpc_code" }) {
+					side.UsesCode = true
+				}
+			}
+		}
+		result = append(result, side)
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("no metric declares a gRPC role")
+	}
+
+	return result, nil
+}
+
+func (s GRPCInterceptors) empty() bool {
+	return len(s.Started)+len(s.Handled)+len(s.HandlingSeconds)+len(s.InFlight)+len(s.MsgReceived)+len(s.MsgSent) == 0
+}
+
+func isGRPCClientRole(role domain.MetricRole) bool {
+	switch role {
+	case domain.MetricRoleGRPCClientStarted, domain.MetricRoleGRPCClientHandled, domain.MetricRoleGRPCClientHandlingSeconds,
+		domain.MetricRoleGRPCClientInFlight, domain.MetricRoleGRPCClientMsgReceived, domain.MetricRoleGRPCClientMsgSent:
+		return true
+	}
+	return false
+}
+
+func grpcLabelNames() string {
+	names := make([]string, 0, len(grpcLabelSources))
+	for name := range grpcLabelSources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,141 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/jycamier/promener/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newGRPCSpec(metrics map[string]domain.Metric) *domain.Specification {
+	return &domain.Specification{
+		Services: map[string]domain.Service{
+			"api": {Metrics: metrics},
+		},
+	}
+}
+
+func TestBuildGRPCInterceptors(t *testing.T) {
+	spec := newGRPCSpec(map[string]domain.Metric{
+		"started_total": {
+			Namespace: "grpc",
+			Subsystem: "server",
+			Type:      domain.MetricTypeCounter,
+			Role:      domain.MetricRoleGRPCServerStarted,
+			Labels:    domain.Labels{{Name: "grpc_method"}, {Name: "grpc_type"}},
+		},
+		"handled_total": {
+			Namespace: "grpc",
+			Subsystem: "server",
+			Type:      domain.MetricTypeCounter,
+			Role:      domain.MetricRoleGRPCServerHandled,
+			Labels: domain.Labels{
+				{Name: "grpc_code", Validations: []string{"value != ''"}},
+				{Name: "grpc_service"},
+				{Name: "cluster", Inherited: "Added by relabeling"},
+			},
+		},
+		"handling_seconds": {
+			Namespace: "grpc",
+			Subsystem: "server",
+			Type:      domain.MetricTypeHistogram,
+			Role:      domain.MetricRoleGRPCServerHandlingSeconds,
+		},
+		"client_in_flight": {
+			Namespace: "grpc",
+			Subsystem: "client",
+			Type:      domain.MetricTypeGauge,
+			Role:      domain.MetricRoleGRPCClientInFlight,
+		},
+		"requests_total": {
+			Namespace: "http",
+			Subsystem: "server",
+			Type:      domain.MetricTypeCounter,
+			Labels:    domain.Labels{{Name: "method"}},
+		},
+	})
+
+	interceptors, err := BuildGRPCInterceptors(spec)
+	require.NoError(t, err)
+	require.Len(t, interceptors, 2)
+
+	server := interceptors[0]
+	assert.Equal(t, "Server", server.Side)
+	assert.True(t, server.UsesCode)
+	require.Len(t, server.Started, 1)
+	assert.Equal(t, "StartedTotal", server.Started[0].MethodName)
+	assert.Equal(t, []MiddlewareLabel{
+		{Name: "grpc_method", Source: "grpcMethod"},
+		{Name: "grpc_type", Source: "grpcType"},
+	}, server.Started[0].Labels)
+	require.Len(t, server.Handled, 1)
+	assert.Equal(t, []MiddlewareLabel{
+		{Name: "grpc_code", Source: "grpcCode", Validator: "validateGrpcServerHandledTotalGrpcCode"},
+		{Name: "grpc_service", Source: "grpcService"},
+	}, server.Handled[0].Labels)
+	require.Len(t, server.HandlingSeconds, 1)
+	assert.Empty(t, server.InFlight)
+
+	client := interceptors[1]
+	assert.Equal(t, "Client", client.Side)
+	assert.False(t, client.UsesCode)
+	require.Len(t, client.InFlight, 1)
+	assert.Equal(t, "Grpc", client.InFlight[0].Namespace)
+	assert.Equal(t, "Client", client.InFlight[0].Subsystem)
+	assert.Equal(t, "ClientInFlight", client.InFlight[0].MethodName)
+}
+
+func TestBuildGRPCInterceptors_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		metric  domain.Metric
+		wantErr string
+	}{
+		{
+			name: "no role",
+			metric: domain.Metric{
+				Namespace: "grpc",
+				Type:      domain.MetricTypeCounter,
+			},
+			wantErr: "no metric declares a gRPC role",
+		},
+		{
+			name: "wrong metric type",
+			metric: domain.Metric{
+				Namespace: "grpc",
+				Type:      domain.MetricTypeCounter,
+				Role:      domain.MetricRoleGRPCServerHandlingSeconds,
+			},
+			wantErr: `metric "grpc_calls" with role grpc_server_handling_seconds is a counter, expected histogram or summary`,
+		},
+		{
+			name: "unknown label",
+			metric: domain.Metric{
+				Namespace: "grpc",
+				Type:      domain.MetricTypeCounter,
+				Role:      domain.MetricRoleGRPCServerHandled,
+				Labels:    domain.Labels{{Name: "method"}},
+			},
+			wantErr: `label "method" is not filled by the gRPC interceptors`,
+		},
+		{
+			name: "code before completion",
+			metric: domain.Metric{
+				Namespace: "grpc",
+				Type:      domain.MetricTypeGauge,
+				Role:      domain.MetricRoleGRPCClientInFlight,
+				Labels:    domain.Labels{{Name: "grpc_code"}},
+			},
+			wantErr: `label "grpc_code" is only known once the call completes`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := BuildGRPCInterceptors(newGRPCSpec(map[string]domain.Metric{"calls": tt.metric}))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/jycamier/promener/internal/domain"
+)
+
+var nonAlphanumericRegex = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+// BuildHTTPMiddlewares builds one HTTP middleware per golden signals topic declaring an http label mapping.
+// Traffic and errors metrics must be counters and latency metrics histograms or summaries; every label
+// they define must be mapped. Saturation gauges are tracked as in-flight requests when their labels only
+// use the request method, other saturation metrics are ignored.
+func BuildHTTPMiddlewares(spec *domain.Specification) ([]HTTPMiddleware, error) {
+	var middlewares []HTTPMiddleware
+	names := make(map[string]string)
+
+	for _, serviceName := range sortedKeys(spec.Services) {
+		service := spec.Services[serviceName]
+		for _, topic := range sortedKeys(service.GoldenSignals) {
+			signals := service.GoldenSignals[topic]
+			if signals.HTTP == nil {
+				continue
+			}
+
+			mw := HTTPMiddleware{
+				Name:  topicToName(topic),
+				Topic: topic,
+			}
+			if other, exists := names[mw.Name]; exists {
+				return nil, fmt.Errorf("golden signals topics %q and %q generate the same middleware name %s", other, topic, mw.Name)
+			}
+			names[mw.Name] = topic
+
+			var err error
+			if mw.Traffic, err = buildMiddlewareMetrics(service, topic, "traffic", signals.Traffic, signals.HTTP, domain.MetricTypeCounter); err != nil {
+				return nil, err
+			}
+			errorMetrics, err := buildMiddlewareMetrics(service, topic, "errors", signals.Errors, signals.HTTP, domain.MetricTypeCounter)
+			if err != nil {
+				return nil, err
+			}
+			for _, metric := range errorMetrics {
+				if !slices.ContainsFunc(mw.Traffic, metric.sameMetric) {
+					mw.Errors = append(mw.Errors, metric)
+				}
+			}
+			if mw.Latency, err = buildMiddlewareMetrics(service, topic, "latency", signals.Latency, signals.HTTP, domain.MetricTypeHistogram, domain.MetricTypeSummary); err != nil {
+				return nil, err
+			}
+			mw.InFlight = buildInFlightMetrics(service, signals.Saturation, signals.HTTP)
+
+			if len(mw.Traffic)+len(mw.Errors)+len(mw.Latency)+len(mw.InFlight) == 0 {
+				return nil, fmt.Errorf("golden signals topic %q declares an http label mapping but no metric to record", topic)
+			}
+
+			for _, metrics := range [][]MiddlewareMetric{mw.Traffic, mw.Errors, mw.Latency} {
+				for _, metric := range metrics {
+					for _, label := range metric.Labels {
+						switch label.Source {
+						case domain.HTTPSourceRoute:
+							mw.UsesRoute = true
+						case domain.HTTPSourceStatusCode:
+							mw.UsesStatusCode = true
+						case domain.HTTPSourceStatusClass:
+							mw.UsesStatusClass = true
+						}
+					}
+				}
+			}
+
+			middlewares = append(middlewares, mw)
+		}
+	}
+
+	if len(middlewares) == 0 {
+		return nil, fmt.Errorf("no golden signals topic declares an http label mapping")
+	}
+
+	return middlewares, nil
+}
+
+// buildMiddlewareMetrics resolves the metrics of a golden signal and maps their labels
+func buildMiddlewareMetrics(service domain.Service, topic, signalName string, signal *domain.GoldenSignal, mapping *domain.HTTPLabelMapping, allowedTypes ...domain.MetricType) ([]MiddlewareMetric, error) {
+	if signal == nil {
+		return nil, nil
+	}
+
+	var result []MiddlewareMetric
+	for _, name := range signal.Metrics {
+		metric, ok := lookupMetric(service, name)
+		if !ok {
+			return nil, fmt.Errorf("golden signals %s.%s: unknown metric %q", topic, signalName, name)
+		}
+		if !slices.Contains(allowedTypes, metric.Type) {
+			return nil, fmt.Errorf("golden signals %s.%s: metric %q is a %s, expected %s", topic, signalName, name, metric.Type, joinMetricTypes(allowedTypes))
+		}
+
+		mm := newMiddlewareMetric(metric)
+		if slices.ContainsFunc(result, mm.sameMetric) {
+			continue
+		}
+		for _, label := range metric.Labels.NonInheritedLabels() {
+			source := mapping.Source(label.Name)
+			if source == "" {
+				return nil, fmt.Errorf("golden signals %s.%s: label %q of metric %q has no http mapping", topic, signalName, label.Name, name)
+			}
+			mm.Labels = append(mm.Labels, mm.newLabel(label, source))
+		}
+		result = append(result, mm)
+	}
+	return result, nil
+}
+
+// buildInFlightMetrics returns the saturation gauges whose labels only use the request method,
+// the only request attribute known before the request is routed
+func buildInFlightMetrics(service domain.Service, signal *domain.GoldenSignal, mapping *domain.HTTPLabelMapping) []MiddlewareMetric {
+	if signal == nil {
+		return nil
+	}
+
+	var result []MiddlewareMetric
+	for _, name := range signal.Metrics {
+		metric, ok := lookupMetric(service, name)
+		if !ok || metric.Type != domain.MetricTypeGauge {
+			continue
+		}
+
+		mm := newMiddlewareMetric(metric)
+		if slices.ContainsFunc(result, mm.sameMetric) {
+			continue
+		}
+		tracked := true
+		for _, label := range metric.Labels.NonInheritedLabels() {
+			if mapping.Source(label.Name) != domain.HTTPSourceMethod {
+				tracked = false
+				break
+			}
+			mm.Labels = append(mm.Labels, mm.newLabel(label, domain.HTTPSourceMethod))
+		}
+		if tracked {
+			result = append(result, mm)
+		}
+	}
+	return result
+}
+
+// lookupMetric finds a metric by its key in the service, or by its explicit name
+func lookupMetric(service domain.Service, name string) (domain.Metric, bool) {
+	if metric, ok := service.Metrics[name]; ok {
+		if metric.Name == "" {
+			metric.Name = name
+		}
+		return metric, true
+	}
+	for _, metric := range service.Metrics {
+		if metric.Name == name {
+			return metric, true
+		}
+	}
+	return domain.Metric{}, false
+}
+
+func newMiddlewareMetric(metric domain.Metric) MiddlewareMetric {
+	return MiddlewareMetric{
+		Namespace:  toCamelCase(metric.Namespace),
+		Subsystem:  toCamelCase(metric.Subsystem),
+		MethodName: toCamelCase(metric.Name),
+		FieldName:  toLowerCamelCase(metric.Name),
+	}
+}
+
+// newLabel maps a label of the metric to the request attribute filling it
+func (m MiddlewareMetric) newLabel(label domain.LabelDefinition, source string) MiddlewareLabel {
+	ml := MiddlewareLabel{Name: label.Name, Source: source}
+	if len(label.Validations) > 0 {
+		ml.Validator = goLabelValidatorName(m.Namespace, m.Subsystem, m.MethodName, label.Name)
+	}
+	return ml
+}
+
+func (m MiddlewareMetric) sameMetric(other MiddlewareMetric) bool {
+	return m.Namespace == other.Namespace && m.Subsystem == other.Subsystem && m.MethodName == other.MethodName
+}
+
+// topicToName converts a golden signals topic (e.g. "http/server") to a CamelCase name (HttpServer)
+func topicToName(topic string) string {
+	return toCamelCase(strings.ToLower(strings.Trim(nonAlphanumericRegex.ReplaceAllString(topic, "_"), "_")))
+}
+
+func joinMetricTypes(types []domain.MetricType) string {
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = string(t)
+	}
+	return strings.Join(names, " or ")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
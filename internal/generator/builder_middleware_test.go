@@ -0,0 +1,156 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/jycamier/promener/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMiddlewareSpec(mapping *domain.HTTPLabelMapping, signals domain.GoldenSignals) *domain.Specification {
+	signals.HTTP = mapping
+	return &domain.Specification{
+		Services: map[string]domain.Service{
+			"api": {
+				Metrics: map[string]domain.Metric{
+					"requests_total": {
+						Namespace: "http",
+						Subsystem: "server",
+						Type:      domain.MetricTypeCounter,
+						Labels: domain.Labels{
+							{Name: "method", Validations: []string{"value in ['GET', 'POST']"}},
+							{Name: "path"},
+							{Name: "status"},
+							{Name: "cluster", Inherited: "Added by relabeling"},
+						},
+					},
+					"errors_total": {
+						Namespace: "http",
+						Subsystem: "server",
+						Type:      domain.MetricTypeCounter,
+						Labels:    domain.Labels{{Name: "class"}},
+					},
+					"request_duration_seconds": {
+						Namespace: "http",
+						Subsystem: "server",
+						Type:      domain.MetricTypeHistogram,
+						Labels:    domain.Labels{{Name: "method"}},
+					},
+					"requests_in_flight": {
+						Namespace: "http",
+						Subsystem: "server",
+						Type:      domain.MetricTypeGauge,
+					},
+					"connections": {
+						Namespace: "http",
+						Subsystem: "server",
+						Type:      domain.MetricTypeGauge,
+						Labels:    domain.Labels{{Name: "pool"}},
+					},
+				},
+				GoldenSignals: map[string]domain.GoldenSignals{
+					"http/server": signals,
+				},
+			},
+		},
+	}
+}
+
+func TestBuildHTTPMiddlewares(t *testing.T) {
+	mapping := &domain.HTTPLabelMapping{
+		Method:      "method",
+		Route:       "path",
+		StatusCode:  "status",
+		StatusClass: "class",
+	}
+	spec := newMiddlewareSpec(mapping, domain.GoldenSignals{
+		Traffic:    &domain.GoldenSignal{Metrics: []string{"requests_total"}},
+		Errors:     &domain.GoldenSignal{Metrics: []string{"requests_total", "errors_total"}},
+		Latency:    &domain.GoldenSignal{Metrics: []string{"request_duration_seconds"}},
+		Saturation: &domain.GoldenSignal{Metrics: []string{"requests_in_flight", "connections"}},
+	})
+
+	middlewares, err := BuildHTTPMiddlewares(spec)
+	require.NoError(t, err)
+	require.Len(t, middlewares, 1)
+
+	mw := middlewares[0]
+	assert.Equal(t, "HttpServer", mw.Name)
+	assert.Equal(t, "http/server", mw.Topic)
+	assert.True(t, mw.UsesRoute)
+	assert.True(t, mw.UsesStatusCode)
+	assert.True(t, mw.UsesStatusClass)
+
+	require.Len(t, mw.Traffic, 1)
+	assert.Equal(t, "RequestsTotal", mw.Traffic[0].MethodName)
+	assert.Equal(t, []MiddlewareLabel{
+		{Name: "method", Source: domain.HTTPSourceMethod, Validator: "validateHttpServerRequestsTotalMethod"},
+		{Name: "path", Source: domain.HTTPSourceRoute},
+		{Name: "status", Source: domain.HTTPSourceStatusCode},
+	}, mw.Traffic[0].Labels)
+
+	// requests_total is already recorded as traffic
+	require.Len(t, mw.Errors, 1)
+	assert.Equal(t, "ErrorsTotal", mw.Errors[0].MethodName)
+
+	require.Len(t, mw.Latency, 1)
+	assert.Equal(t, "RequestDurationSeconds", mw.Latency[0].MethodName)
+
+	// connections uses a label that is not known when the request starts
+	require.Len(t, mw.InFlight, 1)
+	assert.Equal(t, "RequestsInFlight", mw.InFlight[0].MethodName)
+}
+
+func TestBuildHTTPMiddlewares_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping *domain.HTTPLabelMapping
+		signals domain.GoldenSignals
+		wantErr string
+	}{
+		{
+			name:    "no http mapping",
+			signals: domain.GoldenSignals{Traffic: &domain.GoldenSignal{Metrics: []string{"requests_total"}}},
+			wantErr: "no golden signals topic declares an http label mapping",
+		},
+		{
+			name:    "unmapped label",
+			mapping: &domain.HTTPLabelMapping{Method: "method", Route: "path"},
+			signals: domain.GoldenSignals{Traffic: &domain.GoldenSignal{Metrics: []string{"requests_total"}}},
+			wantErr: `label "status" of metric "requests_total" has no http mapping`,
+		},
+		{
+			name:    "unknown metric",
+			mapping: &domain.HTTPLabelMapping{Method: "method"},
+			signals: domain.GoldenSignals{Latency: &domain.GoldenSignal{Metrics: []string{"missing"}}},
+			wantErr: `unknown metric "missing"`,
+		},
+		{
+			name:    "wrong metric type",
+			mapping: &domain.HTTPLabelMapping{Method: "method"},
+			signals: domain.GoldenSignals{Latency: &domain.GoldenSignal{Metrics: []string{"requests_in_flight"}}},
+			wantErr: `metric "requests_in_flight" is a gauge, expected histogram or summary`,
+		},
+		{
+			name:    "nothing to record",
+			mapping: &domain.HTTPLabelMapping{Method: "method"},
+			signals: domain.GoldenSignals{Saturation: &domain.GoldenSignal{Metrics: []string{"connections"}}},
+			wantErr: "declares an http label mapping but no metric to record",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := BuildHTTPMiddlewares(newMiddlewareSpec(tt.mapping, tt.signals))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestTopicToName(t *testing.T) {
+	assert.Equal(t, "HttpServer", topicToName("http/server"))
+	assert.Equal(t, "ApiGatewayPublic", topicToName("api-gateway/public"))
+	assert.Equal(t, "Http", topicToName("/HTTP/"))
+}
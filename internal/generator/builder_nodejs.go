@@ -22,6 +22,8 @@ func NewNodeJSTemplateDataBuilder() *NodeJSTemplateDataBuilder {
 // BuildTemplateData builds template data with Node.js-specific enrichment
 func (b *NodeJSTemplateDataBuilder) BuildTemplateData(spec *domain.Specification, packageName string) *TemplateData {
 	data := b.common.BuildTemplateData(spec, packageName)
+	// The Node.js code reads process.env directly and has no config
+	data.ConfigFields = nil
 
 	// Enrich all metrics with Node.js-specific fields using the common helper
 	_ = b.common.EnrichMetrics(data, func(metric *MetricData) error {
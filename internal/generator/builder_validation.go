@@ -0,0 +1,188 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/google/cel-go/cel"
+	celast "github.com/google/cel-go/common/ast"
+	"github.com/google/cel-go/common/operators"
+	"github.com/google/cel-go/common/overloads"
+	"github.com/google/cel-go/common/types"
+)
+
+// ValidationKind identifies how a label validation is checked by the generated Go code
+type ValidationKind string
+
+const (
+	ValidationKindIn         ValidationKind = "in"         // value in ['a', 'b']: switch statement
+	ValidationKindStartsWith ValidationKind = "startsWith" // value.startsWith('a'): strings.HasPrefix
+	ValidationKindMatches    ValidationKind = "matches"    // value.matches('re'): precompiled regexp
+	ValidationKindSize       ValidationKind = "size"       // size(value) <= 10: utf8.RuneCountInString
+	ValidationKindCEL        ValidationKind = "cel"        // any other expression: precompiled CEL program
+)
+
+// BuildGoLabelValidators builds the validator functions of the labels of a Go metric.
+// Labels without validations get no validator.
+func BuildGoLabelValidators(metric *MetricData, cacheSize int) []LabelValidator {
+	var validators []LabelValidator
+	for _, label := range metric.LabelDefinitions {
+		if len(label.Validations) == 0 || label.IsInherited() {
+			continue
+		}
+
+		funcName := goLabelValidatorName(toCamelCase(metric.Namespace), toCamelCase(metric.Subsystem), metric.MethodName, label.Name)
+		validator := LabelValidator{
+			FuncName: funcName,
+			Label:    label.Name,
+			Param:    escapeGoKeyword(toLowerCamelCase(label.Name)),
+			Metric:   metric.FullName,
+		}
+		for i, expression := range label.Validations {
+			check := compileValidationCheck(expression)
+			switch check.Kind {
+			case ValidationKindMatches:
+				check.VarName = fmt.Sprintf("%sRegexp%d", funcName, i)
+			case ValidationKindCEL:
+				check.VarName = fmt.Sprintf("%sProgram%d", funcName, i)
+			}
+			// Native in and startsWith checks are cheaper than a cache lookup
+			if cacheSize > 0 && check.VarName != "" {
+				validator.CacheSize = cacheSize
+			}
+			validator.Checks = append(validator.Checks, check)
+		}
+		validators = append(validators, validator)
+	}
+	return validators
+}
+
+// goLabelValidatorName returns the name of the Go function validating a label,
+// from the CamelCase namespace, subsystem and metric method name
+func goLabelValidatorName(namespace, subsystem, methodName, label string) string {
+	return "validate" + namespace + subsystem + methodName + toCamelCase(label)
+}
+
+// compileValidationCheck recognizes the CEL expressions with a native Go equivalent.
+// Expressions that don't parse, or with another shape, are left to CEL.
+func compileValidationCheck(expression string) ValidationCheck {
+	check := ValidationCheck{Expression: expression, Kind: ValidationKindCEL}
+
+	env, err := cel.NewEnv(cel.Variable("value", cel.StringType))
+	if err != nil {
+		return check
+	}
+	parsed, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return check
+	}
+	expr := parsed.NativeRep().Expr()
+	if expr.Kind() != celast.CallKind {
+		return check
+	}
+
+	call := expr.AsCall()
+	args := call.Args()
+	switch {
+	case call.FunctionName() == operators.In && !call.IsMemberFunction() && len(args) == 2:
+		if !isValueIdent(args[0]) || args[1].Kind() != celast.ListKind {
+			return check
+		}
+		list := args[1].AsList()
+		if len(list.OptionalIndices()) > 0 {
+			return check
+		}
+		var values []string
+		for _, element := range list.Elements() {
+			value, ok := stringLiteral(element)
+			if !ok {
+				return check
+			}
+			// Duplicate cases don't compile in a switch statement
+			if !slices.Contains(values, value) {
+				values = append(values, value)
+			}
+		}
+		if len(values) == 0 {
+			return check
+		}
+		check.Kind = ValidationKindIn
+		check.Values = values
+	case call.FunctionName() == "startsWith" && call.IsMemberFunction() && len(args) == 1:
+		prefix, ok := stringLiteral(args[0])
+		if !ok || !isValueIdent(call.Target()) {
+			return check
+		}
+		check.Kind = ValidationKindStartsWith
+		check.Operand = prefix
+	case sizeOperators[call.FunctionName()] != "" && len(args) == 2:
+		// CEL counts the code points of a string, like utf8.RuneCountInString
+		if args[0].Kind() != celast.CallKind || args[1].Kind() != celast.LiteralKind {
+			return check
+		}
+		size := args[0].AsCall()
+		if size.FunctionName() != overloads.Size || size.IsMemberFunction() || len(size.Args()) != 1 || !isValueIdent(size.Args()[0]) {
+			return check
+		}
+		limit, ok := args[1].AsLiteral().(types.Int)
+		if !ok {
+			return check
+		}
+		check.Kind = ValidationKindSize
+		check.Operand = sizeOperators[call.FunctionName()]
+		check.Limit = int64(limit)
+	case call.FunctionName() == "matches" && call.IsMemberFunction() && len(args) == 1:
+		pattern, ok := stringLiteral(args[0])
+		if !ok || !isValueIdent(call.Target()) {
+			return check
+		}
+		// CEL uses RE2 like regexp, so a pattern compiling here behaves the same
+		if _, err := regexp.Compile(pattern); err != nil {
+			return check
+		}
+		check.Kind = ValidationKindMatches
+		check.Operand = pattern
+	}
+
+	return check
+}
+
+// sizeOperators maps the CEL comparison operators to the Go operator rejecting a size
+var sizeOperators = map[string]string{
+	operators.Less:          ">=",
+	operators.LessEquals:    ">",
+	operators.Greater:       "<=",
+	operators.GreaterEquals: "<",
+	operators.Equals:        "!=",
+	operators.NotEquals:     "==",
+}
+
+func isValueIdent(expr celast.Expr) bool {
+	return expr.Kind() == celast.IdentKind && expr.AsIdent() == "value"
+}
+
+func stringLiteral(expr celast.Expr) (string, bool) {
+	if expr.Kind() != celast.LiteralKind {
+		return "", false
+	}
+	value, ok := expr.AsLiteral().(types.String)
+	return string(value), ok
+}
+
+// validationKinds returns the kinds of the validation checks used by the Go template data
+func validationKinds(data *TemplateData) map[ValidationKind]bool {
+	kinds := make(map[ValidationKind]bool)
+	for _, ns := range data.Namespaces {
+		for _, ss := range ns.Subsystems {
+			for _, metric := range ss.Metrics {
+				for _, validator := range metric.Validators {
+					for _, check := range validator.Checks {
+						kinds[check.Kind] = true
+					}
+				}
+			}
+		}
+	}
+	return kinds
+}
@@ -0,0 +1,163 @@
+package generator
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/jycamier/promener/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileValidationCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		want       ValidationCheck
+	}{
+		{
+			name:       "in list",
+			expression: "value in ['GET', \"POST\", 'GET']",
+			want:       ValidationCheck{Kind: ValidationKindIn, Values: []string{"GET", "POST"}},
+		},
+		{
+			name:       "startsWith",
+			expression: "value.startsWith('/api')",
+			want:       ValidationCheck{Kind: ValidationKindStartsWith, Operand: "/api"},
+		},
+		{
+			name:       "matches with escapes",
+			expression: `value.matches('^\\d{3}$')`,
+			want:       ValidationCheck{Kind: ValidationKindMatches, Operand: `^\d{3}$`},
+		},
+		{
+			name:       "size",
+			expression: "size(value) <= 200",
+			want:       ValidationCheck{Kind: ValidationKindSize, Operand: ">", Limit: 200},
+		},
+		{
+			name:       "size with a strict bound",
+			expression: "size(value) > 0",
+			want:       ValidationCheck{Kind: ValidationKindSize, Operand: "<=", Limit: 0},
+		},
+		{
+			name:       "in list of non-string values",
+			expression: "size(value) in [1, 2]",
+			want:       ValidationCheck{Kind: ValidationKindCEL},
+		},
+		{
+			name:       "empty in list",
+			expression: "value in []",
+			want:       ValidationCheck{Kind: ValidationKindCEL},
+		},
+		{
+			name:       "startsWith on another expression",
+			expression: "(value + '/').startsWith('a')",
+			want:       ValidationCheck{Kind: ValidationKindCEL},
+		},
+		{
+			name:       "combined expression",
+			expression: "value.startsWith('/') && size(value) <= 200",
+			want:       ValidationCheck{Kind: ValidationKindCEL},
+		},
+		{
+			name:       "invalid expression",
+			expression: "value in [",
+			want:       ValidationCheck{Kind: ValidationKindCEL},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.want.Expression = tt.expression
+			assert.Equal(t, tt.want, compileValidationCheck(tt.expression))
+		})
+	}
+}
+
+func TestBuildGoLabelValidators(t *testing.T) {
+	metric := &MetricData{
+		Namespace:  "http",
+		Subsystem:  "server",
+		MethodName: "RequestsTotal",
+		FullName:   "http_server_requests_total",
+		LabelDefinitions: []domain.LabelDefinition{
+			{Name: "method", Validations: []string{"value in ['GET']"}},
+			{Name: "path"},
+			{Name: "status_code", Validations: []string{"value.startsWith('2')", "value.matches('^[0-9]+$')", "value != '299'"}},
+			{Name: "cluster", Inherited: "Added by relabeling", Validations: []string{"value in ['eu']"}},
+		},
+	}
+
+	validators := BuildGoLabelValidators(metric, 64)
+	require.Len(t, validators, 2)
+
+	assert.Equal(t, "validateHttpServerRequestsTotalMethod", validators[0].FuncName)
+	assert.Equal(t, "method", validators[0].Param)
+	assert.Zero(t, validators[0].CacheSize, "native in checks are not cached")
+
+	status := validators[1]
+	assert.Equal(t, "validateHttpServerRequestsTotalStatusCode", status.FuncName)
+	assert.Equal(t, "statusCode", status.Param)
+	assert.Equal(t, 64, status.CacheSize)
+	require.Len(t, status.Checks, 3)
+	assert.Empty(t, status.Checks[0].VarName)
+	assert.Equal(t, "validateHttpServerRequestsTotalStatusCodeRegexp1", status.Checks[1].VarName)
+	assert.Equal(t, "validateHttpServerRequestsTotalStatusCodeProgram2", status.Checks[2].VarName)
+
+	assert.Zero(t, BuildGoLabelValidators(metric, 0)[1].CacheSize)
+}
+
+// BenchmarkLabelValidation compares the per-call cost of a CEL evaluation,
+// as previously generated for every label validation, with the native Go checks
+func BenchmarkLabelValidation(b *testing.B) {
+	benchmarks := []struct {
+		expression string
+		value      string
+	}{
+		{"value in ['GET', 'POST', 'PUT', 'DELETE', 'PATCH', 'HEAD', 'OPTIONS']", "PATCH"},
+		{"value.startsWith('/')", "/api/users"},
+		{"value.matches('^[1-5][0-9]{2}$')", "200"},
+		{"size(value) <= 200", "/api/users"},
+	}
+
+	for _, bm := range benchmarks {
+		check := compileValidationCheck(bm.expression)
+
+		b.Run(string(check.Kind)+"/cel", func(b *testing.B) {
+			validation, err := domain.ParseValidation(bm.expression)
+			require.NoError(b, err)
+			b.ReportAllocs()
+			for b.Loop() {
+				if err := validation.Validate(bm.value); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(string(check.Kind)+"/native", func(b *testing.B) {
+			var valid func(string) bool
+			switch check.Kind {
+			case ValidationKindIn:
+				valid = func(value string) bool { return slices.Contains(check.Values, value) }
+			case ValidationKindStartsWith:
+				valid = func(value string) bool { return strings.HasPrefix(value, check.Operand) }
+			case ValidationKindMatches:
+				valid = regexp.MustCompile(check.Operand).MatchString
+			case ValidationKindSize:
+				valid = func(value string) bool { return int64(utf8.RuneCountInString(value)) <= check.Limit }
+			default:
+				b.Fatalf("%q is not compiled to a native check", bm.expression)
+			}
+			b.ReportAllocs()
+			for b.Loop() {
+				if !valid(bm.value) {
+					b.Fatal("validation failed")
+				}
+			}
+		})
+	}
+}
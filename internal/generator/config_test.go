@@ -0,0 +1,90 @@
+package generator
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/jycamier/promener/internal/domain"
+)
+
+// configFxVersion is the version of fx the generated FX modules are tested with
+const configFxVersion = "v1.24.0"
+
+// TestGeneratedConfig generates the Go code and FX modules of a specification whose const labels
+// read environment variables, and runs the tests of testdata/config against them: the registry
+// and namespace modules must fail to start when the supplied Config misses required values.
+func TestGeneratedConfig(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping the generated config tests in short mode")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not found")
+	}
+
+	spec := &domain.Specification{
+		Services: map[string]domain.Service{
+			"default": {
+				Metrics: map[string]domain.Metric{
+					"requests_total": {
+						Namespace: "http",
+						Subsystem: "server",
+						Type:      domain.MetricTypeCounter,
+						Help:      "Total requests",
+						ConstLabels: []domain.ConstLabelDefinition{
+							{Name: "env", Value: "${ENVIRONMENT:production}"},
+							{Name: "region", Value: "${AWS_REGION}"},
+						},
+					},
+					"processed_total": {
+						Namespace: "jobs",
+						Subsystem: "worker",
+						Type:      domain.MetricTypeCounter,
+						Help:      "Processed jobs",
+						ConstLabels: []domain.ConstLabelDefinition{
+							{Name: "queue", Value: "${QUEUE}"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	moduleDir := t.TempDir()
+	packageDir := filepath.Join(moduleDir, "metrics")
+	gen, err := NewGolangGenerator("metrics", packageDir)
+	if err != nil {
+		t.Fatalf("NewGolangGenerator() error = %v", err)
+	}
+	if err := gen.GenerateMetrics(spec); err != nil {
+		t.Fatalf("GolangGenerator.GenerateMetrics() error = %v", err)
+	}
+	if err := gen.GenerateDI(spec); err != nil {
+		t.Fatalf("GolangGenerator.GenerateDI() error = %v", err)
+	}
+
+	tests, err := os.ReadFile("testdata/config/config_fx_test.go")
+	if err != nil {
+		t.Fatalf("failed to read the config tests: %v", err)
+	}
+	writeGeneratedModule(t, "promenerconfig", moduleDir, []string{"go.uber.org/fx " + configFxVersion}, map[string][]byte{
+		filepath.Join(packageDir, "config_fx_test.go"): tests,
+	})
+
+	env := append(os.Environ(), "GOFLAGS=-mod=mod")
+	download := exec.Command(goBin, "mod", "download", "go.uber.org/fx")
+	download.Dir = moduleDir
+	download.Env = env
+	if output, err := download.CombinedOutput(); err != nil {
+		t.Skipf("failed to download fx: %v\n%s", err, output)
+	}
+
+	cmd := exec.Command(goBin, "test", ".")
+	cmd.Dir = packageDir
+	cmd.Env = env
+	if output, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("the tests of the generated config failed: %v\n%s", err, output)
+	}
+}
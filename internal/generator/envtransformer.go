@@ -1,43 +1,89 @@
 package generator
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 // EnvTransformer is a function that transforms an EnvVarValue to language-specific code
 type EnvTransformer func(EnvVarValue) string
 
-// GoEnvTransformer generates Go code for environment variables
+// GoEnvTransformer generates Go code for environment variables, read from the cfg Config of the
+// metrics constructors
 func GoEnvTransformer(e EnvVarValue) string {
 	if !e.IsEnvVar {
-		return `"` + e.LiteralValue + `"`
+		return strconv.Quote(e.LiteralValue)
 	}
 
 	if e.DefaultValue != "" {
-		return `getEnvOrDefault("` + e.EnvVar + `", "` + e.DefaultValue + `")`
+		return `valueOrDefault(cfg.` + configFieldName(e.EnvVar) + `, ` + strconv.Quote(e.DefaultValue) + `)`
 	}
 
-	return `os.Getenv("` + e.EnvVar + `")`
+	return `cfg.` + configFieldName(e.EnvVar)
 }
 
-// DotNetEnvTransformer generates C# code for environment variables
+// DotNetEnvTransformer generates C# code for environment variables, read from the _config
+// MetricsConfig of the metrics classes
 func DotNetEnvTransformer(e EnvVarValue) string {
 	if !e.IsEnvVar {
-		return `"` + e.LiteralValue + `"`
+		return csharpString(e.LiteralValue)
 	}
 
+	// An empty value counts as unset, like in the Go code and in MetricsConfig.Validate
+	field := `_config.` + configFieldName(e.EnvVar)
 	if e.DefaultValue != "" {
-		return `Environment.GetEnvironmentVariable("` + e.EnvVar + `") ?? "` + e.DefaultValue + `"`
+		return `string.IsNullOrEmpty(` + field + `) ? ` + csharpString(e.DefaultValue) + ` : ` + field
 	}
 
-	return `Environment.GetEnvironmentVariable("` + e.EnvVar + `") ?? throw new InvalidOperationException("Environment variable ` + e.EnvVar + ` is required")`
+	return `string.IsNullOrEmpty(` + field + `) ? throw new InvalidOperationException("Environment variable ` + e.EnvVar + ` is required") : ` + field
 }
 
 // NodeJSEnvTransformer generates TypeScript code for environment variables
 func NodeJSEnvTransformer(e EnvVarValue) string {
 	if !e.IsEnvVar {
-		return `'` + e.LiteralValue + `'`
+		return typescriptString(e.LiteralValue)
 	}
 
 	if e.DefaultValue != "" {
-		return `process.env.` + e.EnvVar + ` || '` + e.DefaultValue + `'`
+		return `process.env.` + e.EnvVar + ` || ` + typescriptString(e.DefaultValue)
 	}
 
 	return `process.env.` + e.EnvVar + ` || (() => { throw new Error('Environment variable ` + e.EnvVar + ` is required'); })()`
 }
+
+// csharpString returns a C# string literal of s, with its quotes, backslashes and control
+// characters escaped
+func csharpString(s string) string {
+	return `"` + escapeString(s, '"') + `"`
+}
+
+// typescriptString returns a single-quoted TypeScript string literal of s, with its quotes,
+// backslashes and control characters escaped
+func typescriptString(s string) string {
+	return `'` + escapeString(s, '\'') + `'`
+}
+
+// escapeString escapes the quote, the backslashes and the control characters of s, with the
+// escape sequences shared by C# and TypeScript
+func escapeString(s string, quote rune) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == quote || r == '\\':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r < 0x20 || r == 0x7f || r == 0x2028 || r == 0x2029:
+			fmt.Fprintf(&b, `\u%04x`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -1,10 +1,6 @@
 package generator
 
-import (
-	"regexp"
-)
-
-var envVarRegex = regexp.MustCompile(`^\$\{([^:}]+)(?::([^}]*))?\}$`)
+import "github.com/jycamier/promener/internal/domain"
 
 // EnvVarValue represents a constant label value that can be a literal or environment variable
 type EnvVarValue struct {
@@ -19,8 +15,8 @@ type EnvVarValue struct {
 // - An env var: "${REGION}"
 // - An env var with default: "${REGION:eu-west-1}"
 func ParseEnvVarValue(value string) EnvVarValue {
-	matches := envVarRegex.FindStringSubmatch(value)
-	if matches == nil {
+	ref, ok := domain.ParseEnvVarRef(value)
+	if !ok {
 		// It's a literal value
 		return EnvVarValue{
 			IsEnvVar:     false,
@@ -31,8 +27,8 @@ func ParseEnvVarValue(value string) EnvVarValue {
 	// It's an environment variable
 	return EnvVarValue{
 		IsEnvVar:     true,
-		EnvVar:       matches[1],
-		DefaultValue: matches[2], // Empty string if no default provided
+		EnvVar:       ref.Name,
+		DefaultValue: ref.Default, // Empty string if no default provided
 	}
 }
 
@@ -41,7 +37,7 @@ func (e EnvVarValue) NeedsOsImport() bool {
 	return e.IsEnvVar
 }
 
-// NeedsHelperFunction returns true if this value needs the getEnvOrDefault helper
+// NeedsHelperFunction returns true if this value needs the valueOrDefault helper
 func (e EnvVarValue) NeedsHelperFunction() bool {
 	return e.IsEnvVar && e.DefaultValue != ""
 }
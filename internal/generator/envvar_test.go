@@ -266,3 +266,141 @@ func TestNeedsHelperFunc(t *testing.T) {
 		})
 	}
 }
+
+func TestDotNetEnvTransformer(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{
+			name:  "literal value",
+			value: "production",
+			want:  `"production"`,
+		},
+		{
+			name:  "env var with default",
+			value: "${AWS_REGION:eu-west-1}",
+			want:  `string.IsNullOrEmpty(_config.AwsRegion) ? "eu-west-1" : _config.AwsRegion`,
+		},
+		{
+			name:  "env var without default",
+			value: "${AWS_REGION}",
+			want:  `string.IsNullOrEmpty(_config.AwsRegion) ? throw new InvalidOperationException("Environment variable AWS_REGION is required") : _config.AwsRegion`,
+		},
+		{
+			name:  "literal value with quotes and backslashes",
+			value: `platform's "core" \ infra`,
+			want:  `"platform's \"core\" \\ infra"`,
+		},
+		{
+			name:  "default with quotes and a newline",
+			value: "${ENVIRONMENT:\"prod\"\n}",
+			want:  `string.IsNullOrEmpty(_config.Environment) ? "\"prod\"\n" : _config.Environment`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DotNetEnvTransformer(ParseEnvVarValue(tt.value)))
+		})
+	}
+}
+
+func TestGoEnvTransformer(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{
+			name:  "literal value",
+			value: "production",
+			want:  `"production"`,
+		},
+		{
+			name:  "literal value with quotes and backslashes",
+			value: `platform's "core" \ infra`,
+			want:  `"platform's \"core\" \\ infra"`,
+		},
+		{
+			name:  "default with quotes and a newline",
+			value: "${ENVIRONMENT:\"prod\"\n}",
+			want:  `valueOrDefault(cfg.Environment, "\"prod\"\n")`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, GoEnvTransformer(ParseEnvVarValue(tt.value)))
+		})
+	}
+}
+
+func TestNodeJSEnvTransformer(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{
+			name:  "literal value",
+			value: "production",
+			want:  `'production'`,
+		},
+		{
+			name:  "literal value with quotes and backslashes",
+			value: `platform's "core" \ infra`,
+			want:  `'platform\'s "core" \\ infra'`,
+		},
+		{
+			name:  "default with a quote and a newline",
+			value: "${ENVIRONMENT:o'prod\n}",
+			want:  `process.env.ENVIRONMENT || 'o\'prod\n'`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NodeJSEnvTransformer(ParseEnvVarValue(tt.value)))
+		})
+	}
+}
+
+func TestCheckConfigFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		envVars []string
+		wantErr string
+	}{
+		{
+			name:    "distinct fields",
+			envVars: []string{"AWS_REGION", "ENVIRONMENT", "_DC"},
+		},
+		{
+			name:    "same field",
+			envVars: []string{"APP_REGION", "APP__REGION"},
+			wantErr: "environment variables APP_REGION and APP__REGION map to the same config field AppRegion: rename one of them",
+		},
+		{
+			name:    "field starting with a digit",
+			envVars: []string{"_1_ZONE"},
+			wantErr: "environment variable _1_ZONE cannot be a config field: its name must start with a letter after the leading underscores",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fields []ConfigField
+			for _, envVar := range tt.envVars {
+				fields = append(fields, ConfigField{EnvVar: envVar, FieldName: configFieldName(envVar)})
+			}
+			err := checkConfigFields(fields)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
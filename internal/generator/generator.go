@@ -28,6 +28,7 @@ func NewGenerator(fs embed.FS, pattern string, builder TemplateDataBuilder, envT
 				return strings.ToLower(s)
 			},
 			"toLowerCamelCase": toLowerCamelCase,
+			"escapeGoKeyword":  escapeGoKeyword,
 			"list": func(args ...interface{}) []interface{} {
 				return args
 			},
@@ -44,12 +45,24 @@ func NewGenerator(fs embed.FS, pattern string, builder TemplateDataBuilder, envT
 }
 
 func (g *Generator) GenerateFileFromTemplate(spec *domain.Specification, packageName string, templateName string, fileName string) error {
+	return g.GenerateFileFromData(g.builder.BuildTemplateData(spec, packageName), templateName, fileName)
+}
+
+// GenerateFileFromData renders a template with already built template data.
+// fileName is relative to the output path and may contain subdirectories.
+func (g *Generator) GenerateFileFromData(data *TemplateData, templateName string, fileName string) error {
+	if err := checkConfigFields(data.ConfigFields); err != nil {
+		return err
+	}
 	var buf bytes.Buffer
-	err := g.tmpl.ExecuteTemplate(&buf, templateName, g.builder.BuildTemplateData(spec, packageName))
+	err := g.tmpl.ExecuteTemplate(&buf, templateName, data)
 	if err != nil {
 		return err
 	}
 	file := filepath.Join(g.outputPath, fileName)
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
 	if err := os.WriteFile(file, buf.Bytes(), 0644); err != nil {
 		return fmt.Errorf("failed to write file: %w", err)
 	}
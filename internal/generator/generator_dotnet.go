@@ -16,10 +16,11 @@ type DotNetGenerator struct {
 	generator *Generator
 }
 
-// Ensure DotNetGenerator implements MetricsGenerator and DIGenerator
+// Ensure DotNetGenerator implements MetricsGenerator, DIGenerator and MiddlewareGenerator
 var (
-	_ MetricsGenerator = (*DotNetGenerator)(nil)
-	_ DIGenerator      = (*DotNetGenerator)(nil)
+	_ MetricsGenerator    = (*DotNetGenerator)(nil)
+	_ DIGenerator         = (*DotNetGenerator)(nil)
+	_ MiddlewareGenerator = (*DotNetGenerator)(nil)
 )
 
 func NewDotNetGenerator(packageName string, outputPath string) (*DotNetGenerator, error) {
@@ -52,3 +53,20 @@ func (g *DotNetGenerator) GenerateDI(spec *domain.Specification) error {
 
 	return nil
 }
+
+func (g *DotNetGenerator) GenerateMiddleware(spec *domain.Specification) error {
+	middlewares, err := BuildHTTPMiddlewares(spec)
+	if err != nil {
+		return fmt.Errorf("failed to build middleware: %w", err)
+	}
+
+	data := g.generator.builder.BuildTemplateData(spec, g.generator.packageName)
+	data.Middlewares = middlewares
+
+	if err := g.generator.GenerateFileFromData(data, "middleware.gotmpl", "MetricsMiddleware.cs"); err != nil {
+		return err
+	}
+	fmt.Println("✓ Generated middleware:", filepath.Join(g.generator.outputPath, "MetricsMiddleware.cs"))
+
+	return nil
+}
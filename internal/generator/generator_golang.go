@@ -4,6 +4,8 @@ import (
 	"embed"
 	"fmt"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/jycamier/promener/internal/domain"
 )
@@ -11,15 +13,34 @@ import (
 //go:embed templates/go/*.gotmpl
 var templatesFS embed.FS
 
+// DIFramework identifies the Go dependency injection framework targeted by GenerateDI
+type DIFramework string
+
+const (
+	DIFrameworkFx   DIFramework = "fx"
+	DIFrameworkWire DIFramework = "wire"
+	DIFrameworkDig  DIFramework = "dig"
+)
+
+// Router adapters available for the generated Go HTTP middleware
+var goMiddlewareAdapters = []string{"chi", "gin"}
+
 // GolangGenerator generates Go code for Prometheus metrics
 type GolangGenerator struct {
-	generator *Generator
+	generator          *Generator
+	builder            *GoTemplateDataBuilder
+	importPath         string
+	diFramework        DIFramework
+	middlewareAdapters []string
 }
 
-// Ensure GolangGenerator implements MetricsGenerator and DIGenerator
+// Ensure GolangGenerator implements MetricsGenerator, DIGenerator, TestHelpersGenerator, MiddlewareGenerator and GRPCGenerator
 var (
-	_ MetricsGenerator = (*GolangGenerator)(nil)
-	_ DIGenerator      = (*GolangGenerator)(nil)
+	_ MetricsGenerator     = (*GolangGenerator)(nil)
+	_ DIGenerator          = (*GolangGenerator)(nil)
+	_ TestHelpersGenerator = (*GolangGenerator)(nil)
+	_ MiddlewareGenerator  = (*GolangGenerator)(nil)
+	_ GRPCGenerator        = (*GolangGenerator)(nil)
 )
 
 func NewGolangGenerator(packageName string, outputPath string) (*GolangGenerator, error) {
@@ -29,10 +50,18 @@ func NewGolangGenerator(packageName string, outputPath string) (*GolangGenerator
 		return nil, err
 	}
 	return &GolangGenerator{
-		generator: generator,
+		generator:   generator,
+		builder:     builder,
+		diFramework: DIFrameworkFx,
 	}, nil
 }
 
+// SetValidationCacheSize enables an LRU cache of the last accepted values in the label validators
+// using regexps or CEL. 0 (the default) disables the cache.
+func (g *GolangGenerator) SetValidationCacheSize(size int) {
+	g.builder.SetValidationCacheSize(size)
+}
+
 func (g *GolangGenerator) GenerateMetrics(spec *domain.Specification) error {
 	err := g.generator.GenerateFileFromTemplate(spec, g.generator.packageName, "metrics.gotmpl", "metrics.go")
 	if err != nil {
@@ -43,12 +72,121 @@ func (g *GolangGenerator) GenerateMetrics(spec *domain.Specification) error {
 	return nil
 }
 
+// SetDIFramework sets the DI framework targeted by GenerateDI (default: fx)
+func (g *GolangGenerator) SetDIFramework(framework DIFramework) {
+	g.diFramework = framework
+}
+
 func (g *GolangGenerator) GenerateDI(spec *domain.Specification) error {
-	err := g.generator.GenerateFileFromTemplate(spec, g.generator.packageName, "di_fx.gotmpl", "fx.go")
+	var templateName, fileName string
+	switch g.diFramework {
+	case DIFrameworkFx:
+		templateName, fileName = "di_fx.gotmpl", "fx.go"
+	case DIFrameworkWire:
+		templateName, fileName = "di_wire.gotmpl", "wire.go"
+	case DIFrameworkDig:
+		templateName, fileName = "di_dig.gotmpl", "dig.go"
+	default:
+		return fmt.Errorf("unsupported DI framework: %s", g.diFramework)
+	}
+
+	err := g.generator.GenerateFileFromTemplate(spec, g.generator.packageName, templateName, fileName)
 	if err != nil {
 		return err
 	}
-	fmt.Println("✓ Generated DI:", filepath.Join(g.generator.outputPath, "fx.go"))
+	fmt.Println("✓ Generated DI:", filepath.Join(g.generator.outputPath, fileName))
+
+	return nil
+}
+
+// SetImportPath sets the Go import path of the generated package.
+// When not set, it is resolved from the nearest go.mod of the output directory.
+func (g *GolangGenerator) SetImportPath(importPath string) {
+	g.importPath = importPath
+}
+
+func (g *GolangGenerator) GenerateTestHelpers(spec *domain.Specification) error {
+	importPath := g.importPath
+	if importPath == "" {
+		resolved, err := ResolveGoImportPath(g.generator.outputPath)
+		if err != nil {
+			return fmt.Errorf("failed to resolve import path (use --import-path): %w", err)
+		}
+		importPath = resolved
+	}
+
+	data := g.generator.builder.BuildTemplateData(spec, g.generator.packageName)
+	data.ImportPath = importPath
+
+	fileName := filepath.Join("metricstest", "metricstest.go")
+	if err := g.generator.GenerateFileFromData(data, "metricstest.gotmpl", fileName); err != nil {
+		return err
+	}
+	fmt.Println("✓ Generated test helpers:", filepath.Join(g.generator.outputPath, fileName))
+
+	return nil
+}
+
+// SetMiddlewareAdapters sets the router adapters (chi, gin) generated next to the net/http middleware
+func (g *GolangGenerator) SetMiddlewareAdapters(adapters []string) {
+	g.middlewareAdapters = adapters
+}
+
+func (g *GolangGenerator) GenerateMiddleware(spec *domain.Specification) error {
+	for _, adapter := range g.middlewareAdapters {
+		if !slices.Contains(goMiddlewareAdapters, adapter) {
+			return fmt.Errorf("unsupported middleware adapter: %s (available: %s)", adapter, strings.Join(goMiddlewareAdapters, ", "))
+		}
+	}
+
+	middlewares, err := BuildHTTPMiddlewares(spec)
+	if err != nil {
+		return fmt.Errorf("failed to build middleware: %w", err)
+	}
+
+	data := g.generator.builder.BuildTemplateData(spec, g.generator.packageName)
+	data.Middlewares = middlewares
+
+	names := []string{"middleware"}
+	for _, adapter := range g.middlewareAdapters {
+		names = append(names, "middleware_"+adapter)
+	}
+
+	for _, name := range names {
+		if err := g.generator.GenerateFileFromData(data, name+".gotmpl", name+".go"); err != nil {
+			return err
+		}
+		fmt.Println("✓ Generated middleware:", filepath.Join(g.generator.outputPath, name+".go"))
+	}
+
+	return nil
+}
+
+// GenerateOpenMetrics generates openmetrics.go with a Gatherer setting the unit of the metrics
+// and an http.Handler serving them in the OpenMetrics format with their # UNIT metadata
+func (g *GolangGenerator) GenerateOpenMetrics(spec *domain.Specification) error {
+	err := g.generator.GenerateFileFromTemplate(spec, g.generator.packageName, "openmetrics.gotmpl", "openmetrics.go")
+	if err != nil {
+		return err
+	}
+	fmt.Println("✓ Generated OpenMetrics handler:", filepath.Join(g.generator.outputPath, "openmetrics.go"))
+
+	return nil
+}
+
+func (g *GolangGenerator) GenerateGRPC(spec *domain.Specification) error {
+	interceptors, err := BuildGRPCInterceptors(spec)
+	if err != nil {
+		return fmt.Errorf("failed to build gRPC interceptors: %w", err)
+	}
+
+	data := g.generator.builder.BuildTemplateData(spec, g.generator.packageName)
+	data.GRPC = interceptors
+
+	if err := g.generator.GenerateFileFromData(data, "grpc.gotmpl", "grpc.go"); err != nil {
+		return err
+	}
+	fmt.Println("✓ Generated gRPC interceptors:", filepath.Join(g.generator.outputPath, "grpc.go"))
 
 	return nil
 }
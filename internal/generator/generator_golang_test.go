@@ -1,6 +1,8 @@
 package generator
 
 import (
+	"go/parser"
+	"go/token"
 	"os"
 	"path/filepath"
 	"strings"
@@ -15,6 +17,7 @@ func TestGolangGenerator_GenerateMetrics(t *testing.T) {
 		spec    *domain.Specification
 		wantErr bool
 		checks  []string // Content to verify in generated file
+		absent  []string // Content that must not be generated
 	}{
 		{
 			name: "simple counter without labels",
@@ -48,6 +51,10 @@ func TestGolangGenerator_GenerateMetrics(t *testing.T) {
 				"prometheus.NewCounter",
 				"http_server_requests_total",
 			},
+			absent: []string{
+				"type Config struct",
+				"NewRegistryWithConfig",
+			},
 		},
 		{
 			name: "counter with labels",
@@ -85,6 +92,11 @@ func TestGolangGenerator_GenerateMetrics(t *testing.T) {
 				"method",
 				"status",
 				"WithLabelValues",
+				"func NewRegistry(registerer prometheus.Registerer) (*MetricsRegistry, error)",
+				"registerer.Register(collector)",
+				"func (r *MetricsRegistry) Unregister() bool",
+				"func NewHttpMetrics(registerer prometheus.Registerer) (*HttpMetrics, error)",
+				"func (m *HttpMetrics) Unregister() bool",
 			},
 		},
 		{
@@ -148,6 +160,239 @@ func TestGolangGenerator_GenerateMetrics(t *testing.T) {
 				"prometheus.NewHistogram",
 				"http_request_duration",
 			},
+			absent: []string{
+				"TimeRequestDuration",
+			},
+		},
+		{
+			name: "timer, in-flight and timestamp helpers",
+			spec: &domain.Specification{
+				Services: map[string]domain.Service{
+					"default": {
+						Metrics: map[string]domain.Metric{
+							"request_duration_seconds": {
+								Namespace: "http",
+								Subsystem: "server",
+								Type:      domain.MetricTypeHistogram,
+								Help:      "Request duration in seconds",
+								Unit:      domain.UnitSeconds,
+								Labels:    []domain.LabelDefinition{{Name: "method"}},
+							},
+							"requests_in_flight": {
+								Namespace: "http",
+								Subsystem: "server",
+								Type:      domain.MetricTypeGauge,
+								Help:      "Requests being processed",
+								Semantics: domain.MetricSemanticsInFlight,
+								Labels:    []domain.LabelDefinition{{Name: "method", Validations: []string{"value in ['GET']"}}},
+							},
+							"last_success_timestamp_seconds": {
+								Namespace: "http",
+								Subsystem: "server",
+								Type:      domain.MetricTypeGauge,
+								Help:      "Last successful request",
+								Unit:      domain.UnitSeconds,
+								Semantics: domain.MetricSemanticsTimestamp,
+							},
+						},
+					},
+				},
+			},
+			checks: []string{
+				"TimeRequestDurationSeconds(method string) func()",
+				"timer := prometheus.NewTimer(m.requestDurationSeconds.WithLabelValues(method))",
+				"TrackRequestsInFlight(method string) func()",
+				"if err := validateHttpServerRequestsInFlightMethod(method); err != nil {",
+				"return gauge.Dec",
+				"SetToCurrentTimeLastSuccessTimestampSeconds()",
+				"m.lastSuccessTimestampSeconds.SetToCurrentTime()",
+			},
+			absent: []string{
+				"TrackRequestsInFlightInFlight",
+				") TimeLastSuccessTimestampSeconds(",
+			},
+		},
+		{
+			name: "native label validators",
+			spec: &domain.Specification{
+				Services: map[string]domain.Service{
+					"default": {
+						Metrics: map[string]domain.Metric{
+							"requests_total": {
+								Namespace: "http",
+								Subsystem: "server",
+								Type:      domain.MetricTypeCounter,
+								Help:      "Total HTTP requests",
+								Labels: []domain.LabelDefinition{
+									{Name: "method", Validations: []string{"value in ['GET', 'POST']"}},
+									{Name: "path", Validations: []string{"value.startsWith('/')", "size(value) <= 200"}},
+									{Name: "status", Validations: []string{"value.matches('^[1-5][0-9]{2}$')"}},
+								},
+							},
+						},
+					},
+				},
+			},
+			checks: []string{
+				"func validateHttpServerRequestsTotalMethod(value string) error {",
+				`case "GET", "POST":`,
+				`if !strings.HasPrefix(value, "/") {`,
+				"if utf8.RuneCountInString(value) > 200 {",
+				`var validateHttpServerRequestsTotalStatusRegexp0 = regexp.MustCompile("^[1-5][0-9]{2}$")`,
+				"if err := validateHttpServerRequestsTotalPath(path); err != nil {",
+			},
+			absent: []string{
+				"github.com/google/cel-go",
+				"validationCache",
+			},
+		},
+		{
+			name: "CEL label validators",
+			spec: &domain.Specification{
+				Services: map[string]domain.Service{
+					"default": {
+						Metrics: map[string]domain.Metric{
+							"requests_total": {
+								Namespace: "http",
+								Subsystem: "server",
+								Type:      domain.MetricTypeCounter,
+								Help:      "Total HTTP requests",
+								Labels: []domain.LabelDefinition{
+									{Name: "type", Validations: []string{`value.endsWith("_v2")`}},
+								},
+							},
+						},
+					},
+				},
+			},
+			checks: []string{
+				`"github.com/google/cel-go/cel"`,
+				`var validateHttpServerRequestsTotalTypeProgram0 = compileValidation("value.endsWith(\"_v2\")")`,
+				`if err := evalValidation(validateHttpServerRequestsTotalTypeProgram0, "type", value); err != nil {`,
+				"if err := validateHttpServerRequestsTotalType(type_); err != nil {",
+			},
+		},
+		{
+			name: "curried label handles",
+			spec: &domain.Specification{
+				Services: map[string]domain.Service{
+					"default": {
+						Metrics: map[string]domain.Metric{
+							"requests_total": {
+								Namespace: "http",
+								Subsystem: "server",
+								Type:      domain.MetricTypeCounter,
+								Help:      "Total HTTP requests",
+								Labels: []domain.LabelDefinition{
+									{Name: "method", Validations: []string{"value in ['GET']"}},
+									{Name: "path"},
+									{Name: "status"},
+								},
+							},
+							"queue_depth": {
+								Namespace: "http",
+								Subsystem: "server",
+								Type:      domain.MetricTypeGauge,
+								Help:      "Queued requests",
+								Labels:    []domain.LabelDefinition{{Name: "queue"}},
+							},
+							"uptime": {
+								Namespace: "http",
+								Subsystem: "server",
+								Type:      domain.MetricTypeGauge,
+								Help:      "Uptime",
+							},
+						},
+					},
+				},
+			},
+			checks: []string{
+				"RequestsTotalFor(method string, path string) HttpServerRequestsTotalCurried",
+				`m.requestsTotal.MustCurryWith(prometheus.Labels{`,
+				"func (c *httpServerRequestsTotalCurried) Add(status string, value float64) {",
+				"c.vec.WithLabelValues(status).Add(value)",
+				"For(status string) HttpServerRequestsTotalBound",
+				"func (b *httpServerRequestsTotalBound) Inc() {",
+				"QueueDepthFor(queue string) HttpServerQueueDepthBound",
+				"func (b *httpServerQueueDepthBound) Sub(value float64) {",
+			},
+			absent: []string{
+				"UptimeFor(",
+				"QueueDepthCurried",
+			},
+		},
+		{
+			name: "const labels reading environment variables",
+			spec: &domain.Specification{
+				Info: domain.Info{
+					Title:   "Test Metrics",
+					Version: "1.0.0",
+				},
+				Services: map[string]domain.Service{
+					"default": {
+						Info: domain.Info{
+							Title:   "Default Service",
+							Version: "1.0.0",
+						},
+						Metrics: map[string]domain.Metric{
+							"requests_total": {
+								Name:      "requests_total",
+								Namespace: "http",
+								Subsystem: "server",
+								Type:      domain.MetricTypeCounter,
+								Help:      "Total HTTP requests",
+								ConstLabels: []domain.ConstLabelDefinition{
+									{Name: "env", Value: "${ENVIRONMENT:production}"},
+									{Name: "region", Value: "${AWS_REGION}"},
+									{Name: "team", Value: "payments"},
+								},
+							},
+						},
+					},
+				},
+			},
+			checks: []string{
+				"type Config struct",
+				"Environment string",
+				"AwsRegion string",
+				"func ConfigFromEnv() Config",
+				"func ConfigFromMap(values map[string]string) Config",
+				"func (c Config) Validate() error",
+				`"AWS_REGION", c.AwsRegion,`,
+				"func NewHttpMetricsWithConfig(registerer prometheus.Registerer, cfg Config) (*HttpMetrics, error)",
+				`"AWS_REGION", cfg.AwsRegion,`,
+				"func NewRegistryWithConfig(registerer prometheus.Registerer, cfg Config) (*MetricsRegistry, error)",
+				"return newRegistry(registerer, ConfigFromEnv())",
+				`"env": valueOrDefault(cfg.Environment, "production")`,
+				`"region": cfg.AwsRegion`,
+				`"team": "payments"`,
+			},
+			absent: []string{
+				"os.Getenv",
+				"getEnvOrDefault",
+			},
+		},
+		{
+			name: "environment variables mapping to the same config field",
+			spec: &domain.Specification{
+				Services: map[string]domain.Service{
+					"default": {
+						Metrics: map[string]domain.Metric{
+							"requests_total": {
+								Name:      "requests_total",
+								Namespace: "http",
+								Type:      domain.MetricTypeCounter,
+								Help:      "Total HTTP requests",
+								ConstLabels: []domain.ConstLabelDefinition{
+									{Name: "region", Value: "${APP_REGION}"},
+									{Name: "zone", Value: "${APP__REGION}"},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
 		},
 	}
 
@@ -199,6 +444,12 @@ func TestGolangGenerator_GenerateMetrics(t *testing.T) {
 				}
 			}
 
+			for _, check := range tt.absent {
+				if strings.Contains(contentStr, check) {
+					t.Errorf("Generated file contains unexpected content: %q", check)
+				}
+			}
+
 			// Verify file is not empty
 			if len(content) == 0 {
 				t.Error("Generated file is empty")
@@ -207,45 +458,275 @@ func TestGolangGenerator_GenerateMetrics(t *testing.T) {
 	}
 }
 
+func TestGolangGenerator_GenerateMetrics_GoKeywordLabels(t *testing.T) {
+	spec := &domain.Specification{
+		Services: map[string]domain.Service{
+			"default": {
+				Metrics: map[string]domain.Metric{
+					"requests_total": {
+						Namespace: "http",
+						Subsystem: "server",
+						Type:      domain.MetricTypeCounter,
+						Help:      "Total HTTP requests",
+						Labels: []domain.LabelDefinition{
+							{Name: "range"},
+							{Name: "func"},
+							{Name: "type"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tmpDir, err := os.MkdirTemp("", "promener_test_*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	gen, err := NewGolangGenerator("testpackage", tmpDir)
+	if err != nil {
+		t.Fatalf("NewGolangGenerator() error = %v", err)
+	}
+	if err := gen.GenerateMetrics(spec); err != nil {
+		t.Fatalf("GolangGenerator.GenerateMetrics() error = %v", err)
+	}
+
+	metricsPath := filepath.Join(tmpDir, "metrics.go")
+	content, err := os.ReadFile(metricsPath)
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+
+	// The labels are used as parameters, a keyword left unescaped is a syntax error
+	if _, err := parser.ParseFile(token.NewFileSet(), metricsPath, content, 0); err != nil {
+		t.Errorf("Generated file is not valid Go: %v", err)
+	}
+	for _, check := range []string{
+		"RequestsTotalFor(range_ string, func_ string) HttpServerRequestsTotalCurried",
+		`"range": range_,`,
+		`"func": func_,`,
+	} {
+		if !strings.Contains(string(content), check) {
+			t.Errorf("Generated file missing expected content: %q", check)
+		}
+	}
+}
+
+func TestGolangGenerator_GenerateMetrics_ConstLabelEscaping(t *testing.T) {
+	spec := &domain.Specification{
+		Services: map[string]domain.Service{
+			"default": {
+				Metrics: map[string]domain.Metric{
+					"requests_total": {
+						Namespace: "http",
+						Subsystem: "server",
+						Type:      domain.MetricTypeCounter,
+						Help:      "Total HTTP requests",
+						ConstLabels: domain.ConstLabels{
+							{Name: "team", Value: `platform's "core" \ infra`},
+							{Name: "env", Value: `${ENVIRONMENT:"prod"\n}`},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tmpDir, err := os.MkdirTemp("", "promener_test_*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	gen, err := NewGolangGenerator("testpackage", tmpDir)
+	if err != nil {
+		t.Fatalf("NewGolangGenerator() error = %v", err)
+	}
+	if err := gen.GenerateMetrics(spec); err != nil {
+		t.Fatalf("GolangGenerator.GenerateMetrics() error = %v", err)
+	}
+
+	metricsPath := filepath.Join(tmpDir, "metrics.go")
+	content, err := os.ReadFile(metricsPath)
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+
+	if _, err := parser.ParseFile(token.NewFileSet(), metricsPath, content, 0); err != nil {
+		t.Errorf("Generated file is not valid Go: %v", err)
+	}
+	for _, check := range []string{
+		`"team": "platform's \"core\" \\ infra",`,
+		`"env": valueOrDefault(cfg.Environment, "\"prod\"\\n"),`,
+	} {
+		if !strings.Contains(string(content), check) {
+			t.Errorf("Generated file missing expected content: %q", check)
+		}
+	}
+}
+
+func TestGolangGenerator_GenerateOpenMetrics(t *testing.T) {
+	spec := &domain.Specification{
+		Version: "1.0.0",
+		Services: map[string]domain.Service{
+			"default": {
+				Metrics: map[string]domain.Metric{
+					"latency": {
+						Namespace: "http",
+						Subsystem: "server",
+						Type:      domain.MetricTypeHistogram,
+						Help:      "Request latency",
+						Unit:      domain.UnitSeconds,
+					},
+					"request_duration_seconds": {
+						Namespace: "http",
+						Subsystem: "server",
+						Type:      domain.MetricTypeHistogram,
+						Help:      "Request duration in seconds",
+						Unit:      domain.UnitSeconds,
+					},
+				},
+			},
+		},
+	}
+
+	tmpDir, err := os.MkdirTemp("", "promener_test_*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	gen, err := NewGolangGenerator("testpackage", tmpDir)
+	if err != nil {
+		t.Fatalf("NewGolangGenerator() error = %v", err)
+	}
+	if err := gen.GenerateMetrics(spec); err != nil {
+		t.Fatalf("GolangGenerator.GenerateMetrics() error = %v", err)
+	}
+	if err := gen.GenerateOpenMetrics(spec); err != nil {
+		t.Fatalf("GolangGenerator.GenerateOpenMetrics() error = %v", err)
+	}
+
+	path := filepath.Join(tmpDir, "openmetrics.go")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), path, content, 0); err != nil {
+		t.Errorf("Generated file is not valid Go: %v", err)
+	}
+	for _, check := range []string{
+		`"http_server_request_duration_seconds": "seconds",`,
+		"func OpenMetricsHandler(gatherer prometheus.Gatherer) http.Handler {",
+		"if err := encodeOpenMetrics(&buf, format, families); err != nil {",
+		"return closer.Close()",
+	} {
+		if !strings.Contains(string(content), check) {
+			t.Errorf("Generated file missing expected content: %q", check)
+		}
+	}
+	// The v1 metric has no unit suffix, expfmt.WithUnit would rename it
+	if strings.Contains(string(content), `"http_server_latency"`) {
+		t.Error("Generated file sets the unit of a metric without the unit suffix")
+	}
+
+	metrics, err := os.ReadFile(filepath.Join(tmpDir, "metrics.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	for _, absent := range []string{`"net/http"`, "expfmt", "metricUnits"} {
+		if strings.Contains(string(metrics), absent) {
+			t.Errorf("metrics.go contains unexpected content: %q", absent)
+		}
+	}
+}
+
 func TestGolangGenerator_GenerateDI(t *testing.T) {
-	tests := []struct {
-		name    string
-		spec    *domain.Specification
-		wantErr bool
-		checks  []string
-	}{
-		{
-			name: "FX DI generation",
-			spec: &domain.Specification{
+	spec := &domain.Specification{
+		Info: domain.Info{
+			Title:   "Test Metrics",
+			Version: "1.0.0",
+		},
+		Services: map[string]domain.Service{
+			"default": {
 				Info: domain.Info{
-					Title:   "Test Metrics",
+					Title:   "Default Service",
 					Version: "1.0.0",
 				},
-				Services: map[string]domain.Service{
-					"default": {
-						Info: domain.Info{
-							Title:   "Default Service",
-							Version: "1.0.0",
-						},
-						Metrics: map[string]domain.Metric{
-							"requests_total": {
-								Name:      "requests_total",
-								Namespace: "http",
-								Type:      domain.MetricTypeCounter,
-								Help:      "Total requests",
-								Labels:    []domain.LabelDefinition{},
-							},
-						},
+				Metrics: map[string]domain.Metric{
+					"requests_total": {
+						Name:      "requests_total",
+						Namespace: "http",
+						Subsystem: "server",
+						Type:      domain.MetricTypeCounter,
+						Help:      "Total requests",
+						Labels:    []domain.LabelDefinition{},
 					},
 				},
 			},
-			wantErr: false,
+		},
+	}
+
+	tests := []struct {
+		name      string
+		framework DIFramework
+		fileName  string
+		wantErr   bool
+		checks    []string
+	}{
+		{
+			name:      "FX DI generation",
+			framework: DIFrameworkFx,
+			fileName:  "fx.go",
+			wantErr:   false,
 			checks: []string{
 				"package testpackage",
 				"fx.Module",
 				"fx.Provide",
+				"var Module = ModuleWithRegistry(prometheus.DefaultRegisterer)",
+				"func NewMetricsRegistryForFx(lc fx.Lifecycle) (*MetricsRegistry, error)",
+				"OnStop: func(context.Context) error",
+				"var HttpModule = HttpModuleWithRegistry(prometheus.DefaultRegisterer)",
+				`fx.Module("metrics.http",`,
+				"m, err := NewHttpMetrics(registerer)",
+				"func NamedModule(name string, registerer prometheus.Registerer) fx.Option",
+				"fx.Annotate(provideHttpServerMetrics, fx.ParamTags(tag), fx.ResultTags(tag))",
+			},
+		},
+		{
+			name:      "Wire DI generation",
+			framework: DIFrameworkWire,
+			fileName:  "wire.go",
+			wantErr:   false,
+			checks: []string{
+				"package testpackage",
+				`"github.com/google/wire"`,
+				"var ProviderSet = wire.NewSet(",
+				"wire.InterfaceValue(new(prometheus.Registerer), prometheus.DefaultRegisterer)",
+				"func ProvideMetricsRegistry(registerer prometheus.Registerer) (*MetricsRegistry, func(), error)",
+				"func ProvideHttpServerMetrics(metrics *HttpMetrics) HttpServerMetrics",
 			},
 		},
+		{
+			name:      "dig DI generation",
+			framework: DIFrameworkDig,
+			fileName:  "dig.go",
+			wantErr:   false,
+			checks: []string{
+				"package testpackage",
+				`"go.uber.org/dig"`,
+				"func Provide(c *dig.Container, registerer prometheus.Registerer) error",
+				"return NewRegistry(registerer)",
+				"func(metrics *HttpMetrics) HttpServerMetrics",
+			},
+		},
+		{
+			name:      "unsupported framework",
+			framework: DIFramework("unknown"),
+			wantErr:   true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -262,9 +743,10 @@ func TestGolangGenerator_GenerateDI(t *testing.T) {
 			if err != nil {
 				t.Fatalf("NewGolangGenerator() error = %v", err)
 			}
+			gen.SetDIFramework(tt.framework)
 
 			// Generate DI
-			err = gen.GenerateDI(tt.spec)
+			err = gen.GenerateDI(spec)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("GolangGenerator.GenerateDI() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -274,15 +756,15 @@ func TestGolangGenerator_GenerateDI(t *testing.T) {
 				return
 			}
 
-			// Check that fx.go was created
-			fxPath := filepath.Join(tmpDir, "fx.go")
-			if _, err := os.Stat(fxPath); os.IsNotExist(err) {
-				t.Errorf("Expected fx.go to be created at %s", fxPath)
+			// Check that the DI file was created
+			diPath := filepath.Join(tmpDir, tt.fileName)
+			if _, err := os.Stat(diPath); os.IsNotExist(err) {
+				t.Errorf("Expected %s to be created at %s", tt.fileName, diPath)
 				return
 			}
 
 			// Read generated file
-			content, err := os.ReadFile(fxPath)
+			content, err := os.ReadFile(diPath)
 			if err != nil {
 				t.Fatalf("Failed to read generated file: %v", err)
 			}
@@ -304,6 +786,69 @@ func TestGolangGenerator_GenerateDI(t *testing.T) {
 	}
 }
 
+func TestGolangGenerator_GenerateDI_Config(t *testing.T) {
+	spec := &domain.Specification{
+		Info: domain.Info{
+			Title:   "Test Metrics",
+			Version: "1.0.0",
+		},
+		Services: map[string]domain.Service{
+			"default": {
+				Info: domain.Info{
+					Title:   "Default Service",
+					Version: "1.0.0",
+				},
+				Metrics: map[string]domain.Metric{
+					"requests_total": {
+						Name:      "requests_total",
+						Namespace: "http",
+						Subsystem: "server",
+						Type:      domain.MetricTypeCounter,
+						Help:      "Total requests",
+						ConstLabels: []domain.ConstLabelDefinition{
+							{Name: "region", Value: "${AWS_REGION}"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tmpDir, err := os.MkdirTemp("", "promener_test_*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	gen, err := NewGolangGenerator("testpackage", tmpDir)
+	if err != nil {
+		t.Fatalf("NewGolangGenerator() error = %v", err)
+	}
+	gen.SetDIFramework(DIFrameworkFx)
+
+	if err := gen.GenerateDI(spec); err != nil {
+		t.Fatalf("GolangGenerator.GenerateDI() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "fx.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+
+	for _, check := range []string{
+		"func(lc fx.Lifecycle, cfg *Config) (*MetricsRegistry, error)",
+		"fx.ParamTags(``, `optional:\"true\"`)",
+		"fx.ParamTags(``, tag+` optional:\"true\"`)",
+		"return newRegistryForFx(lc, prometheus.DefaultRegisterer, nil)",
+		"registry, err = NewRegistryWithConfig(registerer, *cfg)",
+		"m, err = NewHttpMetricsWithConfig(registerer, *cfg)",
+	} {
+		if !strings.Contains(string(content), check) {
+			t.Errorf("Generated file missing expected content: %q", check)
+		}
+	}
+}
+
 func TestGolangGenerator_Creation(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -407,3 +952,268 @@ func TestGoTemplateDataBuilder_BuildTemplateData(t *testing.T) {
 		t.Error("Http namespace not found in template data")
 	}
 }
+
+func TestGolangGenerator_GenerateTestHelpers(t *testing.T) {
+	spec := &domain.Specification{
+		Info: domain.Info{
+			Title:   "Test Metrics",
+			Version: "1.0.0",
+		},
+		Services: map[string]domain.Service{
+			"default": {
+				Info: domain.Info{
+					Title:   "Default Service",
+					Version: "1.0.0",
+				},
+				Metrics: map[string]domain.Metric{
+					"requests_total": {
+						Name:      "requests_total",
+						Namespace: "http",
+						Subsystem: "server",
+						Type:      domain.MetricTypeCounter,
+						Help:      "Total requests",
+						Labels: []domain.LabelDefinition{
+							{Name: "method"},
+							{Name: "status"},
+						},
+					},
+					"request_duration_seconds": {
+						Name:      "request_duration_seconds",
+						Namespace: "http",
+						Subsystem: "server",
+						Type:      domain.MetricTypeHistogram,
+						Help:      "Request duration",
+						Buckets:   []float64{0.1, 1},
+					},
+				},
+			},
+		},
+	}
+
+	tmpDir, err := os.MkdirTemp("", "promener_test_*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	gen, err := NewGolangGenerator("metrics", tmpDir)
+	if err != nil {
+		t.Fatalf("NewGolangGenerator() error = %v", err)
+	}
+	gen.SetImportPath("example.com/app/metrics")
+
+	if err := gen.GenerateTestHelpers(spec); err != nil {
+		t.Fatalf("GolangGenerator.GenerateTestHelpers() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "metricstest", "metricstest.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+
+	checks := []string{
+		"package metricstest",
+		`metrics "example.com/app/metrics"`,
+		"type HttpServerMetrics struct",
+		"var _ metrics.HttpServerMetrics = (*HttpServerMetrics)(nil)",
+		"func (f *HttpServerMetrics) IncRequestsTotal(method string, status string)",
+		"func (f *HttpServerMetrics) AssertIncRequestsTotal(t testing.TB, method string, status string)",
+		"func (f *HttpServerMetrics) ObserveRequestDurationSeconds(value float64)",
+		"func (r *recorder) ObservedValues(method string, labelValues ...string) []float64",
+		"func NewPedanticRegistry(t testing.TB)",
+		"testutil.GatherAndCompare",
+	}
+	for _, check := range checks {
+		if !strings.Contains(string(content), check) {
+			t.Errorf("Generated file missing expected content: %q", check)
+		}
+	}
+}
+
+func TestGolangGenerator_GenerateMiddleware(t *testing.T) {
+	spec := newMiddlewareSpec(
+		&domain.HTTPLabelMapping{Method: "method", Route: "path", StatusCode: "status"},
+		domain.GoldenSignals{
+			Traffic:    &domain.GoldenSignal{Metrics: []string{"requests_total"}},
+			Latency:    &domain.GoldenSignal{Metrics: []string{"request_duration_seconds"}},
+			Saturation: &domain.GoldenSignal{Metrics: []string{"requests_in_flight"}},
+		},
+	)
+
+	tmpDir, err := os.MkdirTemp("", "promener_test_*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	gen, err := NewGolangGenerator("metrics", tmpDir)
+	if err != nil {
+		t.Fatalf("NewGolangGenerator() error = %v", err)
+	}
+	gen.SetMiddlewareAdapters([]string{"chi", "gin"})
+
+	if err := gen.GenerateMiddleware(spec); err != nil {
+		t.Fatalf("GolangGenerator.GenerateMiddleware() error = %v", err)
+	}
+
+	files := map[string][]string{
+		"middleware.go": {
+			"func NewHttpServerMiddleware(registry *MetricsRegistry, options MiddlewareOptions) *HttpServerMiddleware",
+			"func (mw *HttpServerMiddleware) Handler(next http.Handler) http.Handler",
+			"func ServeMuxRoute(r *http.Request) string",
+			"mw.incHttpServerRequestsInFlight()",
+			"mw.decHttpServerRequestsInFlight()",
+			"statusCode := strconv.Itoa(status)",
+			"mw.incHttpServerRequestsTotal(method, route, statusCode)",
+			"mw.observeHttpServerRequestDurationSeconds(method, duration)",
+			"if err := validateHttpServerRequestsTotalMethod(method); err != nil {",
+			"if m, ok := mw.registry.Http.Server.(*HttpServerMetricsImpl); ok {",
+			"m.requestsTotal.WithLabelValues(method, route, statusCode).Inc()",
+			"m.requestDurationSeconds.WithLabelValues(method).Observe(duration)",
+			"m.requestsInFlight.Inc()",
+			"mw.registry.Http.Server.IncRequestsTotal(method, route, statusCode)",
+		},
+		"middleware_chi.go": {
+			`"github.com/go-chi/chi/v5"`,
+			"func (mw *HttpServerMiddleware) Chi() func(http.Handler) http.Handler",
+		},
+		"middleware_gin.go": {
+			`"github.com/gin-gonic/gin"`,
+			"func (mw *HttpServerMiddleware) Gin() gin.HandlerFunc",
+		},
+	}
+	for file, checks := range files {
+		content, err := os.ReadFile(filepath.Join(tmpDir, file))
+		if err != nil {
+			t.Fatalf("Failed to read generated file %s: %v", file, err)
+		}
+		for _, check := range checks {
+			if !strings.Contains(string(content), check) {
+				t.Errorf("%s missing expected content: %q", file, check)
+			}
+		}
+	}
+
+	gen.SetMiddlewareAdapters([]string{"echo"})
+	if err := gen.GenerateMiddleware(spec); err == nil {
+		t.Error("Expected an error for an unsupported adapter")
+	}
+}
+
+func TestGolangGenerator_GenerateGRPC(t *testing.T) {
+	spec := newGRPCSpec(map[string]domain.Metric{
+		"handled_total": {
+			Namespace: "grpc",
+			Subsystem: "server",
+			Type:      domain.MetricTypeCounter,
+			Role:      domain.MetricRoleGRPCServerHandled,
+			Labels:    domain.Labels{{Name: "grpc_code"}, {Name: "grpc_method", Validations: []string{"size(value) <= 64"}}},
+		},
+		"handling_seconds": {
+			Namespace: "grpc",
+			Subsystem: "server",
+			Type:      domain.MetricTypeHistogram,
+			Role:      domain.MetricRoleGRPCServerHandlingSeconds,
+			Labels:    domain.Labels{{Name: "grpc_method"}},
+		},
+	})
+
+	tmpDir, err := os.MkdirTemp("", "promener_test_*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	gen, err := NewGolangGenerator("metrics", tmpDir)
+	if err != nil {
+		t.Fatalf("NewGolangGenerator() error = %v", err)
+	}
+	if err := gen.GenerateGRPC(spec); err != nil {
+		t.Fatalf("GolangGenerator.GenerateGRPC() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "grpc.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	checks := []string{
+		`"google.golang.org/grpc"`,
+		"func NewGRPCServerInterceptors(registry *MetricsRegistry, options GRPCOptions) *GRPCServerInterceptors",
+		"func (i *GRPCServerInterceptors) UnaryServerInterceptor() grpc.UnaryServerInterceptor",
+		"func (i *GRPCServerInterceptors) StreamServerInterceptor() grpc.StreamServerInterceptor",
+		"grpcCode := code.String()",
+		"c.interceptors.incGrpcServerHandledTotal(grpcCode, c.grpcMethod)",
+		"c.interceptors.observeGrpcServerHandlingSeconds(c.grpcMethod, duration)",
+		"if err := validateGrpcServerHandledTotalGrpcMethod(grpcMethod); err != nil {",
+		"if m, ok := i.registry.Grpc.Server.(*GrpcServerMetricsImpl); ok {",
+		"m.handledTotal.WithLabelValues(grpcCode, grpcMethod).Inc()",
+		"i.registry.Grpc.Server.IncHandledTotal(grpcCode, grpcMethod)",
+	}
+	for _, check := range checks {
+		if !strings.Contains(string(content), check) {
+			t.Errorf("grpc.go missing expected content: %q", check)
+		}
+	}
+	if strings.Contains(string(content), "GRPCClientInterceptors") {
+		t.Error("grpc.go should not contain client interceptors without client roles")
+	}
+
+	if err := gen.GenerateGRPC(newGRPCSpec(map[string]domain.Metric{})); err == nil {
+		t.Error("Expected an error for a specification without gRPC roles")
+	}
+}
+
+func TestGolangGenerator_ValidationCache(t *testing.T) {
+	spec := &domain.Specification{
+		Services: map[string]domain.Service{
+			"default": {
+				Metrics: map[string]domain.Metric{
+					"requests_total": {
+						Namespace: "http",
+						Subsystem: "server",
+						Type:      domain.MetricTypeCounter,
+						Help:      "Total HTTP requests",
+						Labels: []domain.LabelDefinition{
+							{Name: "method", Validations: []string{"value in ['GET', 'POST']"}},
+							{Name: "status", Validations: []string{"value.matches('^[1-5][0-9]{2}$')"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tmpDir, err := os.MkdirTemp("", "promener_test_*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	gen, err := NewGolangGenerator("metrics", tmpDir)
+	if err != nil {
+		t.Fatalf("NewGolangGenerator() error = %v", err)
+	}
+	gen.SetValidationCacheSize(256)
+	if err := gen.GenerateMetrics(spec); err != nil {
+		t.Fatalf("GolangGenerator.GenerateMetrics() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(tmpDir, "metrics.go"))
+	if err != nil {
+		t.Fatalf("Failed to read generated file: %v", err)
+	}
+	checks := []string{
+		`"container/list"`,
+		"var validateHttpServerRequestsTotalStatusCache = newValidationCache(256)",
+		"if validateHttpServerRequestsTotalStatusCache.contains(value) {",
+		"validateHttpServerRequestsTotalStatusCache.add(value)",
+	}
+	for _, check := range checks {
+		if !strings.Contains(string(content), check) {
+			t.Errorf("metrics.go missing expected content: %q", check)
+		}
+	}
+	if strings.Contains(string(content), "validateHttpServerRequestsTotalMethodCache") {
+		t.Error("metrics.go should not cache native in checks")
+	}
+}
@@ -16,8 +16,11 @@ type NodeJSGenerator struct {
 	generator *Generator
 }
 
-// Ensure NodeJSGenerator implements MetricsGenerator
-var _ MetricsGenerator = (*NodeJSGenerator)(nil)
+// Ensure NodeJSGenerator implements MetricsGenerator and MiddlewareGenerator
+var (
+	_ MetricsGenerator    = (*NodeJSGenerator)(nil)
+	_ MiddlewareGenerator = (*NodeJSGenerator)(nil)
+)
 
 func NewNodeJSGenerator(packageName string, outputPath string) (*NodeJSGenerator, error) {
 	builder := NewNodeJSTemplateDataBuilder()
@@ -39,3 +42,20 @@ func (g *NodeJSGenerator) GenerateMetrics(spec *domain.Specification) error {
 
 	return nil
 }
+
+func (g *NodeJSGenerator) GenerateMiddleware(spec *domain.Specification) error {
+	middlewares, err := BuildHTTPMiddlewares(spec)
+	if err != nil {
+		return fmt.Errorf("failed to build middleware: %w", err)
+	}
+
+	data := g.generator.builder.BuildTemplateData(spec, g.generator.packageName)
+	data.Middlewares = middlewares
+
+	if err := g.generator.GenerateFileFromData(data, "middleware.gotmpl", "middleware.ts"); err != nil {
+		return err
+	}
+	fmt.Println("✓ Generated middleware:", filepath.Join(g.generator.outputPath, "middleware.ts"))
+
+	return nil
+}
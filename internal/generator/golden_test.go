@@ -0,0 +1,259 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jycamier/promener/internal/domain"
+	"github.com/jycamier/promener/internal/validator"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite the golden files of testdata/golden from the generated code")
+
+const (
+	goldenSpecs = "../../testdata/*.cue"
+	goldenDir   = "testdata/golden"
+
+	// goldenPackage is the namespace of the generated .NET code
+	goldenPackage = "Golden"
+
+	// Versions of the runtime libraries restored to type-check the generated code
+	goldenPrometheusNetVersion = "8.2.1"
+	goldenPromClientVersion    = "15.1.3"
+
+	// toolchainTimeout bounds the package restores, which hang without network access
+	toolchainTimeout = 2 * time.Minute
+)
+
+// TestGoldenFiles renders the .NET and Node.js templates for every spec of testdata and compares
+// the generated files with testdata/golden/<spec>/<language>. Run with -update after an intended
+// change of the templates. When dotnet or tsc is installed, the generated code is also type-checked.
+func TestGoldenFiles(t *testing.T) {
+	specs, err := filepath.Glob(goldenSpecs)
+	if err != nil {
+		t.Fatalf("invalid glob %s: %v", goldenSpecs, err)
+	}
+	if len(specs) == 0 {
+		t.Fatalf("no spec matches %s", goldenSpecs)
+	}
+
+	for _, specFile := range specs {
+		name := strings.TrimSuffix(filepath.Base(specFile), filepath.Ext(specFile))
+		spec, _, err := validator.New().ValidateAndExtract(specFile)
+		if err != nil {
+			t.Fatalf("failed to load %s: %v", specFile, err)
+		}
+		// Middleware are only generated for the specs declaring an http label mapping
+		_, middlewareErr := BuildHTTPMiddlewares(spec)
+		hasMiddleware := middlewareErr == nil
+
+		t.Run(name+"/dotnet", func(t *testing.T) {
+			outputDir := t.TempDir()
+			gen, err := NewDotNetGenerator(goldenPackage, outputDir)
+			if err != nil {
+				t.Fatalf("NewDotNetGenerator() error = %v", err)
+			}
+			generate(t, "GenerateMetrics", gen.GenerateMetrics, spec)
+			generate(t, "GenerateDI", gen.GenerateDI, spec)
+			if hasMiddleware {
+				generate(t, "GenerateMiddleware", gen.GenerateMiddleware, spec)
+			}
+
+			compareGoldenDir(t, outputDir, filepath.Join(goldenDir, name, "dotnet"))
+			dotnetBuild(t, outputDir)
+		})
+
+		t.Run(name+"/nodejs", func(t *testing.T) {
+			outputDir := t.TempDir()
+			gen, err := NewNodeJSGenerator(goldenPackage, outputDir)
+			if err != nil {
+				t.Fatalf("NewNodeJSGenerator() error = %v", err)
+			}
+			generate(t, "GenerateMetrics", gen.GenerateMetrics, spec)
+			if hasMiddleware {
+				generate(t, "GenerateMiddleware", gen.GenerateMiddleware, spec)
+			}
+
+			compareGoldenDir(t, outputDir, filepath.Join(goldenDir, name, "nodejs"))
+			tscNoEmit(t, outputDir)
+		})
+	}
+}
+
+func generate(t *testing.T, method string, fn func(*domain.Specification) error, spec *domain.Specification) {
+	t.Helper()
+	if err := fn(spec); err != nil {
+		t.Fatalf("%s() error = %v", method, err)
+	}
+}
+
+// compareGoldenDir compares the files generated in outputDir with the golden files,
+// or replaces the golden files with them when running with -update
+func compareGoldenDir(t *testing.T, outputDir, goldenPath string) {
+	t.Helper()
+
+	generated := readDirFiles(t, outputDir)
+	if *updateGolden {
+		if err := os.RemoveAll(goldenPath); err != nil {
+			t.Fatalf("failed to remove %s: %v", goldenPath, err)
+		}
+		if err := os.MkdirAll(goldenPath, 0755); err != nil {
+			t.Fatalf("failed to create %s: %v", goldenPath, err)
+		}
+		for name, content := range generated {
+			if err := os.WriteFile(filepath.Join(goldenPath, name), content, 0644); err != nil {
+				t.Fatalf("failed to write %s: %v", name, err)
+			}
+		}
+		t.Logf("updated %s", goldenPath)
+		return
+	}
+
+	golden := readDirFiles(t, goldenPath)
+	for _, name := range sortedKeys(generated) {
+		want, ok := golden[name]
+		if !ok {
+			t.Errorf("%s has no golden file in %s (run with -update)", name, goldenPath)
+			continue
+		}
+		if line, ok := firstDifference(want, generated[name]); ok {
+			t.Errorf("%s differs from its golden file at line %d (run with -update after an intended change):\n%s", name, line, generated[name])
+		}
+	}
+	for _, name := range sortedKeys(golden) {
+		if _, ok := generated[name]; !ok {
+			t.Errorf("golden file %s was not generated", filepath.Join(goldenPath, name))
+		}
+	}
+}
+
+func readDirFiles(t *testing.T, dir string) map[string][]byte {
+	t.Helper()
+	files := make(map[string][]byte)
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[rel], err = os.ReadFile(path)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", dir, err)
+	}
+	return files
+}
+
+// firstDifference returns the first line, starting at 1, on which want and got differ
+func firstDifference(want, got []byte) (int, bool) {
+	if bytes.Equal(want, got) {
+		return 0, false
+	}
+	wantLines := strings.Split(string(want), "\n")
+	gotLines := strings.Split(string(got), "\n")
+	for i := range min(len(wantLines), len(gotLines)) {
+		if wantLines[i] != gotLines[i] {
+			return i + 1, true
+		}
+	}
+	return min(len(wantLines), len(gotLines)) + 1, true
+}
+
+// dotnetBuild compiles the generated C# code against prometheus-net and ASP.NET Core.
+// It is skipped when dotnet is not installed or prometheus-net cannot be restored.
+func dotnetBuild(t *testing.T, outputDir string) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("skipping the dotnet build in short mode")
+	}
+	dotnet, err := exec.LookPath("dotnet")
+	if err != nil {
+		t.Skip("dotnet not found")
+	}
+
+	project := fmt.Sprintf(`<Project Sdk="Microsoft.NET.Sdk">
+  <PropertyGroup>
+    <TargetFramework>net8.0</TargetFramework>
+    <Nullable>enable</Nullable>
+    <OutputType>Library</OutputType>
+  </PropertyGroup>
+  <ItemGroup>
+    <FrameworkReference Include="Microsoft.AspNetCore.App" />
+    <PackageReference Include="prometheus-net" Version="%s" />
+  </ItemGroup>
+</Project>
+`, goldenPrometheusNetVersion)
+	writeToolchainFile(t, filepath.Join(outputDir, "Golden.csproj"), project)
+
+	if output, err := runToolchain(outputDir, dotnet, "restore", "-nologo"); err != nil {
+		t.Skipf("failed to restore prometheus-net: %v\n%s", err, output)
+	}
+	if output, err := runToolchain(outputDir, dotnet, "build", "-nologo", "--no-restore"); err != nil {
+		t.Errorf("the generated C# code does not compile: %v\n%s", err, output)
+	}
+}
+
+// tscNoEmit type-checks the generated TypeScript code against prom-client.
+// It is skipped when tsc or npm is not installed or prom-client cannot be installed.
+func tscNoEmit(t *testing.T, outputDir string) {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("skipping tsc in short mode")
+	}
+	tsc, err := exec.LookPath("tsc")
+	if err != nil {
+		t.Skip("tsc not found")
+	}
+	npm, err := exec.LookPath("npm")
+	if err != nil {
+		t.Skip("npm not found")
+	}
+
+	writeToolchainFile(t, filepath.Join(outputDir, "package.json"), `{"private": true}`+"\n")
+	writeToolchainFile(t, filepath.Join(outputDir, "tsconfig.json"), `{
+  "compilerOptions": {
+    "target": "ES2020",
+    "module": "commonjs",
+    "moduleResolution": "node",
+    "strict": true,
+    "noEmit": true,
+    "types": ["node"]
+  },
+  "include": ["*.ts"]
+}
+`)
+
+	if output, err := runToolchain(outputDir, npm, "install", "--no-audit", "--no-fund", "prom-client@"+goldenPromClientVersion, "@types/node"); err != nil {
+		t.Skipf("failed to install prom-client: %v\n%s", err, output)
+	}
+	if output, err := runToolchain(outputDir, tsc, "--noEmit", "-p", "."); err != nil {
+		t.Errorf("the generated TypeScript code does not type-check: %v\n%s", err, output)
+	}
+}
+
+func writeToolchainFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+func runToolchain(dir, name string, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), toolchainTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	return cmd.CombinedOutput()
+}
@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ResolveGoImportPath returns the Go import path of the package located in dir.
+// It walks up from dir to the nearest go.mod and joins its module path with
+// the relative path of dir inside the module.
+func ResolveGoImportPath(dir string) (string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to get absolute path: %w", err)
+	}
+
+	for curr := absDir; ; curr = filepath.Dir(curr) {
+		goModPath := filepath.Join(curr, "go.mod")
+		if _, err := os.Stat(goModPath); err == nil {
+			modulePath, err := readModulePath(goModPath)
+			if err != nil {
+				return "", err
+			}
+
+			rel, err := filepath.Rel(curr, absDir)
+			if err != nil {
+				return "", fmt.Errorf("failed to compute package path: %w", err)
+			}
+			if rel == "." {
+				return modulePath, nil
+			}
+			return modulePath + "/" + filepath.ToSlash(rel), nil
+		}
+
+		if curr == filepath.Dir(curr) {
+			return "", fmt.Errorf("no go.mod found in %s or any parent directory", absDir)
+		}
+	}
+}
+
+// readModulePath extracts the module path from a go.mod file
+func readModulePath(goModPath string) (string, error) {
+	file, err := os.Open(goModPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open go.mod: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to read go.mod: %w", err)
+	}
+
+	return "", fmt.Errorf("no module directive found in %s", goModPath)
+}
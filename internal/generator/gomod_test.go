@@ -0,0 +1,44 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveGoImportPath(t *testing.T) {
+	root, err := os.MkdirTemp("", "promener_gomod_*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer func() { _ = os.RemoveAll(root) }()
+
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/app\n\ngo 1.22\n"), 0644); err != nil {
+		t.Fatalf("Failed to write go.mod: %v", err)
+	}
+	nested := filepath.Join(root, "internal", "metrics")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatalf("Failed to create nested dir: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		dir  string
+		want string
+	}{
+		{name: "module root", dir: root, want: "example.com/app"},
+		{name: "nested package", dir: nested, want: "example.com/app/internal/metrics"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveGoImportPath(tt.dir)
+			if err != nil {
+				t.Fatalf("ResolveGoImportPath() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveGoImportPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -15,3 +15,21 @@ type DIGenerator interface {
 	// GenerateDI generates dependency injection code (e.g., FX module for Go, DI extensions for .NET)
 	GenerateDI(spec *domain.Specification) error
 }
+
+// TestHelpersGenerator is the interface for generating test helpers and fakes
+type TestHelpersGenerator interface {
+	// GenerateTestHelpers generates recording fakes and assertion helpers for the generated metrics
+	GenerateTestHelpers(spec *domain.Specification) error
+}
+
+// MiddlewareGenerator is the interface for generating HTTP middleware from golden signals
+type MiddlewareGenerator interface {
+	// GenerateMiddleware generates HTTP middleware feeding the traffic, errors and latency metrics
+	GenerateMiddleware(spec *domain.Specification) error
+}
+
+// GRPCGenerator is the interface for generating gRPC interceptors from metric roles
+type GRPCGenerator interface {
+	// GenerateGRPC generates gRPC server and client interceptors feeding the metrics declaring a gRPC role
+	GenerateGRPC(spec *domain.Specification) error
+}
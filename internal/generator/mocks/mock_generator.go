@@ -91,3 +91,117 @@ func (mr *MockDIGeneratorMockRecorder) GenerateDI(spec any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateDI", reflect.TypeOf((*MockDIGenerator)(nil).GenerateDI), spec)
 }
+
+// MockTestHelpersGenerator is a mock of TestHelpersGenerator interface.
+type MockTestHelpersGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MockTestHelpersGeneratorMockRecorder
+	isgomock struct{}
+}
+
+// MockTestHelpersGeneratorMockRecorder is the mock recorder for MockTestHelpersGenerator.
+type MockTestHelpersGeneratorMockRecorder struct {
+	mock *MockTestHelpersGenerator
+}
+
+// NewMockTestHelpersGenerator creates a new mock instance.
+func NewMockTestHelpersGenerator(ctrl *gomock.Controller) *MockTestHelpersGenerator {
+	mock := &MockTestHelpersGenerator{ctrl: ctrl}
+	mock.recorder = &MockTestHelpersGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTestHelpersGenerator) EXPECT() *MockTestHelpersGeneratorMockRecorder {
+	return m.recorder
+}
+
+// GenerateTestHelpers mocks base method.
+func (m *MockTestHelpersGenerator) GenerateTestHelpers(spec *domain.Specification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateTestHelpers", spec)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GenerateTestHelpers indicates an expected call of GenerateTestHelpers.
+func (mr *MockTestHelpersGeneratorMockRecorder) GenerateTestHelpers(spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateTestHelpers", reflect.TypeOf((*MockTestHelpersGenerator)(nil).GenerateTestHelpers), spec)
+}
+
+// MockMiddlewareGenerator is a mock of MiddlewareGenerator interface.
+type MockMiddlewareGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MockMiddlewareGeneratorMockRecorder
+	isgomock struct{}
+}
+
+// MockMiddlewareGeneratorMockRecorder is the mock recorder for MockMiddlewareGenerator.
+type MockMiddlewareGeneratorMockRecorder struct {
+	mock *MockMiddlewareGenerator
+}
+
+// NewMockMiddlewareGenerator creates a new mock instance.
+func NewMockMiddlewareGenerator(ctrl *gomock.Controller) *MockMiddlewareGenerator {
+	mock := &MockMiddlewareGenerator{ctrl: ctrl}
+	mock.recorder = &MockMiddlewareGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMiddlewareGenerator) EXPECT() *MockMiddlewareGeneratorMockRecorder {
+	return m.recorder
+}
+
+// GenerateMiddleware mocks base method.
+func (m *MockMiddlewareGenerator) GenerateMiddleware(spec *domain.Specification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateMiddleware", spec)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GenerateMiddleware indicates an expected call of GenerateMiddleware.
+func (mr *MockMiddlewareGeneratorMockRecorder) GenerateMiddleware(spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateMiddleware", reflect.TypeOf((*MockMiddlewareGenerator)(nil).GenerateMiddleware), spec)
+}
+
+// MockGRPCGenerator is a mock of GRPCGenerator interface.
+type MockGRPCGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MockGRPCGeneratorMockRecorder
+	isgomock struct{}
+}
+
+// MockGRPCGeneratorMockRecorder is the mock recorder for MockGRPCGenerator.
+type MockGRPCGeneratorMockRecorder struct {
+	mock *MockGRPCGenerator
+}
+
+// NewMockGRPCGenerator creates a new mock instance.
+func NewMockGRPCGenerator(ctrl *gomock.Controller) *MockGRPCGenerator {
+	mock := &MockGRPCGenerator{ctrl: ctrl}
+	mock.recorder = &MockGRPCGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGRPCGenerator) EXPECT() *MockGRPCGeneratorMockRecorder {
+	return m.recorder
+}
+
+// GenerateGRPC mocks base method.
+func (m *MockGRPCGenerator) GenerateGRPC(spec *domain.Specification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateGRPC", spec)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GenerateGRPC indicates an expected call of GenerateGRPC.
+func (mr *MockGRPCGeneratorMockRecorder) GenerateGRPC(spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateGRPC", reflect.TypeOf((*MockGRPCGenerator)(nil).GenerateGRPC), spec)
+}
@@ -18,22 +18,50 @@ var goReservedKeywords = map[string]bool{
 // TemplateData contains all data for template generation
 type TemplateData struct {
 	PackageName     string
+	ImportPath      string // Go import path of the generated package (used by the metricstest package)
 	Info            domain.Info
 	Namespaces      []Namespace
+	Middlewares     []HTTPMiddleware   // HTTP middlewares built from golden signals (used by the middleware templates)
+	GRPC            []GRPCInterceptors // gRPC interceptors built from metric roles (used by the grpc template)
 	NeedsOsImport   bool
 	NeedsHelperFunc bool
+	ConfigFields    []ConfigField     // environment variables read by the const labels, fields of the generated config
+	HasValidations  bool              // some label is validated
+	NeedsCEL        bool              // some label validation is evaluated with CEL at runtime
+	NeedsRegexp     bool              // some label validation is a regexp match
+	NeedsStrings    bool              // some label validation is a prefix check
+	NeedsUTF8       bool              // some label validation is a size check
+	NeedsCache      bool              // some label validator caches the values it accepted
+	MetricUnits     map[string]string // unit of the metrics declaring one, by full name (OpenMetrics # UNIT)
+}
+
+// ConfigField is an environment variable read by const labels, generated as a field of the
+// config holding the const label values
+type ConfigField struct {
+	EnvVar    string
+	FieldName string   // CamelCase name of the field (e.g. AwsRegion for AWS_REGION)
+	Required  bool     // used without a default by at least one const label
+	Defaults  []string // distinct defaults of the const labels giving one
+}
+
+// MetricOperation is a method recording a metric value (e.g. Inc, or Observe with a value)
+type MetricOperation struct {
+	Name      string
+	WithValue bool
 }
 
 // Namespace represents a metric namespace
 type Namespace struct {
-	Name       string
-	Subsystems []Subsystem
+	Name           string
+	Subsystems     []Subsystem
+	RequiredConfig []ConfigField // config fields read without a default by the const labels of the namespace
 }
 
 // Subsystem represents a metric subsystem
 type Subsystem struct {
-	Name    string
-	Metrics []MetricData
+	Name       string
+	Metrics    []MetricData
+	UsesConfig bool // some const label of the metrics reads an environment variable
 }
 
 // MetricData contains all information needed to generate a metric
@@ -66,7 +94,82 @@ type MetricData struct {
 	Constructor          string
 	HasLabels            bool   // true if the metric has labels (uses Vec types)
 	SimpleType           string // The simple type without Vec (Counter, Gauge, etc.)
+	Operations           []MetricOperation
+	CurriedParams        string                   // Go parameters of the labels bound by <Metric>For (all but the last)
+	CurriedArgs          string                   // Go arguments of the labels bound by <Metric>For (all but the last)
+	CurriedLabels        []domain.LabelDefinition // labels bound by <Metric>For when the metric has several labels
+	LastLabel            domain.LabelDefinition   // label left unbound by <Metric>For
+	LastParam            string                   // Go parameter name of LastLabel
+	CurriedVecType       string                   // Go type returned by CurryWith (e.g. *prometheus.CounterVec)
+	BoundType            string                   // Go type of a fully bound child (e.g. prometheus.Counter)
 	Deprecated           *domain.Deprecated
+	HasTimer             bool             // unit is seconds: generate Time<Metric> helpers
+	HasInFlight          bool             // in_flight gauge: generate Track<Metric>InFlight helpers
+	InFlightName         string           // <Metric>InFlight, without repeating an InFlight suffix of the metric name
+	HasCurrentTime       bool             // timestamp gauge: generate SetToCurrentTime<Metric> helpers
+	Validators           []LabelValidator // Go validator functions of the validated labels
+	Metadata             []string         // unit, stability, owner, since and SLO lines of the doc comments
+}
+
+// LabelValidator is a generated Go function checking the values of a metric label
+type LabelValidator struct {
+	FuncName  string
+	Label     string
+	Param     string // Go parameter name of the label in the metric methods
+	Metric    string // full name of the metric
+	Checks    []ValidationCheck
+	CacheSize int // size of the LRU cache of accepted values, 0 when not cached
+}
+
+// ValidationCheck is a label validation expression, compiled to native Go when possible
+type ValidationCheck struct {
+	Expression string
+	Kind       ValidationKind
+	Values     []string // allowed values of an in check
+	Operand    string   // prefix of a startsWith check, pattern of a matches check, Go operator rejecting a size check
+	Limit      int64    // size compared by a size check
+	VarName    string   // package variable holding the compiled regexp or CEL program
+}
+
+// HTTPMiddleware describes the HTTP middleware generated for a golden signals topic
+type HTTPMiddleware struct {
+	Name            string // CamelCase name derived from the topic (e.g. HttpServer for "http/server")
+	Topic           string
+	Traffic         []MiddlewareMetric // counters incremented for every request
+	Errors          []MiddlewareMetric // counters incremented for 5xx responses (metrics already in Traffic are skipped)
+	Latency         []MiddlewareMetric // histograms or summaries observing the request duration in seconds
+	InFlight        []MiddlewareMetric // gauges tracking the requests being processed
+	UsesRoute       bool
+	UsesStatusCode  bool
+	UsesStatusClass bool
+}
+
+// GRPCInterceptors describes the gRPC interceptors generated for one side of a call
+type GRPCInterceptors struct {
+	Side            string             // "Server" or "Client"
+	Started         []MiddlewareMetric // counters incremented when a call starts
+	Handled         []MiddlewareMetric // counters incremented when a call completes
+	HandlingSeconds []MiddlewareMetric // histograms or summaries observing the call duration in seconds
+	InFlight        []MiddlewareMetric // gauges tracking the calls being processed
+	MsgReceived     []MiddlewareMetric // counters incremented for every message received
+	MsgSent         []MiddlewareMetric // counters incremented for every message sent
+	UsesCode        bool
+}
+
+// MiddlewareMetric is a metric fed by an HTTP middleware or a gRPC interceptor
+type MiddlewareMetric struct {
+	Namespace  string // CamelCase namespace
+	Subsystem  string // CamelCase subsystem
+	MethodName string
+	FieldName  string            // field of the collector in the <Namespace><Subsystem>MetricsImpl struct
+	Labels     []MiddlewareLabel // non-inherited labels in method parameter order
+}
+
+// MiddlewareLabel maps a metric label to the request attribute filling it
+type MiddlewareLabel struct {
+	Name      string
+	Source    string // one of the domain.HTTPSource* constants, or a grpcLabelSources value
+	Validator string // Go function validating the label, empty when the label has no validations
 }
 
 // toCamelCase converts a snake_case string to CamelCase
@@ -80,6 +183,11 @@ func toCamelCase(s string) string {
 	return strings.Join(words, "")
 }
 
+// configFieldName returns the CamelCase field name of an environment variable (e.g. AwsRegion for AWS_REGION)
+func configFieldName(envVar string) string {
+	return toCamelCase(strings.ToLower(envVar))
+}
+
 // toLowerCamelCase converts a snake_case string to camelCase
 func toLowerCamelCase(s string) string {
 	words := strings.Split(s, "_")
@@ -100,4 +208,3 @@ func escapeGoKeyword(s string) string {
 	}
 	return s
 }
-
@@ -0,0 +1,125 @@
+package metrics
+
+// Benchmarks of the code generated from testdata/benchmark.cue.
+// They are copied next to the generated metrics.go by TestGeneratedCodeBenchmarks.
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func newBenchRegistry(b *testing.B) *MetricsRegistry {
+	b.Helper()
+	registry, err := NewRegistry(prometheus.NewRegistry())
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	return registry
+}
+
+func BenchmarkCounter(b *testing.B) {
+	m := newBenchRegistry(b).Bench.Counter
+	for b.Loop() {
+		m.IncEventsTotal()
+	}
+}
+
+func BenchmarkCounterLabels(b *testing.B) {
+	m := newBenchRegistry(b).Bench.Counter
+	for b.Loop() {
+		m.IncLabeledEventsTotal("GET", "200")
+	}
+}
+
+func BenchmarkCounterValidatedLabels(b *testing.B) {
+	m := newBenchRegistry(b).Bench.Counter
+	for b.Loop() {
+		m.IncValidatedEventsTotal("GET", "/api/orders/{id}", "200")
+	}
+}
+
+func BenchmarkCounterCELValidatedLabel(b *testing.B) {
+	m := newBenchRegistry(b).Bench.Counter
+	for b.Loop() {
+		m.IncTenantEventsTotal("acme")
+	}
+}
+
+func BenchmarkCounterCurried(b *testing.B) {
+	requests := newBenchRegistry(b).Bench.Counter.ValidatedEventsTotalFor("GET", "/api/orders/{id}")
+	for b.Loop() {
+		requests.Inc("200")
+	}
+}
+
+func BenchmarkCounterBound(b *testing.B) {
+	ok := newBenchRegistry(b).Bench.Counter.ValidatedEventsTotalFor("GET", "/api/orders/{id}").For("200")
+	for b.Loop() {
+		ok.Inc()
+	}
+}
+
+func BenchmarkGauge(b *testing.B) {
+	m := newBenchRegistry(b).Bench.Gauge
+	for b.Loop() {
+		m.SetQueueDepth(42)
+	}
+}
+
+func BenchmarkGaugeLabels(b *testing.B) {
+	m := newBenchRegistry(b).Bench.Gauge
+	for b.Loop() {
+		m.SetLabeledQueueDepth("GET", "200", 42)
+	}
+}
+
+func BenchmarkGaugeValidatedLabels(b *testing.B) {
+	m := newBenchRegistry(b).Bench.Gauge
+	for b.Loop() {
+		m.SetValidatedQueueDepth("GET", "/api/orders/{id}", "200", 42)
+	}
+}
+
+func BenchmarkHistogram(b *testing.B) {
+	m := newBenchRegistry(b).Bench.Histogram
+	for b.Loop() {
+		m.ObserveDurationSeconds(0.042)
+	}
+}
+
+func BenchmarkHistogramLabels(b *testing.B) {
+	m := newBenchRegistry(b).Bench.Histogram
+	for b.Loop() {
+		m.ObserveLabeledDurationSeconds("GET", "200", 0.042)
+	}
+}
+
+func BenchmarkHistogramValidatedLabels(b *testing.B) {
+	m := newBenchRegistry(b).Bench.Histogram
+	for b.Loop() {
+		m.ObserveValidatedDurationSeconds("GET", "/api/orders/{id}", "200", 0.042)
+	}
+}
+
+func BenchmarkSummary(b *testing.B) {
+	m := newBenchRegistry(b).Bench.Summary
+	for b.Loop() {
+		m.ObserveSizeBytes(512)
+	}
+}
+
+func BenchmarkSummaryLabels(b *testing.B) {
+	m := newBenchRegistry(b).Bench.Summary
+	for b.Loop() {
+		m.ObserveLabeledSizeBytes("GET", "200", 512)
+	}
+}
+
+func BenchmarkSummaryValidatedLabels(b *testing.B) {
+	m := newBenchRegistry(b).Bench.Summary
+	for b.Loop() {
+		m.ObserveValidatedSizeBytes("GET", "/api/orders/{id}", "200", 512)
+	}
+}
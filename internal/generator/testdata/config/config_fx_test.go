@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/fx"
+)
+
+func startApp(t *testing.T, options ...fx.Option) error {
+	t.Helper()
+	app := fx.New(append(options, fx.NopLogger)...)
+	return app.Err()
+}
+
+func TestNamespaceModuleFailsWithMissingConfig(t *testing.T) {
+	err := startApp(t,
+		fx.Supply(&Config{Environment: "staging", Queue: "emails"}),
+		HttpModuleWithRegistry(prometheus.NewRegistry()),
+		fx.Invoke(func(*HttpMetrics) {}),
+	)
+	if err == nil || !strings.Contains(err.Error(), "missing required const label values: AWS_REGION") {
+		t.Fatalf("expected the missing AWS_REGION error, got %v", err)
+	}
+}
+
+func TestNamespaceModuleChecksOnlyItsConfig(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	err := startApp(t,
+		fx.Supply(&Config{Queue: "emails"}),
+		JobsModuleWithRegistry(registry),
+		fx.Invoke(func(m *JobsMetrics) { m.Worker.IncProcessedTotal() }),
+	)
+	if err != nil {
+		t.Fatalf("expected the Jobs module to start without AWS_REGION, got %v", err)
+	}
+	assertLabel(t, registry, "jobs_worker_processed_total", "queue", "emails")
+}
+
+func TestModuleFailsWithMissingConfig(t *testing.T) {
+	err := startApp(t,
+		fx.Supply(&Config{AwsRegion: "eu-west-1"}),
+		ModuleWithRegistry(prometheus.NewRegistry()),
+		fx.Invoke(func(*MetricsRegistry) {}),
+	)
+	if err == nil || !strings.Contains(err.Error(), "missing required const label values: QUEUE") {
+		t.Fatalf("expected the missing QUEUE error, got %v", err)
+	}
+}
+
+func TestNewMetricsWithConfig(t *testing.T) {
+	if _, err := NewHttpMetricsWithConfig(prometheus.NewRegistry(), Config{}); err == nil {
+		t.Fatal("expected an error without AWS_REGION")
+	}
+
+	registry := prometheus.NewRegistry()
+	m, err := NewHttpMetricsWithConfig(registry, Config{AwsRegion: "eu-west-1"})
+	if err != nil {
+		t.Fatalf("NewHttpMetricsWithConfig() error = %v", err)
+	}
+	m.Server.IncRequestsTotal()
+	assertLabel(t, registry, "http_server_requests_total", "region", "eu-west-1")
+	assertLabel(t, registry, "http_server_requests_total", "env", "production")
+}
+
+func assertLabel(t *testing.T, registry *prometheus.Registry, family, name, value string) {
+	t.Helper()
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	for _, f := range families {
+		if f.GetName() != family {
+			continue
+		}
+		for _, label := range f.GetMetric()[0].GetLabel() {
+			if label.GetName() == name && label.GetValue() == value {
+				return
+			}
+		}
+		t.Fatalf("%s has no label %s=%q", family, name, value)
+	}
+	t.Fatalf("%s was not gathered", family)
+}
@@ -0,0 +1,416 @@
+// <auto-generated>
+// This code was generated by Promener
+// Changes to this file may cause incorrect behavior and will be lost if the code is regenerated.
+// </auto-generated>
+
+using Prometheus;
+using System;
+
+namespace Golden.Metrics
+{
+    /// <summary>
+    /// Interface for Bench.Counter metrics
+    /// </summary>
+    public interface IBenchCounterMetrics
+    {
+        /// <summary>Increment events_total by 1</summary>
+        void IncEventsTotal();
+        /// <summary>Increment events_total by a specific value</summary>
+        void AddEventsTotal(double value);
+        /// <summary>Increment labeled_events_total by 1</summary>
+        void IncLabeledEventsTotal(string method, string status);
+        /// <summary>Increment labeled_events_total by a specific value</summary>
+        void AddLabeledEventsTotal(string method, string status, double value);
+        /// <summary>Increment tenant_events_total by 1</summary>
+        void IncTenantEventsTotal(string tenant);
+        /// <summary>Increment tenant_events_total by a specific value</summary>
+        void AddTenantEventsTotal(string tenant, double value);
+        /// <summary>Increment validated_events_total by 1</summary>
+        void IncValidatedEventsTotal(string method, string path, string status);
+        /// <summary>Increment validated_events_total by a specific value</summary>
+        void AddValidatedEventsTotal(string method, string path, string status, double value);
+    }
+    /// <summary>
+    /// Interface for Bench.Gauge metrics
+    /// </summary>
+    public interface IBenchGaugeMetrics
+    {
+        /// <summary>Set labeled_queue_depth to a specific value</summary>
+        void SetLabeledQueueDepth(string method, string status, double value);
+        /// <summary>Increment labeled_queue_depth by 1</summary>
+        void IncLabeledQueueDepth(string method, string status);
+        /// <summary>Decrement labeled_queue_depth by 1</summary>
+        void DecLabeledQueueDepth(string method, string status);
+        /// <summary>Add a value to labeled_queue_depth</summary>
+        void AddLabeledQueueDepth(string method, string status, double value);
+        /// <summary>Subtract a value from labeled_queue_depth</summary>
+        void SubLabeledQueueDepth(string method, string status, double value);
+        /// <summary>Set queue_depth to a specific value</summary>
+        void SetQueueDepth(double value);
+        /// <summary>Increment queue_depth by 1</summary>
+        void IncQueueDepth();
+        /// <summary>Decrement queue_depth by 1</summary>
+        void DecQueueDepth();
+        /// <summary>Add a value to queue_depth</summary>
+        void AddQueueDepth(double value);
+        /// <summary>Subtract a value from queue_depth</summary>
+        void SubQueueDepth(double value);
+        /// <summary>Set validated_queue_depth to a specific value</summary>
+        void SetValidatedQueueDepth(string method, string path, string status, double value);
+        /// <summary>Increment validated_queue_depth by 1</summary>
+        void IncValidatedQueueDepth(string method, string path, string status);
+        /// <summary>Decrement validated_queue_depth by 1</summary>
+        void DecValidatedQueueDepth(string method, string path, string status);
+        /// <summary>Add a value to validated_queue_depth</summary>
+        void AddValidatedQueueDepth(string method, string path, string status, double value);
+        /// <summary>Subtract a value from validated_queue_depth</summary>
+        void SubValidatedQueueDepth(string method, string path, string status, double value);
+    }
+    /// <summary>
+    /// Interface for Bench.Histogram metrics
+    /// </summary>
+    public interface IBenchHistogramMetrics
+    {
+        /// <summary>Observe a value for duration_seconds</summary>
+        void ObserveDurationSeconds(double value);
+        /// <summary>Observe a value for labeled_duration_seconds</summary>
+        void ObserveLabeledDurationSeconds(string method, string status, double value);
+        /// <summary>Observe a value for validated_duration_seconds</summary>
+        void ObserveValidatedDurationSeconds(string method, string path, string status, double value);
+    }
+    /// <summary>
+    /// Interface for Bench.Summary metrics
+    /// </summary>
+    public interface IBenchSummaryMetrics
+    {
+        /// <summary>Observe a value for labeled_size_bytes</summary>
+        void ObserveLabeledSizeBytes(string method, string status, double value);
+        /// <summary>Observe a value for size_bytes</summary>
+        void ObserveSizeBytes(double value);
+        /// <summary>Observe a value for validated_size_bytes</summary>
+        void ObserveValidatedSizeBytes(string method, string path, string status, double value);
+    }
+
+    /// <summary>
+    /// Implementation of Bench.Counter metrics
+    /// </summary>
+    public class BenchCounterMetricsImpl : IBenchCounterMetrics
+    {
+        private readonly Counter _eventsTotal;
+        private readonly Counter _labeledEventsTotal;
+        private readonly Counter _tenantEventsTotal;
+        private readonly Counter _validatedEventsTotal;
+
+        public BenchCounterMetricsImpl()
+        {
+            _eventsTotal = Prometheus.Metrics.CreateCounter(
+                "bench_counter_events_total",
+                "Counter without labels"
+            );
+            _labeledEventsTotal = Prometheus.Metrics.CreateCounter(
+                "bench_counter_labeled_events_total",
+                "Counter with labels",
+                new CounterConfiguration
+                {
+                    LabelNames = new[] {"method", "status"}
+                }
+            );
+            _tenantEventsTotal = Prometheus.Metrics.CreateCounter(
+                "bench_counter_tenant_events_total",
+                "Counter with a label validated by CEL",
+                new CounterConfiguration
+                {
+                    LabelNames = new[] {"tenant"}
+                }
+            );
+            _validatedEventsTotal = Prometheus.Metrics.CreateCounter(
+                "bench_counter_validated_events_total",
+                "Counter with validated labels",
+                new CounterConfiguration
+                {
+                    LabelNames = new[] {"method", "path", "status"}
+                }
+            );
+        }
+
+        public void IncEventsTotal()
+        {
+            _eventsTotal.Inc();
+        }
+
+        public void AddEventsTotal(double value)
+        {
+            _eventsTotal.Inc(value);
+        }
+
+        public void IncLabeledEventsTotal(string method, string status)
+        {
+            _labeledEventsTotal.WithLabels(method, status).Inc();
+        }
+
+        public void AddLabeledEventsTotal(string method, string status, double value)
+        {
+            _labeledEventsTotal.WithLabels(method, status).Inc(value);
+        }
+
+        public void IncTenantEventsTotal(string tenant)
+        {
+            _tenantEventsTotal.WithLabels(tenant).Inc();
+        }
+
+        public void AddTenantEventsTotal(string tenant, double value)
+        {
+            _tenantEventsTotal.WithLabels(tenant).Inc(value);
+        }
+
+        public void IncValidatedEventsTotal(string method, string path, string status)
+        {
+            _validatedEventsTotal.WithLabels(method, path, status).Inc();
+        }
+
+        public void AddValidatedEventsTotal(string method, string path, string status, double value)
+        {
+            _validatedEventsTotal.WithLabels(method, path, status).Inc(value);
+        }
+    }
+
+    /// <summary>
+    /// Implementation of Bench.Gauge metrics
+    /// </summary>
+    public class BenchGaugeMetricsImpl : IBenchGaugeMetrics
+    {
+        private readonly Gauge _labeledQueueDepth;
+        private readonly Gauge _queueDepth;
+        private readonly Gauge _validatedQueueDepth;
+
+        public BenchGaugeMetricsImpl()
+        {
+            _labeledQueueDepth = Prometheus.Metrics.CreateGauge(
+                "bench_gauge_labeled_queue_depth",
+                "Gauge with labels",
+                new GaugeConfiguration
+                {
+                    LabelNames = new[] {"method", "status"}
+                }
+            );
+            _queueDepth = Prometheus.Metrics.CreateGauge(
+                "bench_gauge_queue_depth",
+                "Gauge without labels"
+            );
+            _validatedQueueDepth = Prometheus.Metrics.CreateGauge(
+                "bench_gauge_validated_queue_depth",
+                "Gauge with validated labels",
+                new GaugeConfiguration
+                {
+                    LabelNames = new[] {"method", "path", "status"}
+                }
+            );
+        }
+
+        public void SetLabeledQueueDepth(string method, string status, double value)
+        {
+            _labeledQueueDepth.WithLabels(method, status).Set(value);
+        }
+
+        public void IncLabeledQueueDepth(string method, string status)
+        {
+            _labeledQueueDepth.WithLabels(method, status).Inc();
+        }
+
+        public void DecLabeledQueueDepth(string method, string status)
+        {
+            _labeledQueueDepth.WithLabels(method, status).Dec();
+        }
+
+        public void AddLabeledQueueDepth(string method, string status, double value)
+        {
+            _labeledQueueDepth.WithLabels(method, status).Inc(value);
+        }
+
+        public void SubLabeledQueueDepth(string method, string status, double value)
+        {
+            _labeledQueueDepth.WithLabels(method, status).Dec(value);
+        }
+
+        public void SetQueueDepth(double value)
+        {
+            _queueDepth.Set(value);
+        }
+
+        public void IncQueueDepth()
+        {
+            _queueDepth.Inc();
+        }
+
+        public void DecQueueDepth()
+        {
+            _queueDepth.Dec();
+        }
+
+        public void AddQueueDepth(double value)
+        {
+            _queueDepth.Inc(value);
+        }
+
+        public void SubQueueDepth(double value)
+        {
+            _queueDepth.Dec(value);
+        }
+
+        public void SetValidatedQueueDepth(string method, string path, string status, double value)
+        {
+            _validatedQueueDepth.WithLabels(method, path, status).Set(value);
+        }
+
+        public void IncValidatedQueueDepth(string method, string path, string status)
+        {
+            _validatedQueueDepth.WithLabels(method, path, status).Inc();
+        }
+
+        public void DecValidatedQueueDepth(string method, string path, string status)
+        {
+            _validatedQueueDepth.WithLabels(method, path, status).Dec();
+        }
+
+        public void AddValidatedQueueDepth(string method, string path, string status, double value)
+        {
+            _validatedQueueDepth.WithLabels(method, path, status).Inc(value);
+        }
+
+        public void SubValidatedQueueDepth(string method, string path, string status, double value)
+        {
+            _validatedQueueDepth.WithLabels(method, path, status).Dec(value);
+        }
+    }
+
+    /// <summary>
+    /// Implementation of Bench.Histogram metrics
+    /// </summary>
+    public class BenchHistogramMetricsImpl : IBenchHistogramMetrics
+    {
+        private readonly Histogram _durationSeconds;
+        private readonly Histogram _labeledDurationSeconds;
+        private readonly Histogram _validatedDurationSeconds;
+
+        public BenchHistogramMetricsImpl()
+        {
+            _durationSeconds = Prometheus.Metrics.CreateHistogram(
+                "bench_histogram_duration_seconds",
+                "Histogram without labels",
+                new HistogramConfiguration
+                {
+                    Buckets = new[] {0.005d, 0.01d, 0.025d, 0.05d, 0.1d, 0.25d, 0.5d, 1d, 2.5d, 5d, 10d}
+                }
+            );
+            _labeledDurationSeconds = Prometheus.Metrics.CreateHistogram(
+                "bench_histogram_labeled_duration_seconds",
+                "Histogram with labels",
+                new HistogramConfiguration
+                {
+                    LabelNames = new[] {"method", "status"},
+                    Buckets = new[] {0.005d, 0.01d, 0.025d, 0.05d, 0.1d, 0.25d, 0.5d, 1d, 2.5d, 5d, 10d}
+                }
+            );
+            _validatedDurationSeconds = Prometheus.Metrics.CreateHistogram(
+                "bench_histogram_validated_duration_seconds",
+                "Histogram with validated labels",
+                new HistogramConfiguration
+                {
+                    LabelNames = new[] {"method", "path", "status"},
+                    Buckets = new[] {0.005d, 0.01d, 0.025d, 0.05d, 0.1d, 0.25d, 0.5d, 1d, 2.5d, 5d, 10d}
+                }
+            );
+        }
+
+        public void ObserveDurationSeconds(double value)
+        {
+            _durationSeconds.Observe(value);
+        }
+
+        public void ObserveLabeledDurationSeconds(string method, string status, double value)
+        {
+            _labeledDurationSeconds.WithLabels(method, status).Observe(value);
+        }
+
+        public void ObserveValidatedDurationSeconds(string method, string path, string status, double value)
+        {
+            _validatedDurationSeconds.WithLabels(method, path, status).Observe(value);
+        }
+    }
+
+    /// <summary>
+    /// Implementation of Bench.Summary metrics
+    /// </summary>
+    public class BenchSummaryMetricsImpl : IBenchSummaryMetrics
+    {
+        private readonly Summary _labeledSizeBytes;
+        private readonly Summary _sizeBytes;
+        private readonly Summary _validatedSizeBytes;
+
+        public BenchSummaryMetricsImpl()
+        {
+            _labeledSizeBytes = Prometheus.Metrics.CreateSummary(
+                "bench_summary_labeled_size_bytes",
+                "Summary with labels",
+                new SummaryConfiguration
+                {
+                    LabelNames = new[] {"method", "status"},
+                }
+            );
+            _sizeBytes = Prometheus.Metrics.CreateSummary(
+                "bench_summary_size_bytes",
+                "Summary without labels"
+            );
+            _validatedSizeBytes = Prometheus.Metrics.CreateSummary(
+                "bench_summary_validated_size_bytes",
+                "Summary with validated labels",
+                new SummaryConfiguration
+                {
+                    LabelNames = new[] {"method", "path", "status"},
+                }
+            );
+        }
+
+        public void ObserveLabeledSizeBytes(string method, string status, double value)
+        {
+            _labeledSizeBytes.WithLabels(method, status).Observe(value);
+        }
+
+        public void ObserveSizeBytes(double value)
+        {
+            _sizeBytes.Observe(value);
+        }
+
+        public void ObserveValidatedSizeBytes(string method, string path, string status, double value)
+        {
+            _validatedSizeBytes.WithLabels(method, path, status).Observe(value);
+        }
+    }
+
+    /// <summary>
+    /// Main metrics registry
+    /// </summary>
+    public class MetricsRegistry
+    {
+        public IBenchCounterMetrics BenchCounter { get; }
+        public IBenchGaugeMetrics BenchGauge { get; }
+        public IBenchHistogramMetrics BenchHistogram { get; }
+        public IBenchSummaryMetrics BenchSummary { get; }
+
+        private static readonly Lazy<MetricsRegistry> _instance =
+            new Lazy<MetricsRegistry>(() => new MetricsRegistry());
+
+        /// <summary>
+        /// Gets the default singleton instance
+        /// </summary>
+        public static MetricsRegistry Default => _instance.Value;
+
+        public MetricsRegistry()
+        {
+            BenchCounter = new BenchCounterMetricsImpl();
+            BenchGauge = new BenchGaugeMetricsImpl();
+            BenchHistogram = new BenchHistogramMetricsImpl();
+            BenchSummary = new BenchSummaryMetricsImpl();
+        }
+    }
+}
@@ -0,0 +1,38 @@
+// <auto-generated>
+// This code was generated by Promener
+// Changes to this file may cause incorrect behavior and will be lost if the code is regenerated.
+// </auto-generated>
+
+using Microsoft.Extensions.DependencyInjection;
+
+namespace Golden.Metrics
+{
+    /// <summary>
+    /// Extension methods for registering metrics in dependency injection container
+    /// </summary>
+    public static class MetricsServiceCollectionExtensions
+    {
+        /// <summary>
+        /// Adds metrics services to the dependency injection container
+        /// </summary>
+        public static IServiceCollection AddMetrics(this IServiceCollection services)
+        {
+            // Register the main registry as singleton
+            services.AddSingleton<MetricsRegistry>();
+            // Register Bench.Counter metrics
+            services.AddSingleton<IBenchCounterMetrics>(sp =>
+                sp.GetRequiredService<MetricsRegistry>().BenchCounter);
+            // Register Bench.Gauge metrics
+            services.AddSingleton<IBenchGaugeMetrics>(sp =>
+                sp.GetRequiredService<MetricsRegistry>().BenchGauge);
+            // Register Bench.Histogram metrics
+            services.AddSingleton<IBenchHistogramMetrics>(sp =>
+                sp.GetRequiredService<MetricsRegistry>().BenchHistogram);
+            // Register Bench.Summary metrics
+            services.AddSingleton<IBenchSummaryMetrics>(sp =>
+                sp.GetRequiredService<MetricsRegistry>().BenchSummary);
+
+            return services;
+        }
+    }
+}
@@ -0,0 +1,469 @@
+// This code was generated by Promener
+// Changes to this file may cause incorrect behavior and will be lost if the code is regenerated.
+
+import { Registry, Counter, Gauge, Histogram, Summary } from 'prom-client';
+
+/**
+ * Interface for Bench.Counter metrics
+ */
+export interface IBenchCounterMetrics {
+  /**
+   * Increment events_total by 1
+   */
+  incEventsTotal(): void;
+
+  /**
+   * Increment events_total by a specific value
+   */
+  addEventsTotal(value: number): void;
+  /**
+   * Increment labeled_events_total by 1
+   */
+  incLabeledEventsTotal(method: string, status: string): void;
+
+  /**
+   * Increment labeled_events_total by a specific value
+   */
+  addLabeledEventsTotal(method: string, status: string, value: number): void;
+  /**
+   * Increment tenant_events_total by 1
+   */
+  incTenantEventsTotal(tenant: string): void;
+
+  /**
+   * Increment tenant_events_total by a specific value
+   */
+  addTenantEventsTotal(tenant: string, value: number): void;
+  /**
+   * Increment validated_events_total by 1
+   */
+  incValidatedEventsTotal(method: string, path: string, status: string): void;
+
+  /**
+   * Increment validated_events_total by a specific value
+   */
+  addValidatedEventsTotal(method: string, path: string, status: string, value: number): void;
+}
+
+/**
+ * Implementation of Bench.Counter metrics
+ */
+export class BenchCounterMetricsImpl implements IBenchCounterMetrics {
+  private readonly _eventsTotal: Counter;
+  private readonly _labeledEventsTotal: Counter;
+  private readonly _tenantEventsTotal: Counter;
+  private readonly _validatedEventsTotal: Counter;
+
+  constructor(registry: Registry) {
+    this._eventsTotal = new Counter({
+      name: 'bench_counter_events_total',
+      help: 'Counter without labels',
+      registers: [registry],
+    });
+    this._labeledEventsTotal = new Counter({
+      name: 'bench_counter_labeled_events_total',
+      help: 'Counter with labels',
+      registers: [registry],
+      labelNames: ['method', 'status'
+      ],
+    });
+    this._tenantEventsTotal = new Counter({
+      name: 'bench_counter_tenant_events_total',
+      help: 'Counter with a label validated by CEL',
+      registers: [registry],
+      labelNames: ['tenant'
+      ],
+    });
+    this._validatedEventsTotal = new Counter({
+      name: 'bench_counter_validated_events_total',
+      help: 'Counter with validated labels',
+      registers: [registry],
+      labelNames: ['method', 'path', 'status'
+      ],
+    });
+  }
+
+  incEventsTotal(): void {
+    this._eventsTotal.inc();
+  }
+
+  addEventsTotal(value: number): void {
+    this._eventsTotal.inc(value);
+  }
+
+  incLabeledEventsTotal(method: string, status: string): void {
+    this._labeledEventsTotal.inc({method: method, status: status
+    });
+  }
+
+  addLabeledEventsTotal(method: string, status: string, value: number): void {
+    this._labeledEventsTotal.inc({method: method, status: status
+    }, value);
+  }
+
+  incTenantEventsTotal(tenant: string): void {
+    this._tenantEventsTotal.inc({tenant: tenant
+    });
+  }
+
+  addTenantEventsTotal(tenant: string, value: number): void {
+    this._tenantEventsTotal.inc({tenant: tenant
+    }, value);
+  }
+
+  incValidatedEventsTotal(method: string, path: string, status: string): void {
+    this._validatedEventsTotal.inc({method: method, path: path, status: status
+    });
+  }
+
+  addValidatedEventsTotal(method: string, path: string, status: string, value: number): void {
+    this._validatedEventsTotal.inc({method: method, path: path, status: status
+    }, value);
+  }
+}
+
+/**
+ * Interface for Bench.Gauge metrics
+ */
+export interface IBenchGaugeMetrics {
+  /**
+   * Set labeled_queue_depth to a specific value
+   */
+  setLabeledQueueDepth(method: string, status: string, value: number): void;
+
+  /**
+   * Increment labeled_queue_depth by 1
+   */
+  incLabeledQueueDepth(method: string, status: string): void;
+
+  /**
+   * Decrement labeled_queue_depth by 1
+   */
+  decLabeledQueueDepth(method: string, status: string): void;
+
+  /**
+   * Add a value to labeled_queue_depth
+   */
+  addLabeledQueueDepth(method: string, status: string, value: number): void;
+
+  /**
+   * Subtract a value from labeled_queue_depth
+   */
+  subLabeledQueueDepth(method: string, status: string, value: number): void;
+  /**
+   * Set queue_depth to a specific value
+   */
+  setQueueDepth(value: number): void;
+
+  /**
+   * Increment queue_depth by 1
+   */
+  incQueueDepth(): void;
+
+  /**
+   * Decrement queue_depth by 1
+   */
+  decQueueDepth(): void;
+
+  /**
+   * Add a value to queue_depth
+   */
+  addQueueDepth(value: number): void;
+
+  /**
+   * Subtract a value from queue_depth
+   */
+  subQueueDepth(value: number): void;
+  /**
+   * Set validated_queue_depth to a specific value
+   */
+  setValidatedQueueDepth(method: string, path: string, status: string, value: number): void;
+
+  /**
+   * Increment validated_queue_depth by 1
+   */
+  incValidatedQueueDepth(method: string, path: string, status: string): void;
+
+  /**
+   * Decrement validated_queue_depth by 1
+   */
+  decValidatedQueueDepth(method: string, path: string, status: string): void;
+
+  /**
+   * Add a value to validated_queue_depth
+   */
+  addValidatedQueueDepth(method: string, path: string, status: string, value: number): void;
+
+  /**
+   * Subtract a value from validated_queue_depth
+   */
+  subValidatedQueueDepth(method: string, path: string, status: string, value: number): void;
+}
+
+/**
+ * Implementation of Bench.Gauge metrics
+ */
+export class BenchGaugeMetricsImpl implements IBenchGaugeMetrics {
+  private readonly _labeledQueueDepth: Gauge;
+  private readonly _queueDepth: Gauge;
+  private readonly _validatedQueueDepth: Gauge;
+
+  constructor(registry: Registry) {
+    this._labeledQueueDepth = new Gauge({
+      name: 'bench_gauge_labeled_queue_depth',
+      help: 'Gauge with labels',
+      registers: [registry],
+      labelNames: ['method', 'status'
+      ],
+    });
+    this._queueDepth = new Gauge({
+      name: 'bench_gauge_queue_depth',
+      help: 'Gauge without labels',
+      registers: [registry],
+    });
+    this._validatedQueueDepth = new Gauge({
+      name: 'bench_gauge_validated_queue_depth',
+      help: 'Gauge with validated labels',
+      registers: [registry],
+      labelNames: ['method', 'path', 'status'
+      ],
+    });
+  }
+
+  setLabeledQueueDepth(method: string, status: string, value: number): void {
+    this._labeledQueueDepth.set({method: method, status: status
+    }, value);
+  }
+
+  incLabeledQueueDepth(method: string, status: string): void {
+    this._labeledQueueDepth.inc({method: method, status: status
+    });
+  }
+
+  decLabeledQueueDepth(method: string, status: string): void {
+    this._labeledQueueDepth.dec({method: method, status: status
+    });
+  }
+
+  addLabeledQueueDepth(method: string, status: string, value: number): void {
+    this._labeledQueueDepth.inc({method: method, status: status
+    }, value);
+  }
+
+  subLabeledQueueDepth(method: string, status: string, value: number): void {
+    this._labeledQueueDepth.dec({method: method, status: status
+    }, value);
+  }
+
+  setQueueDepth(value: number): void {
+    this._queueDepth.set(value);
+  }
+
+  incQueueDepth(): void {
+    this._queueDepth.inc();
+  }
+
+  decQueueDepth(): void {
+    this._queueDepth.dec();
+  }
+
+  addQueueDepth(value: number): void {
+    this._queueDepth.inc(value);
+  }
+
+  subQueueDepth(value: number): void {
+    this._queueDepth.dec(value);
+  }
+
+  setValidatedQueueDepth(method: string, path: string, status: string, value: number): void {
+    this._validatedQueueDepth.set({method: method, path: path, status: status
+    }, value);
+  }
+
+  incValidatedQueueDepth(method: string, path: string, status: string): void {
+    this._validatedQueueDepth.inc({method: method, path: path, status: status
+    });
+  }
+
+  decValidatedQueueDepth(method: string, path: string, status: string): void {
+    this._validatedQueueDepth.dec({method: method, path: path, status: status
+    });
+  }
+
+  addValidatedQueueDepth(method: string, path: string, status: string, value: number): void {
+    this._validatedQueueDepth.inc({method: method, path: path, status: status
+    }, value);
+  }
+
+  subValidatedQueueDepth(method: string, path: string, status: string, value: number): void {
+    this._validatedQueueDepth.dec({method: method, path: path, status: status
+    }, value);
+  }
+}
+
+/**
+ * Interface for Bench.Histogram metrics
+ */
+export interface IBenchHistogramMetrics {
+  /**
+   * Observe a value for duration_seconds
+   */
+  observeDurationSeconds(value: number): void;
+  /**
+   * Observe a value for labeled_duration_seconds
+   */
+  observeLabeledDurationSeconds(method: string, status: string, value: number): void;
+  /**
+   * Observe a value for validated_duration_seconds
+   */
+  observeValidatedDurationSeconds(method: string, path: string, status: string, value: number): void;
+}
+
+/**
+ * Implementation of Bench.Histogram metrics
+ */
+export class BenchHistogramMetricsImpl implements IBenchHistogramMetrics {
+  private readonly _durationSeconds: Histogram;
+  private readonly _labeledDurationSeconds: Histogram;
+  private readonly _validatedDurationSeconds: Histogram;
+
+  constructor(registry: Registry) {
+    this._durationSeconds = new Histogram({
+      name: 'bench_histogram_duration_seconds',
+      help: 'Histogram without labels',
+      registers: [registry],
+      buckets: [0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10],
+    });
+    this._labeledDurationSeconds = new Histogram({
+      name: 'bench_histogram_labeled_duration_seconds',
+      help: 'Histogram with labels',
+      registers: [registry],
+      labelNames: ['method', 'status'
+      ],
+      buckets: [0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10],
+    });
+    this._validatedDurationSeconds = new Histogram({
+      name: 'bench_histogram_validated_duration_seconds',
+      help: 'Histogram with validated labels',
+      registers: [registry],
+      labelNames: ['method', 'path', 'status'
+      ],
+      buckets: [0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10],
+    });
+  }
+
+  observeDurationSeconds(value: number): void {
+    this._durationSeconds.observe(value);
+  }
+
+  observeLabeledDurationSeconds(method: string, status: string, value: number): void {
+    this._labeledDurationSeconds.observe({method: method, status: status
+    }, value);
+  }
+
+  observeValidatedDurationSeconds(method: string, path: string, status: string, value: number): void {
+    this._validatedDurationSeconds.observe({method: method, path: path, status: status
+    }, value);
+  }
+}
+
+/**
+ * Interface for Bench.Summary metrics
+ */
+export interface IBenchSummaryMetrics {
+  /**
+   * Observe a value for labeled_size_bytes
+   */
+  observeLabeledSizeBytes(method: string, status: string, value: number): void;
+  /**
+   * Observe a value for size_bytes
+   */
+  observeSizeBytes(value: number): void;
+  /**
+   * Observe a value for validated_size_bytes
+   */
+  observeValidatedSizeBytes(method: string, path: string, status: string, value: number): void;
+}
+
+/**
+ * Implementation of Bench.Summary metrics
+ */
+export class BenchSummaryMetricsImpl implements IBenchSummaryMetrics {
+  private readonly _labeledSizeBytes: Summary;
+  private readonly _sizeBytes: Summary;
+  private readonly _validatedSizeBytes: Summary;
+
+  constructor(registry: Registry) {
+    this._labeledSizeBytes = new Summary({
+      name: 'bench_summary_labeled_size_bytes',
+      help: 'Summary with labels',
+      registers: [registry],
+      labelNames: ['method', 'status'
+      ],
+    });
+    this._sizeBytes = new Summary({
+      name: 'bench_summary_size_bytes',
+      help: 'Summary without labels',
+      registers: [registry],
+    });
+    this._validatedSizeBytes = new Summary({
+      name: 'bench_summary_validated_size_bytes',
+      help: 'Summary with validated labels',
+      registers: [registry],
+      labelNames: ['method', 'path', 'status'
+      ],
+    });
+  }
+
+  observeLabeledSizeBytes(method: string, status: string, value: number): void {
+    this._labeledSizeBytes.observe({method: method, status: status
+    }, value);
+  }
+
+  observeSizeBytes(value: number): void {
+    this._sizeBytes.observe(value);
+  }
+
+  observeValidatedSizeBytes(method: string, path: string, status: string, value: number): void {
+    this._validatedSizeBytes.observe({method: method, path: path, status: status
+    }, value);
+  }
+}
+
+/**
+ * Main metrics registry
+ */
+export class MetricsRegistry {
+  public readonly registry: Registry;
+  public readonly benchCounter: IBenchCounterMetrics;
+  public readonly benchGauge: IBenchGaugeMetrics;
+  public readonly benchHistogram: IBenchHistogramMetrics;
+  public readonly benchSummary: IBenchSummaryMetrics;
+
+  private static _instance: MetricsRegistry | null = null;
+
+  /**
+   * Gets the default singleton instance
+   */
+  static get default(): MetricsRegistry {
+    if (!MetricsRegistry._instance) {
+      MetricsRegistry._instance = new MetricsRegistry();
+    }
+    return MetricsRegistry._instance;
+  }
+
+  constructor(registry?: Registry) {
+    this.registry = registry || new Registry();
+    this.benchCounter = new BenchCounterMetricsImpl(this.registry);
+    this.benchGauge = new BenchGaugeMetricsImpl(this.registry);
+    this.benchHistogram = new BenchHistogramMetricsImpl(this.registry);
+    this.benchSummary = new BenchSummaryMetricsImpl(this.registry);
+  }
+
+  /**
+   * Get metrics in Prometheus format
+   */
+  async getMetrics(): Promise<string> {
+    return this.registry.metrics();
+  }
+}
@@ -0,0 +1,994 @@
+// <auto-generated>
+// This code was generated by Promener
+// Changes to this file may cause incorrect behavior and will be lost if the code is regenerated.
+// </auto-generated>
+
+using Prometheus;
+using System;
+using System.Collections.Generic;
+
+namespace Golden.Metrics
+{
+    /// <summary>
+    /// Values of the environment variables read by the const labels
+    /// </summary>
+    public class MetricsConfig
+    {
+        /// <summary>Value of ENVIRONMENT, defaults to "production" when null</summary>
+        public string Environment { get; set; }
+
+        /// <summary>
+        /// Reads the config from the environment variables
+        /// </summary>
+        public static MetricsConfig FromEnvironment() =>
+            FromProvider(global::System.Environment.GetEnvironmentVariable);
+
+        /// <summary>
+        /// Reads the config from values keyed by environment variable name
+        /// </summary>
+        public static MetricsConfig FromDictionary(IReadOnlyDictionary<string, string> values) =>
+            FromProvider(name => values.TryGetValue(name, out var value) ? value : null);
+
+        /// <summary>
+        /// Reads the config from a provider returning the value of an environment variable name, or null
+        /// </summary>
+        public static MetricsConfig FromProvider(Func<string, string> provider) => new MetricsConfig
+        {
+            Environment = provider("ENVIRONMENT"),
+        };
+
+        /// <summary>
+        /// Throws an InvalidOperationException listing all the required values that are null or empty
+        /// </summary>
+        public void Validate()
+        {
+            var missing = new List<string>();
+            if (missing.Count > 0)
+            {
+                throw new InvalidOperationException($"Missing required const label values: {string.Join(", ", missing)}");
+            }
+        }
+    }
+    /// <summary>
+    /// Interface for Business.Orders metrics
+    /// </summary>
+    public interface IBusinessOrdersMetrics
+    {
+        /// <summary>Increment created_total by 1</summary>
+        void IncCreatedTotal(string channel, string paymentMethod, string status);
+        /// <summary>Increment created_total by a specific value</summary>
+        void AddCreatedTotal(string channel, string paymentMethod, string status, double value);
+        /// <summary>Set last_processed_timestamp_seconds to a specific value</summary>
+        /// <remarks>
+        /// <para>Unit: seconds</para>
+        /// </remarks>
+        void SetLastProcessedTimestampSeconds(double value);
+        /// <summary>Increment last_processed_timestamp_seconds by 1</summary>
+        void IncLastProcessedTimestampSeconds();
+        /// <summary>Decrement last_processed_timestamp_seconds by 1</summary>
+        void DecLastProcessedTimestampSeconds();
+        /// <summary>Add a value to last_processed_timestamp_seconds</summary>
+        void AddLastProcessedTimestampSeconds(double value);
+        /// <summary>Subtract a value from last_processed_timestamp_seconds</summary>
+        void SubLastProcessedTimestampSeconds(double value);
+        /// <summary>Set last_processed_timestamp_seconds to the current Unix time in seconds</summary>
+        void SetToCurrentTimeLastProcessedTimestampSeconds();
+        /// <summary>Observe a value for processing_duration_seconds</summary>
+        /// <remarks>
+        /// <para>Unit: seconds</para>
+        /// </remarks>
+        void ObserveProcessingDurationSeconds(string paymentMethod, double value);
+        /// <summary>Start timing processing_duration_seconds, the elapsed seconds are recorded when the returned timer is disposed</summary>
+        IDisposable TimeProcessingDurationSeconds(string paymentMethod);
+        /// <summary>Increment value_total by 1</summary>
+        void IncValueTotal(string currency, string status);
+        /// <summary>Increment value_total by a specific value</summary>
+        void AddValueTotal(string currency, string status, double value);
+    }
+    /// <summary>
+    /// Interface for Cache.Redis metrics
+    /// </summary>
+    public interface ICacheRedisMetrics
+    {
+        /// <summary>Set keys to a specific value</summary>
+        void SetKeys(double value);
+        /// <summary>Increment keys by 1</summary>
+        void IncKeys();
+        /// <summary>Decrement keys by 1</summary>
+        void DecKeys();
+        /// <summary>Add a value to keys</summary>
+        void AddKeys(double value);
+        /// <summary>Subtract a value from keys</summary>
+        void SubKeys(double value);
+        /// <summary>Set cache_memory_bytes to a specific value</summary>
+        void SetCacheMemoryBytes(double value);
+        /// <summary>Increment cache_memory_bytes by 1</summary>
+        void IncCacheMemoryBytes();
+        /// <summary>Decrement cache_memory_bytes by 1</summary>
+        void DecCacheMemoryBytes();
+        /// <summary>Add a value to cache_memory_bytes</summary>
+        void AddCacheMemoryBytes(double value);
+        /// <summary>Subtract a value from cache_memory_bytes</summary>
+        void SubCacheMemoryBytes(double value);
+        /// <summary>Observe a value for duration_seconds</summary>
+        void ObserveDurationSeconds(string operation, double value);
+        /// <summary>Increment cache_requests_total by 1</summary>
+        void IncCacheRequestsTotal(string operation, string status);
+        /// <summary>Increment cache_requests_total by a specific value</summary>
+        void AddCacheRequestsTotal(string operation, string status, double value);
+    }
+    /// <summary>
+    /// Interface for Db.Postgres metrics
+    /// </summary>
+    public interface IDbPostgresMetrics
+    {
+        /// <summary>Set db_connections_active to a specific value</summary>
+        void SetDbConnectionsActive(double value);
+        /// <summary>Increment db_connections_active by 1</summary>
+        void IncDbConnectionsActive();
+        /// <summary>Decrement db_connections_active by 1</summary>
+        void DecDbConnectionsActive();
+        /// <summary>Add a value to db_connections_active</summary>
+        void AddDbConnectionsActive(double value);
+        /// <summary>Subtract a value from db_connections_active</summary>
+        void SubDbConnectionsActive(double value);
+        /// <summary>Set db_connections_idle to a specific value</summary>
+        void SetDbConnectionsIdle(double value);
+        /// <summary>Increment db_connections_idle by 1</summary>
+        void IncDbConnectionsIdle();
+        /// <summary>Decrement db_connections_idle by 1</summary>
+        void DecDbConnectionsIdle();
+        /// <summary>Add a value to db_connections_idle</summary>
+        void AddDbConnectionsIdle(double value);
+        /// <summary>Subtract a value from db_connections_idle</summary>
+        void SubDbConnectionsIdle(double value);
+        /// <summary>Set db_connections_max to a specific value</summary>
+        void SetDbConnectionsMax(double value);
+        /// <summary>Increment db_connections_max by 1</summary>
+        void IncDbConnectionsMax();
+        /// <summary>Decrement db_connections_max by 1</summary>
+        void DecDbConnectionsMax();
+        /// <summary>Add a value to db_connections_max</summary>
+        void AddDbConnectionsMax(double value);
+        /// <summary>Subtract a value from db_connections_max</summary>
+        void SubDbConnectionsMax(double value);
+        /// <summary>Observe a value for db_connections_wait_seconds</summary>
+        void ObserveDbConnectionsWaitSeconds(double value);
+        /// <summary>Increment db_queries_total by 1</summary>
+        void IncDbQueriesTotal(string operation, string status, string table);
+        /// <summary>Increment db_queries_total by a specific value</summary>
+        void AddDbQueriesTotal(string operation, string status, string table, double value);
+        /// <summary>Observe a value for db_query_duration_seconds</summary>
+        /// <remarks>
+        /// <para>Unit: seconds</para>
+        /// </remarks>
+        void ObserveDbQueryDurationSeconds(string operation, string table, double value);
+        /// <summary>Start timing db_query_duration_seconds, the elapsed seconds are recorded when the returned timer is disposed</summary>
+        IDisposable TimeDbQueryDurationSeconds(string operation, string table);
+    }
+    /// <summary>
+    /// Interface for Grpc.Client metrics
+    /// </summary>
+    public interface IGrpcClientMetrics
+    {
+        /// <summary>Increment handled_total by 1</summary>
+        void IncHandledTotal(string grpcCode, string grpcMethod, string grpcService);
+        /// <summary>Increment handled_total by a specific value</summary>
+        void AddHandledTotal(string grpcCode, string grpcMethod, string grpcService, double value);
+        /// <summary>Increment msg_sent_total by 1</summary>
+        void IncMsgSentTotal(string grpcMethod);
+        /// <summary>Increment msg_sent_total by a specific value</summary>
+        void AddMsgSentTotal(string grpcMethod, double value);
+    }
+    /// <summary>
+    /// Interface for Grpc.Server metrics
+    /// </summary>
+    public interface IGrpcServerMetrics
+    {
+        /// <summary>Increment handled_total by 1</summary>
+        void IncHandledTotal(string grpcCode, string grpcMethod, string grpcService, string grpcType);
+        /// <summary>Increment handled_total by a specific value</summary>
+        void AddHandledTotal(string grpcCode, string grpcMethod, string grpcService, string grpcType, double value);
+        /// <summary>Observe a value for handling_seconds</summary>
+        /// <remarks>
+        /// <para>Unit: seconds</para>
+        /// </remarks>
+        void ObserveHandlingSeconds(string grpcMethod, string grpcService, string grpcType, double value);
+        /// <summary>Start timing handling_seconds, the elapsed seconds are recorded when the returned timer is disposed</summary>
+        IDisposable TimeHandlingSeconds(string grpcMethod, string grpcService, string grpcType);
+        /// <summary>Set in_flight to a specific value</summary>
+        void SetInFlight(string grpcService, double value);
+        /// <summary>Increment in_flight by 1</summary>
+        void IncInFlight(string grpcService);
+        /// <summary>Decrement in_flight by 1</summary>
+        void DecInFlight(string grpcService);
+        /// <summary>Add a value to in_flight</summary>
+        void AddInFlight(string grpcService, double value);
+        /// <summary>Subtract a value from in_flight</summary>
+        void SubInFlight(string grpcService, double value);
+        /// <summary>Increment in_flight, it is decremented when the returned tracker is disposed</summary>
+        IDisposable TrackInFlight(string grpcService);
+        /// <summary>Increment msg_received_total by 1</summary>
+        void IncMsgReceivedTotal(string grpcMethod, string grpcService, string grpcType);
+        /// <summary>Increment msg_received_total by a specific value</summary>
+        void AddMsgReceivedTotal(string grpcMethod, string grpcService, string grpcType, double value);
+        /// <summary>Increment started_total by 1</summary>
+        void IncStartedTotal(string grpcMethod, string grpcService, string grpcType);
+        /// <summary>Increment started_total by a specific value</summary>
+        void AddStartedTotal(string grpcMethod, string grpcService, string grpcType, double value);
+    }
+    /// <summary>
+    /// Interface for Http.Server metrics
+    /// </summary>
+    public interface IHttpServerMetrics
+    {
+        [Obsolete("Since 1.2.0. Use http_requests_total instead. Renamed for consistency with Prometheus naming conventions", error: false)]
+        /// <summary>Increment http_request_count_total by 1</summary>
+        void IncHttpRequestCountTotal(string code, string method);
+        [Obsolete("Since 1.2.0. Use http_requests_total instead. Renamed for consistency with Prometheus naming conventions", error: false)]
+        /// <summary>Increment http_request_count_total by a specific value</summary>
+        void AddHttpRequestCountTotal(string code, string method, double value);
+        /// <summary>Observe a value for http_request_duration_seconds</summary>
+        /// <remarks>
+        /// <para>Unit: seconds</para>
+        /// </remarks>
+        void ObserveHttpRequestDurationSeconds(string method, string path, string status, double value);
+        /// <summary>Start timing http_request_duration_seconds, the elapsed seconds are recorded when the returned timer is disposed</summary>
+        IDisposable TimeHttpRequestDurationSeconds(string method, string path, string status);
+        /// <summary>Observe a value for http_request_size_bytes</summary>
+        void ObserveHttpRequestSizeBytes(string method, string path, double value);
+        /// <summary>Set http_requests_in_flight to a specific value</summary>
+        void SetHttpRequestsInFlight(double value);
+        /// <summary>Increment http_requests_in_flight by 1</summary>
+        void IncHttpRequestsInFlight();
+        /// <summary>Decrement http_requests_in_flight by 1</summary>
+        void DecHttpRequestsInFlight();
+        /// <summary>Add a value to http_requests_in_flight</summary>
+        void AddHttpRequestsInFlight(double value);
+        /// <summary>Subtract a value from http_requests_in_flight</summary>
+        void SubHttpRequestsInFlight(double value);
+        /// <summary>Increment http_requests_in_flight, it is decremented when the returned tracker is disposed</summary>
+        IDisposable TrackHttpRequestsInFlight();
+        /// <summary>Increment http_requests_total by 1</summary>
+        void IncHttpRequestsTotal(string method, string path, string status);
+        /// <summary>Increment http_requests_total by a specific value</summary>
+        void AddHttpRequestsTotal(string method, string path, string status, double value);
+        /// <summary>Observe a value for http_response_size_bytes</summary>
+        void ObserveHttpResponseSizeBytes(string method, string path, string status, double value);
+    }
+
+    /// <summary>
+    /// Implementation of Business.Orders metrics
+    /// </summary>
+    public class BusinessOrdersMetricsImpl : IBusinessOrdersMetrics
+    {
+        private readonly Counter _createdTotal;
+        private readonly Gauge _lastProcessedTimestampSeconds;
+        private readonly Histogram _processingDurationSeconds;
+        private readonly Counter _valueTotal;
+
+        public BusinessOrdersMetricsImpl()
+        {
+            _createdTotal = Prometheus.Metrics.CreateCounter(
+                "business_orders_created_total",
+                "Total number of orders created",
+                new CounterConfiguration
+                {
+                    LabelNames = new[] {"channel", "payment_method", "status"}
+                }
+            );
+            _lastProcessedTimestampSeconds = Prometheus.Metrics.CreateGauge(
+                "business_orders_last_processed_timestamp_seconds",
+                "Unix time of the last processed order"
+            );
+            _processingDurationSeconds = Prometheus.Metrics.CreateHistogram(
+                "business_orders_processing_duration_seconds",
+                "Time taken to process an order from creation to completion",
+                new HistogramConfiguration
+                {
+                    LabelNames = new[] {"payment_method"},
+                    Buckets = new[] {0.1d, 0.5d, 1d, 2d, 5d, 10d, 30d, 60d, 120d, 300d}
+                }
+            );
+            _valueTotal = Prometheus.Metrics.CreateCounter(
+                "business_orders_value_total",
+                "Total monetary value of orders (in cents)",
+                new CounterConfiguration
+                {
+                    LabelNames = new[] {"currency", "status"}
+                }
+            );
+        }
+
+        public void IncCreatedTotal(string channel, string paymentMethod, string status)
+        {
+            _createdTotal.WithLabels(channel, paymentMethod, status).Inc();
+        }
+
+        public void AddCreatedTotal(string channel, string paymentMethod, string status, double value)
+        {
+            _createdTotal.WithLabels(channel, paymentMethod, status).Inc(value);
+        }
+
+        public void SetLastProcessedTimestampSeconds(double value)
+        {
+            _lastProcessedTimestampSeconds.Set(value);
+        }
+
+        public void IncLastProcessedTimestampSeconds()
+        {
+            _lastProcessedTimestampSeconds.Inc();
+        }
+
+        public void DecLastProcessedTimestampSeconds()
+        {
+            _lastProcessedTimestampSeconds.Dec();
+        }
+
+        public void AddLastProcessedTimestampSeconds(double value)
+        {
+            _lastProcessedTimestampSeconds.Inc(value);
+        }
+
+        public void SubLastProcessedTimestampSeconds(double value)
+        {
+            _lastProcessedTimestampSeconds.Dec(value);
+        }
+
+        public void SetToCurrentTimeLastProcessedTimestampSeconds()
+        {
+            _lastProcessedTimestampSeconds.SetToCurrentTimeUtc();
+        }
+
+        public void ObserveProcessingDurationSeconds(string paymentMethod, double value)
+        {
+            _processingDurationSeconds.WithLabels(paymentMethod).Observe(value);
+        }
+
+        public IDisposable TimeProcessingDurationSeconds(string paymentMethod)
+        {
+            return _processingDurationSeconds.WithLabels(paymentMethod).NewTimer();
+        }
+
+        public void IncValueTotal(string currency, string status)
+        {
+            _valueTotal.WithLabels(currency, status).Inc();
+        }
+
+        public void AddValueTotal(string currency, string status, double value)
+        {
+            _valueTotal.WithLabels(currency, status).Inc(value);
+        }
+    }
+
+    /// <summary>
+    /// Implementation of Cache.Redis metrics
+    /// </summary>
+    public class CacheRedisMetricsImpl : ICacheRedisMetrics
+    {
+        private readonly Gauge _keys;
+        private readonly Gauge _cacheMemoryBytes;
+        private readonly Histogram _durationSeconds;
+        private readonly Counter _cacheRequestsTotal;
+
+        public CacheRedisMetricsImpl()
+        {
+            _keys = Prometheus.Metrics.CreateGauge(
+                "cache_redis_keys",
+                "Total number of keys in the cache"
+            );
+            _cacheMemoryBytes = Prometheus.Metrics.CreateGauge(
+                "cache_redis_cache_memory_bytes",
+                "Memory used by the cache in bytes"
+            );
+            _durationSeconds = Prometheus.Metrics.CreateHistogram(
+                "cache_redis_duration_seconds",
+                "Cache operation duration in seconds",
+                new HistogramConfiguration
+                {
+                    LabelNames = new[] {"operation"},
+                    Buckets = new[] {1e-05d, 0.0001d, 0.0005d, 0.001d, 0.005d, 0.01d, 0.025d, 0.05d, 0.1d}
+                }
+            );
+            _cacheRequestsTotal = Prometheus.Metrics.CreateCounter(
+                "cache_redis_cache_requests_total",
+                "Total number of cache requests",
+                new CounterConfiguration
+                {
+                    LabelNames = new[] {"operation", "status"}
+                }
+            );
+        }
+
+        public void SetKeys(double value)
+        {
+            _keys.Set(value);
+        }
+
+        public void IncKeys()
+        {
+            _keys.Inc();
+        }
+
+        public void DecKeys()
+        {
+            _keys.Dec();
+        }
+
+        public void AddKeys(double value)
+        {
+            _keys.Inc(value);
+        }
+
+        public void SubKeys(double value)
+        {
+            _keys.Dec(value);
+        }
+
+        public void SetCacheMemoryBytes(double value)
+        {
+            _cacheMemoryBytes.Set(value);
+        }
+
+        public void IncCacheMemoryBytes()
+        {
+            _cacheMemoryBytes.Inc();
+        }
+
+        public void DecCacheMemoryBytes()
+        {
+            _cacheMemoryBytes.Dec();
+        }
+
+        public void AddCacheMemoryBytes(double value)
+        {
+            _cacheMemoryBytes.Inc(value);
+        }
+
+        public void SubCacheMemoryBytes(double value)
+        {
+            _cacheMemoryBytes.Dec(value);
+        }
+
+        public void ObserveDurationSeconds(string operation, double value)
+        {
+            _durationSeconds.WithLabels(operation).Observe(value);
+        }
+
+        public void IncCacheRequestsTotal(string operation, string status)
+        {
+            _cacheRequestsTotal.WithLabels(operation, status).Inc();
+        }
+
+        public void AddCacheRequestsTotal(string operation, string status, double value)
+        {
+            _cacheRequestsTotal.WithLabels(operation, status).Inc(value);
+        }
+    }
+
+    /// <summary>
+    /// Implementation of Db.Postgres metrics
+    /// </summary>
+    public class DbPostgresMetricsImpl : IDbPostgresMetrics
+    {
+        private readonly Gauge _dbConnectionsActive;
+        private readonly Gauge _dbConnectionsIdle;
+        private readonly Gauge _dbConnectionsMax;
+        private readonly Histogram _dbConnectionsWaitSeconds;
+        private readonly Counter _dbQueriesTotal;
+        private readonly Histogram _dbQueryDurationSeconds;
+
+        public DbPostgresMetricsImpl()
+        {
+            _dbConnectionsActive = Prometheus.Metrics.CreateGauge(
+                "db_postgres_db_connections_active",
+                "Number of active database connections in the pool"
+            );
+            _dbConnectionsIdle = Prometheus.Metrics.CreateGauge(
+                "db_postgres_db_connections_idle",
+                "Number of idle connections in the pool"
+            );
+            _dbConnectionsMax = Prometheus.Metrics.CreateGauge(
+                "db_postgres_db_connections_max",
+                "Maximum number of connections allowed in the pool"
+            );
+            _dbConnectionsWaitSeconds = Prometheus.Metrics.CreateHistogram(
+                "db_postgres_db_connections_wait_seconds",
+                "Time spent waiting for a database connection from the pool",
+                new HistogramConfiguration
+                {
+                    Buckets = new[] {0.0001d, 0.001d, 0.01d, 0.1d, 0.5d, 1d, 5d}
+                }
+            );
+            _dbQueriesTotal = Prometheus.Metrics.CreateCounter(
+                "db_postgres_db_queries_total",
+                "Total number of database queries executed",
+                new CounterConfiguration
+                {
+                    LabelNames = new[] {"operation", "status", "table"}
+                }
+            );
+            _dbQueryDurationSeconds = Prometheus.Metrics.CreateHistogram(
+                "db_postgres_db_query_duration_seconds",
+                "Database query duration in seconds",
+                new HistogramConfiguration
+                {
+                    LabelNames = new[] {"operation", "table"},
+                    Buckets = new[] {0.0001d, 0.0005d, 0.001d, 0.005d, 0.01d, 0.025d, 0.05d, 0.1d, 0.25d, 0.5d, 1d, 2.5d, 5d}
+                }
+            );
+        }
+
+        public void SetDbConnectionsActive(double value)
+        {
+            _dbConnectionsActive.Set(value);
+        }
+
+        public void IncDbConnectionsActive()
+        {
+            _dbConnectionsActive.Inc();
+        }
+
+        public void DecDbConnectionsActive()
+        {
+            _dbConnectionsActive.Dec();
+        }
+
+        public void AddDbConnectionsActive(double value)
+        {
+            _dbConnectionsActive.Inc(value);
+        }
+
+        public void SubDbConnectionsActive(double value)
+        {
+            _dbConnectionsActive.Dec(value);
+        }
+
+        public void SetDbConnectionsIdle(double value)
+        {
+            _dbConnectionsIdle.Set(value);
+        }
+
+        public void IncDbConnectionsIdle()
+        {
+            _dbConnectionsIdle.Inc();
+        }
+
+        public void DecDbConnectionsIdle()
+        {
+            _dbConnectionsIdle.Dec();
+        }
+
+        public void AddDbConnectionsIdle(double value)
+        {
+            _dbConnectionsIdle.Inc(value);
+        }
+
+        public void SubDbConnectionsIdle(double value)
+        {
+            _dbConnectionsIdle.Dec(value);
+        }
+
+        public void SetDbConnectionsMax(double value)
+        {
+            _dbConnectionsMax.Set(value);
+        }
+
+        public void IncDbConnectionsMax()
+        {
+            _dbConnectionsMax.Inc();
+        }
+
+        public void DecDbConnectionsMax()
+        {
+            _dbConnectionsMax.Dec();
+        }
+
+        public void AddDbConnectionsMax(double value)
+        {
+            _dbConnectionsMax.Inc(value);
+        }
+
+        public void SubDbConnectionsMax(double value)
+        {
+            _dbConnectionsMax.Dec(value);
+        }
+
+        public void ObserveDbConnectionsWaitSeconds(double value)
+        {
+            _dbConnectionsWaitSeconds.Observe(value);
+        }
+
+        public void IncDbQueriesTotal(string operation, string status, string table)
+        {
+            _dbQueriesTotal.WithLabels(operation, status, table).Inc();
+        }
+
+        public void AddDbQueriesTotal(string operation, string status, string table, double value)
+        {
+            _dbQueriesTotal.WithLabels(operation, status, table).Inc(value);
+        }
+
+        public void ObserveDbQueryDurationSeconds(string operation, string table, double value)
+        {
+            _dbQueryDurationSeconds.WithLabels(operation, table).Observe(value);
+        }
+
+        public IDisposable TimeDbQueryDurationSeconds(string operation, string table)
+        {
+            return _dbQueryDurationSeconds.WithLabels(operation, table).NewTimer();
+        }
+    }
+
+    /// <summary>
+    /// Implementation of Grpc.Client metrics
+    /// </summary>
+    public class GrpcClientMetricsImpl : IGrpcClientMetrics
+    {
+        private readonly Counter _handledTotal;
+        private readonly Counter _msgSentTotal;
+
+        public GrpcClientMetricsImpl()
+        {
+            _handledTotal = Prometheus.Metrics.CreateCounter(
+                "grpc_client_handled_total",
+                "Total number of RPCs completed by the client, regardless of success or failure",
+                new CounterConfiguration
+                {
+                    LabelNames = new[] {"grpc_code", "grpc_method", "grpc_service"}
+                }
+            );
+            _msgSentTotal = Prometheus.Metrics.CreateCounter(
+                "grpc_client_msg_sent_total",
+                "Total number of gRPC stream messages sent by the client",
+                new CounterConfiguration
+                {
+                    LabelNames = new[] {"grpc_method"}
+                }
+            );
+        }
+
+        public void IncHandledTotal(string grpcCode, string grpcMethod, string grpcService)
+        {
+            _handledTotal.WithLabels(grpcCode, grpcMethod, grpcService).Inc();
+        }
+
+        public void AddHandledTotal(string grpcCode, string grpcMethod, string grpcService, double value)
+        {
+            _handledTotal.WithLabels(grpcCode, grpcMethod, grpcService).Inc(value);
+        }
+
+        public void IncMsgSentTotal(string grpcMethod)
+        {
+            _msgSentTotal.WithLabels(grpcMethod).Inc();
+        }
+
+        public void AddMsgSentTotal(string grpcMethod, double value)
+        {
+            _msgSentTotal.WithLabels(grpcMethod).Inc(value);
+        }
+    }
+
+    /// <summary>
+    /// Implementation of Grpc.Server metrics
+    /// </summary>
+    public class GrpcServerMetricsImpl : IGrpcServerMetrics
+    {
+        private readonly Counter _handledTotal;
+        private readonly Histogram _handlingSeconds;
+        private readonly Gauge _inFlight;
+        private readonly Counter _msgReceivedTotal;
+        private readonly Counter _startedTotal;
+
+        public GrpcServerMetricsImpl()
+        {
+            _handledTotal = Prometheus.Metrics.CreateCounter(
+                "grpc_server_handled_total",
+                "Total number of RPCs completed on the server, regardless of success or failure",
+                new CounterConfiguration
+                {
+                    LabelNames = new[] {"grpc_code", "grpc_method", "grpc_service", "grpc_type"}
+                }
+            );
+            _handlingSeconds = Prometheus.Metrics.CreateHistogram(
+                "grpc_server_handling_seconds",
+                "Response latency of RPCs handled by the server",
+                new HistogramConfiguration
+                {
+                    LabelNames = new[] {"grpc_method", "grpc_service", "grpc_type"},
+                    Buckets = new[] {0.005d, 0.01d, 0.025d, 0.05d, 0.1d, 0.25d, 0.5d, 1d, 2.5d, 5d}
+                }
+            );
+            _inFlight = Prometheus.Metrics.CreateGauge(
+                "grpc_server_in_flight",
+                "Number of RPCs currently handled by the server",
+                new GaugeConfiguration
+                {
+                    LabelNames = new[] {"grpc_service"}
+                }
+            );
+            _msgReceivedTotal = Prometheus.Metrics.CreateCounter(
+                "grpc_server_msg_received_total",
+                "Total number of RPC stream messages received on the server",
+                new CounterConfiguration
+                {
+                    LabelNames = new[] {"grpc_method", "grpc_service", "grpc_type"}
+                }
+            );
+            _startedTotal = Prometheus.Metrics.CreateCounter(
+                "grpc_server_started_total",
+                "Total number of RPCs started on the server",
+                new CounterConfiguration
+                {
+                    LabelNames = new[] {"grpc_method", "grpc_service", "grpc_type"}
+                }
+            );
+        }
+
+        public void IncHandledTotal(string grpcCode, string grpcMethod, string grpcService, string grpcType)
+        {
+            _handledTotal.WithLabels(grpcCode, grpcMethod, grpcService, grpcType).Inc();
+        }
+
+        public void AddHandledTotal(string grpcCode, string grpcMethod, string grpcService, string grpcType, double value)
+        {
+            _handledTotal.WithLabels(grpcCode, grpcMethod, grpcService, grpcType).Inc(value);
+        }
+
+        public void ObserveHandlingSeconds(string grpcMethod, string grpcService, string grpcType, double value)
+        {
+            _handlingSeconds.WithLabels(grpcMethod, grpcService, grpcType).Observe(value);
+        }
+
+        public IDisposable TimeHandlingSeconds(string grpcMethod, string grpcService, string grpcType)
+        {
+            return _handlingSeconds.WithLabels(grpcMethod, grpcService, grpcType).NewTimer();
+        }
+
+        public void SetInFlight(string grpcService, double value)
+        {
+            _inFlight.WithLabels(grpcService).Set(value);
+        }
+
+        public void IncInFlight(string grpcService)
+        {
+            _inFlight.WithLabels(grpcService).Inc();
+        }
+
+        public void DecInFlight(string grpcService)
+        {
+            _inFlight.WithLabels(grpcService).Dec();
+        }
+
+        public void AddInFlight(string grpcService, double value)
+        {
+            _inFlight.WithLabels(grpcService).Inc(value);
+        }
+
+        public void SubInFlight(string grpcService, double value)
+        {
+            _inFlight.WithLabels(grpcService).Dec(value);
+        }
+
+        public IDisposable TrackInFlight(string grpcService)
+        {
+            return _inFlight.WithLabels(grpcService).TrackInProgress();
+        }
+
+        public void IncMsgReceivedTotal(string grpcMethod, string grpcService, string grpcType)
+        {
+            _msgReceivedTotal.WithLabels(grpcMethod, grpcService, grpcType).Inc();
+        }
+
+        public void AddMsgReceivedTotal(string grpcMethod, string grpcService, string grpcType, double value)
+        {
+            _msgReceivedTotal.WithLabels(grpcMethod, grpcService, grpcType).Inc(value);
+        }
+
+        public void IncStartedTotal(string grpcMethod, string grpcService, string grpcType)
+        {
+            _startedTotal.WithLabels(grpcMethod, grpcService, grpcType).Inc();
+        }
+
+        public void AddStartedTotal(string grpcMethod, string grpcService, string grpcType, double value)
+        {
+            _startedTotal.WithLabels(grpcMethod, grpcService, grpcType).Inc(value);
+        }
+    }
+
+    /// <summary>
+    /// Implementation of Http.Server metrics
+    /// </summary>
+    public class HttpServerMetricsImpl : IHttpServerMetrics
+    {
+        private readonly Counter _httpRequestCountTotal;
+        private readonly Histogram _httpRequestDurationSeconds;
+        private readonly Histogram _httpRequestSizeBytes;
+        private readonly Gauge _httpRequestsInFlight;
+        private readonly Counter _httpRequestsTotal;
+        private readonly Histogram _httpResponseSizeBytes;
+        private readonly MetricsConfig _config;
+
+        public HttpServerMetricsImpl() : this(MetricsConfig.FromEnvironment())
+        {
+        }
+
+        public HttpServerMetricsImpl(MetricsConfig config)
+        {
+            _config = config;
+            _httpRequestCountTotal = Prometheus.Metrics.CreateCounter(
+                "http_server_http_request_count_total",
+                "Total HTTP request count",
+                new CounterConfiguration
+                {
+                    LabelNames = new[] {"code", "method"}
+                }
+            );
+            _httpRequestDurationSeconds = Prometheus.Metrics.CreateHistogram(
+                "http_server_http_request_duration_seconds",
+                "HTTP request duration in seconds",
+                new HistogramConfiguration
+                {
+                    LabelNames = new[] {"method", "path", "status"},
+                    Buckets = new[] {0.001d, 0.005d, 0.01d, 0.025d, 0.05d, 0.1d, 0.25d, 0.5d, 1d, 2.5d, 5d, 10d}
+                }
+            );
+            _httpRequestSizeBytes = Prometheus.Metrics.CreateHistogram(
+                "http_server_http_request_size_bytes",
+                "HTTP request body size in bytes",
+                new HistogramConfiguration
+                {
+                    LabelNames = new[] {"method", "path"},
+                    Buckets = new[] {100d, 1000d, 10000d, 100000d, 1e+06d, 1e+07d}
+                }
+            );
+            _httpRequestsInFlight = Prometheus.Metrics.CreateGauge(
+                "http_server_http_requests_in_flight",
+                "Current number of HTTP requests being processed"
+            );
+            _httpRequestsTotal = Prometheus.Metrics.CreateCounter(
+                "http_server_http_requests_total",
+                "Total number of HTTP requests processed by the order service",
+                new CounterConfiguration
+                {
+                    LabelNames = new[] {"method", "path", "status", "app", "env", }
+                }
+            );
+            _httpResponseSizeBytes = Prometheus.Metrics.CreateHistogram(
+                "http_server_http_response_size_bytes",
+                "HTTP response body size in bytes",
+                new HistogramConfiguration
+                {
+                    LabelNames = new[] {"method", "path", "status"},
+                    Buckets = new[] {100d, 1000d, 10000d, 100000d, 1e+06d, 1e+07d}
+                }
+            );
+        }
+        [Obsolete("Since 1.2.0. Use http_requests_total instead. Renamed for consistency with Prometheus naming conventions", error: false)]
+
+        public void IncHttpRequestCountTotal(string code, string method)
+        {
+            _httpRequestCountTotal.WithLabels(code, method).Inc();
+        }
+        [Obsolete("Since 1.2.0. Use http_requests_total instead. Renamed for consistency with Prometheus naming conventions", error: false)]
+
+        public void AddHttpRequestCountTotal(string code, string method, double value)
+        {
+            _httpRequestCountTotal.WithLabels(code, method).Inc(value);
+        }
+
+        public void ObserveHttpRequestDurationSeconds(string method, string path, string status, double value)
+        {
+            _httpRequestDurationSeconds.WithLabels(method, path, status).Observe(value);
+        }
+
+        public IDisposable TimeHttpRequestDurationSeconds(string method, string path, string status)
+        {
+            return _httpRequestDurationSeconds.WithLabels(method, path, status).NewTimer();
+        }
+
+        public void ObserveHttpRequestSizeBytes(string method, string path, double value)
+        {
+            _httpRequestSizeBytes.WithLabels(method, path).Observe(value);
+        }
+
+        public void SetHttpRequestsInFlight(double value)
+        {
+            _httpRequestsInFlight.Set(value);
+        }
+
+        public void IncHttpRequestsInFlight()
+        {
+            _httpRequestsInFlight.Inc();
+        }
+
+        public void DecHttpRequestsInFlight()
+        {
+            _httpRequestsInFlight.Dec();
+        }
+
+        public void AddHttpRequestsInFlight(double value)
+        {
+            _httpRequestsInFlight.Inc(value);
+        }
+
+        public void SubHttpRequestsInFlight(double value)
+        {
+            _httpRequestsInFlight.Dec(value);
+        }
+
+        public IDisposable TrackHttpRequestsInFlight()
+        {
+            return _httpRequestsInFlight.TrackInProgress();
+        }
+
+        public void IncHttpRequestsTotal(string method, string path, string status)
+        {
+            var app = "order-service";
+            var env = string.IsNullOrEmpty(_config.Environment) ? "production" : _config.Environment;
+            _httpRequestsTotal.WithLabels(method, path, status, app, env).Inc();
+        }
+
+        public void AddHttpRequestsTotal(string method, string path, string status, double value)
+        {
+            var app = "order-service";
+            var env = string.IsNullOrEmpty(_config.Environment) ? "production" : _config.Environment;
+            _httpRequestsTotal.WithLabels(method, path, status, app, env).Inc(value);
+        }
+
+        public void ObserveHttpResponseSizeBytes(string method, string path, string status, double value)
+        {
+            _httpResponseSizeBytes.WithLabels(method, path, status).Observe(value);
+        }
+    }
+
+    /// <summary>
+    /// Main metrics registry
+    /// </summary>
+    public class MetricsRegistry
+    {
+        public IBusinessOrdersMetrics BusinessOrders { get; }
+        public ICacheRedisMetrics CacheRedis { get; }
+        public IDbPostgresMetrics DbPostgres { get; }
+        public IGrpcClientMetrics GrpcClient { get; }
+        public IGrpcServerMetrics GrpcServer { get; }
+        public IHttpServerMetrics HttpServer { get; }
+
+        private static readonly Lazy<MetricsRegistry> _instance =
+            new Lazy<MetricsRegistry>(() => new MetricsRegistry());
+
+        /// <summary>
+        /// Gets the default singleton instance
+        /// </summary>
+        public static MetricsRegistry Default => _instance.Value;
+
+        /// <summary>
+        /// Creates the metrics, whose const labels read the environment variables
+        /// </summary>
+        public MetricsRegistry() : this(MetricsConfig.FromEnvironment(), false)
+        {
+        }
+
+        /// <summary>
+        /// Creates the metrics with the const label values of the config.
+        /// Throws an InvalidOperationException listing the required values that are missing.
+        /// </summary>
+        public MetricsRegistry(MetricsConfig config) : this(config, true)
+        {
+        }
+
+        private MetricsRegistry(MetricsConfig config, bool validate)
+        {
+            if (validate)
+            {
+                config.Validate();
+            }
+            BusinessOrders = new BusinessOrdersMetricsImpl();
+            CacheRedis = new CacheRedisMetricsImpl();
+            DbPostgres = new DbPostgresMetricsImpl();
+            GrpcClient = new GrpcClientMetricsImpl();
+            GrpcServer = new GrpcServerMetricsImpl();
+            HttpServer = new HttpServerMetricsImpl(config);
+        }
+    }
+}
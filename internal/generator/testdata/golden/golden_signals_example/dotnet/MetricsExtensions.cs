@@ -0,0 +1,68 @@
+// <auto-generated>
+// This code was generated by Promener
+// Changes to this file may cause incorrect behavior and will be lost if the code is regenerated.
+// </auto-generated>
+
+using Microsoft.Extensions.DependencyInjection;
+using System;
+
+namespace Golden.Metrics
+{
+    /// <summary>
+    /// Extension methods for registering metrics in dependency injection container
+    /// </summary>
+    public static class MetricsServiceCollectionExtensions
+    {
+        /// <summary>
+        /// Adds metrics services to the dependency injection container
+        /// </summary>
+        /// <remarks>
+        /// When a MetricsConfig is registered, the const labels take its values and the registry
+        /// throws when created if required values are missing. Otherwise they read the environment variables.
+        /// </remarks>
+        public static IServiceCollection AddMetrics(this IServiceCollection services)
+        {
+            // Register the main registry as singleton
+            services.AddSingleton<MetricsRegistry>();
+            // Register Business.Orders metrics
+            services.AddSingleton<IBusinessOrdersMetrics>(sp =>
+                sp.GetRequiredService<MetricsRegistry>().BusinessOrders);
+            // Register Cache.Redis metrics
+            services.AddSingleton<ICacheRedisMetrics>(sp =>
+                sp.GetRequiredService<MetricsRegistry>().CacheRedis);
+            // Register Db.Postgres metrics
+            services.AddSingleton<IDbPostgresMetrics>(sp =>
+                sp.GetRequiredService<MetricsRegistry>().DbPostgres);
+            // Register Grpc.Client metrics
+            services.AddSingleton<IGrpcClientMetrics>(sp =>
+                sp.GetRequiredService<MetricsRegistry>().GrpcClient);
+            // Register Grpc.Server metrics
+            services.AddSingleton<IGrpcServerMetrics>(sp =>
+                sp.GetRequiredService<MetricsRegistry>().GrpcServer);
+            // Register Http.Server metrics
+            services.AddSingleton<IHttpServerMetrics>(sp =>
+                sp.GetRequiredService<MetricsRegistry>().HttpServer);
+
+            return services;
+        }
+
+        /// <summary>
+        /// Adds metrics services whose const labels take the values of the config
+        /// </summary>
+        public static IServiceCollection AddMetrics(this IServiceCollection services, MetricsConfig config)
+        {
+            services.AddSingleton(config);
+            return services.AddMetrics();
+        }
+
+        /// <summary>
+        /// Adds metrics services whose const labels take the values of the config created by the factory,
+        /// e.g. from IConfiguration or a secret store
+        /// </summary>
+        public static IServiceCollection AddMetrics(this IServiceCollection services, Func<IServiceProvider, MetricsConfig> configFactory)
+        {
+            services.AddSingleton(configFactory);
+            return services.AddMetrics();
+        }
+    }
+}
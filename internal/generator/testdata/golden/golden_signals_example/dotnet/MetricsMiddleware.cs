@@ -0,0 +1,98 @@
+// <auto-generated>
+// This code was generated by Promener
+// Changes to this file may cause incorrect behavior and will be lost if the code is regenerated.
+// </auto-generated>
+
+#nullable enable
+
+using System;
+using System.Diagnostics;
+using System.Threading.Tasks;
+using Microsoft.AspNetCore.Builder;
+using Microsoft.AspNetCore.Http;
+using Microsoft.AspNetCore.Routing;
+using Microsoft.Extensions.DependencyInjection;
+
+namespace Golden.Metrics
+{
+    /// <summary>
+    /// Helpers shared by the generated HTTP metrics middlewares
+    /// </summary>
+    public static class HttpMetricsRoutes
+    {
+        /// <summary>
+        /// Route label value used when a request did not match any endpoint
+        /// </summary>
+        public const string Unmatched = "/unmatched";
+
+        /// <summary>
+        /// Returns the route template of the matched endpoint (e.g. "/orders/{id}")
+        /// </summary>
+        public static string GetRoute(HttpContext context)
+        {
+            var pattern = (context.GetEndpoint() as RouteEndpoint)?.RoutePattern.RawText;
+            if (string.IsNullOrEmpty(pattern))
+            {
+                return Unmatched;
+            }
+            return pattern.StartsWith("/") ? pattern : "/" + pattern;
+        }
+
+        /// <summary>
+        /// Returns the class of an HTTP status code (e.g. "2xx" for 204)
+        /// </summary>
+        public static string StatusClass(int status) => $"{status / 100}xx";
+    }
+
+    /// <summary>
+    /// ASP.NET Core middleware recording the http/server golden signals for every request
+    /// </summary>
+    public class HttpServerMetricsMiddleware
+    {
+        private readonly RequestDelegate _next;
+        private readonly MetricsRegistry _registry;
+
+        public HttpServerMetricsMiddleware(RequestDelegate next, MetricsRegistry registry)
+        {
+            _next = next;
+            _registry = registry;
+        }
+
+        public async Task InvokeAsync(HttpContext context)
+        {
+            var method = context.Request.Method;
+            var stopwatch = Stopwatch.StartNew();
+            _registry.HttpServer.IncHttpRequestsInFlight();
+            var status = StatusCodes.Status500InternalServerError;
+            try
+            {
+                await _next(context);
+                status = context.Response.StatusCode;
+            }
+            finally
+            {
+                _registry.HttpServer.DecHttpRequestsInFlight();
+                var route = HttpMetricsRoutes.GetRoute(context);
+                var statusCode = status.ToString();
+                _registry.HttpServer.IncHttpRequestsTotal(method, route, statusCode);
+                _registry.HttpServer.ObserveHttpRequestDurationSeconds(method, route, statusCode, stopwatch.Elapsed.TotalSeconds);
+            }
+        }
+    }
+
+    /// <summary>
+    /// Extension methods for adding the HTTP metrics middlewares to the request pipeline
+    /// </summary>
+    public static class HttpMetricsApplicationBuilderExtensions
+    {
+        /// <summary>
+        /// Adds the http/server metrics middleware. The route template is read once the request has been handled.
+        /// Uses the given registry, the MetricsRegistry registered with AddMetrics, or MetricsRegistry.Default.
+        /// </summary>
+        public static IApplicationBuilder UseHttpServerMetrics(this IApplicationBuilder app, MetricsRegistry? registry = null)
+        {
+            registry ??= app.ApplicationServices.GetService<MetricsRegistry>() ?? MetricsRegistry.Default;
+            return app.UseMiddleware<HttpServerMetricsMiddleware>(registry);
+        }
+    }
+}
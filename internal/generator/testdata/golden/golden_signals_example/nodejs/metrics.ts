@@ -0,0 +1,1072 @@
+// This code was generated by Promener
+// Changes to this file may cause incorrect behavior and will be lost if the code is regenerated.
+
+import { Registry, Counter, Gauge, Histogram, Summary } from 'prom-client';
+
+/**
+ * Interface for Business.Orders metrics
+ */
+export interface IBusinessOrdersMetrics {
+  /**
+   * Increment created_total by 1
+   */
+  incCreatedTotal(channel: string, paymentMethod: string, status: string): void;
+
+  /**
+   * Increment created_total by a specific value
+   */
+  addCreatedTotal(channel: string, paymentMethod: string, status: string, value: number): void;
+  /**
+   * Set last_processed_timestamp_seconds to a specific value
+   *
+   * Unit: seconds
+   */
+  setLastProcessedTimestampSeconds(value: number): void;
+
+  /**
+   * Increment last_processed_timestamp_seconds by 1
+   */
+  incLastProcessedTimestampSeconds(): void;
+
+  /**
+   * Decrement last_processed_timestamp_seconds by 1
+   */
+  decLastProcessedTimestampSeconds(): void;
+
+  /**
+   * Add a value to last_processed_timestamp_seconds
+   */
+  addLastProcessedTimestampSeconds(value: number): void;
+
+  /**
+   * Subtract a value from last_processed_timestamp_seconds
+   */
+  subLastProcessedTimestampSeconds(value: number): void;
+
+  /**
+   * Set last_processed_timestamp_seconds to the current Unix time in seconds
+   */
+  setToCurrentTimeLastProcessedTimestampSeconds(): void;
+  /**
+   * Observe a value for processing_duration_seconds
+   *
+   * Unit: seconds
+   */
+  observeProcessingDurationSeconds(paymentMethod: string, value: number): void;
+
+  /**
+   * Start timing processing_duration_seconds. Call the returned function to record the elapsed seconds, which it returns.
+   */
+  startTimerProcessingDurationSeconds(paymentMethod: string): () => number;
+  /**
+   * Increment value_total by 1
+   */
+  incValueTotal(currency: string, status: string): void;
+
+  /**
+   * Increment value_total by a specific value
+   */
+  addValueTotal(currency: string, status: string, value: number): void;
+}
+
+/**
+ * Implementation of Business.Orders metrics
+ */
+export class BusinessOrdersMetricsImpl implements IBusinessOrdersMetrics {
+  private readonly _createdTotal: Counter;
+  private readonly _lastProcessedTimestampSeconds: Gauge;
+  private readonly _processingDurationSeconds: Histogram;
+  private readonly _valueTotal: Counter;
+
+  constructor(registry: Registry) {
+    this._createdTotal = new Counter({
+      name: 'business_orders_created_total',
+      help: 'Total number of orders created',
+      registers: [registry],
+      labelNames: ['channel', 'payment_method', 'status'
+      ],
+    });
+    this._lastProcessedTimestampSeconds = new Gauge({
+      name: 'business_orders_last_processed_timestamp_seconds',
+      help: 'Unix time of the last processed order',
+      registers: [registry],
+    });
+    this._processingDurationSeconds = new Histogram({
+      name: 'business_orders_processing_duration_seconds',
+      help: 'Time taken to process an order from creation to completion',
+      registers: [registry],
+      labelNames: ['payment_method'
+      ],
+      buckets: [0.1, 0.5, 1, 2, 5, 10, 30, 60, 120, 300],
+    });
+    this._valueTotal = new Counter({
+      name: 'business_orders_value_total',
+      help: 'Total monetary value of orders (in cents)',
+      registers: [registry],
+      labelNames: ['currency', 'status'
+      ],
+    });
+  }
+
+  incCreatedTotal(channel: string, paymentMethod: string, status: string): void {
+    this._createdTotal.inc({channel: channel, payment_method: paymentMethod, status: status
+    });
+  }
+
+  addCreatedTotal(channel: string, paymentMethod: string, status: string, value: number): void {
+    this._createdTotal.inc({channel: channel, payment_method: paymentMethod, status: status
+    }, value);
+  }
+
+  setLastProcessedTimestampSeconds(value: number): void {
+    this._lastProcessedTimestampSeconds.set(value);
+  }
+
+  incLastProcessedTimestampSeconds(): void {
+    this._lastProcessedTimestampSeconds.inc();
+  }
+
+  decLastProcessedTimestampSeconds(): void {
+    this._lastProcessedTimestampSeconds.dec();
+  }
+
+  addLastProcessedTimestampSeconds(value: number): void {
+    this._lastProcessedTimestampSeconds.inc(value);
+  }
+
+  subLastProcessedTimestampSeconds(value: number): void {
+    this._lastProcessedTimestampSeconds.dec(value);
+  }
+
+  setToCurrentTimeLastProcessedTimestampSeconds(): void {
+    this._lastProcessedTimestampSeconds.setToCurrentTime();
+  }
+
+  observeProcessingDurationSeconds(paymentMethod: string, value: number): void {
+    this._processingDurationSeconds.observe({payment_method: paymentMethod
+    }, value);
+  }
+
+  startTimerProcessingDurationSeconds(paymentMethod: string): () => number {
+    const end = this._processingDurationSeconds.startTimer({payment_method: paymentMethod
+    });
+    return () => end();
+  }
+
+  incValueTotal(currency: string, status: string): void {
+    this._valueTotal.inc({currency: currency, status: status
+    });
+  }
+
+  addValueTotal(currency: string, status: string, value: number): void {
+    this._valueTotal.inc({currency: currency, status: status
+    }, value);
+  }
+}
+
+/**
+ * Interface for Cache.Redis metrics
+ */
+export interface ICacheRedisMetrics {
+  /**
+   * Set keys to a specific value
+   */
+  setKeys(value: number): void;
+
+  /**
+   * Increment keys by 1
+   */
+  incKeys(): void;
+
+  /**
+   * Decrement keys by 1
+   */
+  decKeys(): void;
+
+  /**
+   * Add a value to keys
+   */
+  addKeys(value: number): void;
+
+  /**
+   * Subtract a value from keys
+   */
+  subKeys(value: number): void;
+  /**
+   * Set cache_memory_bytes to a specific value
+   */
+  setCacheMemoryBytes(value: number): void;
+
+  /**
+   * Increment cache_memory_bytes by 1
+   */
+  incCacheMemoryBytes(): void;
+
+  /**
+   * Decrement cache_memory_bytes by 1
+   */
+  decCacheMemoryBytes(): void;
+
+  /**
+   * Add a value to cache_memory_bytes
+   */
+  addCacheMemoryBytes(value: number): void;
+
+  /**
+   * Subtract a value from cache_memory_bytes
+   */
+  subCacheMemoryBytes(value: number): void;
+  /**
+   * Observe a value for duration_seconds
+   */
+  observeDurationSeconds(operation: string, value: number): void;
+  /**
+   * Increment cache_requests_total by 1
+   */
+  incCacheRequestsTotal(operation: string, status: string): void;
+
+  /**
+   * Increment cache_requests_total by a specific value
+   */
+  addCacheRequestsTotal(operation: string, status: string, value: number): void;
+}
+
+/**
+ * Implementation of Cache.Redis metrics
+ */
+export class CacheRedisMetricsImpl implements ICacheRedisMetrics {
+  private readonly _keys: Gauge;
+  private readonly _cacheMemoryBytes: Gauge;
+  private readonly _durationSeconds: Histogram;
+  private readonly _cacheRequestsTotal: Counter;
+
+  constructor(registry: Registry) {
+    this._keys = new Gauge({
+      name: 'cache_redis_keys',
+      help: 'Total number of keys in the cache',
+      registers: [registry],
+    });
+    this._cacheMemoryBytes = new Gauge({
+      name: 'cache_redis_cache_memory_bytes',
+      help: 'Memory used by the cache in bytes',
+      registers: [registry],
+    });
+    this._durationSeconds = new Histogram({
+      name: 'cache_redis_duration_seconds',
+      help: 'Cache operation duration in seconds',
+      registers: [registry],
+      labelNames: ['operation'
+      ],
+      buckets: [1e-05, 0.0001, 0.0005, 0.001, 0.005, 0.01, 0.025, 0.05, 0.1],
+    });
+    this._cacheRequestsTotal = new Counter({
+      name: 'cache_redis_cache_requests_total',
+      help: 'Total number of cache requests',
+      registers: [registry],
+      labelNames: ['operation', 'status'
+      ],
+    });
+  }
+
+  setKeys(value: number): void {
+    this._keys.set(value);
+  }
+
+  incKeys(): void {
+    this._keys.inc();
+  }
+
+  decKeys(): void {
+    this._keys.dec();
+  }
+
+  addKeys(value: number): void {
+    this._keys.inc(value);
+  }
+
+  subKeys(value: number): void {
+    this._keys.dec(value);
+  }
+
+  setCacheMemoryBytes(value: number): void {
+    this._cacheMemoryBytes.set(value);
+  }
+
+  incCacheMemoryBytes(): void {
+    this._cacheMemoryBytes.inc();
+  }
+
+  decCacheMemoryBytes(): void {
+    this._cacheMemoryBytes.dec();
+  }
+
+  addCacheMemoryBytes(value: number): void {
+    this._cacheMemoryBytes.inc(value);
+  }
+
+  subCacheMemoryBytes(value: number): void {
+    this._cacheMemoryBytes.dec(value);
+  }
+
+  observeDurationSeconds(operation: string, value: number): void {
+    this._durationSeconds.observe({operation: operation
+    }, value);
+  }
+
+  incCacheRequestsTotal(operation: string, status: string): void {
+    this._cacheRequestsTotal.inc({operation: operation, status: status
+    });
+  }
+
+  addCacheRequestsTotal(operation: string, status: string, value: number): void {
+    this._cacheRequestsTotal.inc({operation: operation, status: status
+    }, value);
+  }
+}
+
+/**
+ * Interface for Db.Postgres metrics
+ */
+export interface IDbPostgresMetrics {
+  /**
+   * Set db_connections_active to a specific value
+   */
+  setDbConnectionsActive(value: number): void;
+
+  /**
+   * Increment db_connections_active by 1
+   */
+  incDbConnectionsActive(): void;
+
+  /**
+   * Decrement db_connections_active by 1
+   */
+  decDbConnectionsActive(): void;
+
+  /**
+   * Add a value to db_connections_active
+   */
+  addDbConnectionsActive(value: number): void;
+
+  /**
+   * Subtract a value from db_connections_active
+   */
+  subDbConnectionsActive(value: number): void;
+  /**
+   * Set db_connections_idle to a specific value
+   */
+  setDbConnectionsIdle(value: number): void;
+
+  /**
+   * Increment db_connections_idle by 1
+   */
+  incDbConnectionsIdle(): void;
+
+  /**
+   * Decrement db_connections_idle by 1
+   */
+  decDbConnectionsIdle(): void;
+
+  /**
+   * Add a value to db_connections_idle
+   */
+  addDbConnectionsIdle(value: number): void;
+
+  /**
+   * Subtract a value from db_connections_idle
+   */
+  subDbConnectionsIdle(value: number): void;
+  /**
+   * Set db_connections_max to a specific value
+   */
+  setDbConnectionsMax(value: number): void;
+
+  /**
+   * Increment db_connections_max by 1
+   */
+  incDbConnectionsMax(): void;
+
+  /**
+   * Decrement db_connections_max by 1
+   */
+  decDbConnectionsMax(): void;
+
+  /**
+   * Add a value to db_connections_max
+   */
+  addDbConnectionsMax(value: number): void;
+
+  /**
+   * Subtract a value from db_connections_max
+   */
+  subDbConnectionsMax(value: number): void;
+  /**
+   * Observe a value for db_connections_wait_seconds
+   */
+  observeDbConnectionsWaitSeconds(value: number): void;
+  /**
+   * Increment db_queries_total by 1
+   */
+  incDbQueriesTotal(operation: string, status: string, table: string): void;
+
+  /**
+   * Increment db_queries_total by a specific value
+   */
+  addDbQueriesTotal(operation: string, status: string, table: string, value: number): void;
+  /**
+   * Observe a value for db_query_duration_seconds
+   *
+   * Unit: seconds
+   */
+  observeDbQueryDurationSeconds(operation: string, table: string, value: number): void;
+
+  /**
+   * Start timing db_query_duration_seconds. Call the returned function to record the elapsed seconds, which it returns.
+   */
+  startTimerDbQueryDurationSeconds(operation: string, table: string): () => number;
+}
+
+/**
+ * Implementation of Db.Postgres metrics
+ */
+export class DbPostgresMetricsImpl implements IDbPostgresMetrics {
+  private readonly _dbConnectionsActive: Gauge;
+  private readonly _dbConnectionsIdle: Gauge;
+  private readonly _dbConnectionsMax: Gauge;
+  private readonly _dbConnectionsWaitSeconds: Histogram;
+  private readonly _dbQueriesTotal: Counter;
+  private readonly _dbQueryDurationSeconds: Histogram;
+
+  constructor(registry: Registry) {
+    this._dbConnectionsActive = new Gauge({
+      name: 'db_postgres_db_connections_active',
+      help: 'Number of active database connections in the pool',
+      registers: [registry],
+    });
+    this._dbConnectionsIdle = new Gauge({
+      name: 'db_postgres_db_connections_idle',
+      help: 'Number of idle connections in the pool',
+      registers: [registry],
+    });
+    this._dbConnectionsMax = new Gauge({
+      name: 'db_postgres_db_connections_max',
+      help: 'Maximum number of connections allowed in the pool',
+      registers: [registry],
+    });
+    this._dbConnectionsWaitSeconds = new Histogram({
+      name: 'db_postgres_db_connections_wait_seconds',
+      help: 'Time spent waiting for a database connection from the pool',
+      registers: [registry],
+      buckets: [0.0001, 0.001, 0.01, 0.1, 0.5, 1, 5],
+    });
+    this._dbQueriesTotal = new Counter({
+      name: 'db_postgres_db_queries_total',
+      help: 'Total number of database queries executed',
+      registers: [registry],
+      labelNames: ['operation', 'status', 'table'
+      ],
+    });
+    this._dbQueryDurationSeconds = new Histogram({
+      name: 'db_postgres_db_query_duration_seconds',
+      help: 'Database query duration in seconds',
+      registers: [registry],
+      labelNames: ['operation', 'table'
+      ],
+      buckets: [0.0001, 0.0005, 0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5],
+    });
+  }
+
+  setDbConnectionsActive(value: number): void {
+    this._dbConnectionsActive.set(value);
+  }
+
+  incDbConnectionsActive(): void {
+    this._dbConnectionsActive.inc();
+  }
+
+  decDbConnectionsActive(): void {
+    this._dbConnectionsActive.dec();
+  }
+
+  addDbConnectionsActive(value: number): void {
+    this._dbConnectionsActive.inc(value);
+  }
+
+  subDbConnectionsActive(value: number): void {
+    this._dbConnectionsActive.dec(value);
+  }
+
+  setDbConnectionsIdle(value: number): void {
+    this._dbConnectionsIdle.set(value);
+  }
+
+  incDbConnectionsIdle(): void {
+    this._dbConnectionsIdle.inc();
+  }
+
+  decDbConnectionsIdle(): void {
+    this._dbConnectionsIdle.dec();
+  }
+
+  addDbConnectionsIdle(value: number): void {
+    this._dbConnectionsIdle.inc(value);
+  }
+
+  subDbConnectionsIdle(value: number): void {
+    this._dbConnectionsIdle.dec(value);
+  }
+
+  setDbConnectionsMax(value: number): void {
+    this._dbConnectionsMax.set(value);
+  }
+
+  incDbConnectionsMax(): void {
+    this._dbConnectionsMax.inc();
+  }
+
+  decDbConnectionsMax(): void {
+    this._dbConnectionsMax.dec();
+  }
+
+  addDbConnectionsMax(value: number): void {
+    this._dbConnectionsMax.inc(value);
+  }
+
+  subDbConnectionsMax(value: number): void {
+    this._dbConnectionsMax.dec(value);
+  }
+
+  observeDbConnectionsWaitSeconds(value: number): void {
+    this._dbConnectionsWaitSeconds.observe(value);
+  }
+
+  incDbQueriesTotal(operation: string, status: string, table: string): void {
+    this._dbQueriesTotal.inc({operation: operation, status: status, table: table
+    });
+  }
+
+  addDbQueriesTotal(operation: string, status: string, table: string, value: number): void {
+    this._dbQueriesTotal.inc({operation: operation, status: status, table: table
+    }, value);
+  }
+
+  observeDbQueryDurationSeconds(operation: string, table: string, value: number): void {
+    this._dbQueryDurationSeconds.observe({operation: operation, table: table
+    }, value);
+  }
+
+  startTimerDbQueryDurationSeconds(operation: string, table: string): () => number {
+    const end = this._dbQueryDurationSeconds.startTimer({operation: operation, table: table
+    });
+    return () => end();
+  }
+}
+
+/**
+ * Interface for Grpc.Client metrics
+ */
+export interface IGrpcClientMetrics {
+  /**
+   * Increment handled_total by 1
+   */
+  incHandledTotal(grpcCode: string, grpcMethod: string, grpcService: string): void;
+
+  /**
+   * Increment handled_total by a specific value
+   */
+  addHandledTotal(grpcCode: string, grpcMethod: string, grpcService: string, value: number): void;
+  /**
+   * Increment msg_sent_total by 1
+   */
+  incMsgSentTotal(grpcMethod: string): void;
+
+  /**
+   * Increment msg_sent_total by a specific value
+   */
+  addMsgSentTotal(grpcMethod: string, value: number): void;
+}
+
+/**
+ * Implementation of Grpc.Client metrics
+ */
+export class GrpcClientMetricsImpl implements IGrpcClientMetrics {
+  private readonly _handledTotal: Counter;
+  private readonly _msgSentTotal: Counter;
+
+  constructor(registry: Registry) {
+    this._handledTotal = new Counter({
+      name: 'grpc_client_handled_total',
+      help: 'Total number of RPCs completed by the client, regardless of success or failure',
+      registers: [registry],
+      labelNames: ['grpc_code', 'grpc_method', 'grpc_service'
+      ],
+    });
+    this._msgSentTotal = new Counter({
+      name: 'grpc_client_msg_sent_total',
+      help: 'Total number of gRPC stream messages sent by the client',
+      registers: [registry],
+      labelNames: ['grpc_method'
+      ],
+    });
+  }
+
+  incHandledTotal(grpcCode: string, grpcMethod: string, grpcService: string): void {
+    this._handledTotal.inc({grpc_code: grpcCode, grpc_method: grpcMethod, grpc_service: grpcService
+    });
+  }
+
+  addHandledTotal(grpcCode: string, grpcMethod: string, grpcService: string, value: number): void {
+    this._handledTotal.inc({grpc_code: grpcCode, grpc_method: grpcMethod, grpc_service: grpcService
+    }, value);
+  }
+
+  incMsgSentTotal(grpcMethod: string): void {
+    this._msgSentTotal.inc({grpc_method: grpcMethod
+    });
+  }
+
+  addMsgSentTotal(grpcMethod: string, value: number): void {
+    this._msgSentTotal.inc({grpc_method: grpcMethod
+    }, value);
+  }
+}
+
+/**
+ * Interface for Grpc.Server metrics
+ */
+export interface IGrpcServerMetrics {
+  /**
+   * Increment handled_total by 1
+   */
+  incHandledTotal(grpcCode: string, grpcMethod: string, grpcService: string, grpcType: string): void;
+
+  /**
+   * Increment handled_total by a specific value
+   */
+  addHandledTotal(grpcCode: string, grpcMethod: string, grpcService: string, grpcType: string, value: number): void;
+  /**
+   * Observe a value for handling_seconds
+   *
+   * Unit: seconds
+   */
+  observeHandlingSeconds(grpcMethod: string, grpcService: string, grpcType: string, value: number): void;
+
+  /**
+   * Start timing handling_seconds. Call the returned function to record the elapsed seconds, which it returns.
+   */
+  startTimerHandlingSeconds(grpcMethod: string, grpcService: string, grpcType: string): () => number;
+  /**
+   * Set in_flight to a specific value
+   */
+  setInFlight(grpcService: string, value: number): void;
+
+  /**
+   * Increment in_flight by 1
+   */
+  incInFlight(grpcService: string): void;
+
+  /**
+   * Decrement in_flight by 1
+   */
+  decInFlight(grpcService: string): void;
+
+  /**
+   * Add a value to in_flight
+   */
+  addInFlight(grpcService: string, value: number): void;
+
+  /**
+   * Subtract a value from in_flight
+   */
+  subInFlight(grpcService: string, value: number): void;
+
+  /**
+   * Increment in_flight. Call the returned function to decrement it.
+   */
+  trackInFlight(grpcService: string): () => void;
+  /**
+   * Increment msg_received_total by 1
+   */
+  incMsgReceivedTotal(grpcMethod: string, grpcService: string, grpcType: string): void;
+
+  /**
+   * Increment msg_received_total by a specific value
+   */
+  addMsgReceivedTotal(grpcMethod: string, grpcService: string, grpcType: string, value: number): void;
+  /**
+   * Increment started_total by 1
+   */
+  incStartedTotal(grpcMethod: string, grpcService: string, grpcType: string): void;
+
+  /**
+   * Increment started_total by a specific value
+   */
+  addStartedTotal(grpcMethod: string, grpcService: string, grpcType: string, value: number): void;
+}
+
+/**
+ * Implementation of Grpc.Server metrics
+ */
+export class GrpcServerMetricsImpl implements IGrpcServerMetrics {
+  private readonly _handledTotal: Counter;
+  private readonly _handlingSeconds: Histogram;
+  private readonly _inFlight: Gauge;
+  private readonly _msgReceivedTotal: Counter;
+  private readonly _startedTotal: Counter;
+
+  constructor(registry: Registry) {
+    this._handledTotal = new Counter({
+      name: 'grpc_server_handled_total',
+      help: 'Total number of RPCs completed on the server, regardless of success or failure',
+      registers: [registry],
+      labelNames: ['grpc_code', 'grpc_method', 'grpc_service', 'grpc_type'
+      ],
+    });
+    this._handlingSeconds = new Histogram({
+      name: 'grpc_server_handling_seconds',
+      help: 'Response latency of RPCs handled by the server',
+      registers: [registry],
+      labelNames: ['grpc_method', 'grpc_service', 'grpc_type'
+      ],
+      buckets: [0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5],
+    });
+    this._inFlight = new Gauge({
+      name: 'grpc_server_in_flight',
+      help: 'Number of RPCs currently handled by the server',
+      registers: [registry],
+      labelNames: ['grpc_service'
+      ],
+    });
+    this._msgReceivedTotal = new Counter({
+      name: 'grpc_server_msg_received_total',
+      help: 'Total number of RPC stream messages received on the server',
+      registers: [registry],
+      labelNames: ['grpc_method', 'grpc_service', 'grpc_type'
+      ],
+    });
+    this._startedTotal = new Counter({
+      name: 'grpc_server_started_total',
+      help: 'Total number of RPCs started on the server',
+      registers: [registry],
+      labelNames: ['grpc_method', 'grpc_service', 'grpc_type'
+      ],
+    });
+  }
+
+  incHandledTotal(grpcCode: string, grpcMethod: string, grpcService: string, grpcType: string): void {
+    this._handledTotal.inc({grpc_code: grpcCode, grpc_method: grpcMethod, grpc_service: grpcService, grpc_type: grpcType
+    });
+  }
+
+  addHandledTotal(grpcCode: string, grpcMethod: string, grpcService: string, grpcType: string, value: number): void {
+    this._handledTotal.inc({grpc_code: grpcCode, grpc_method: grpcMethod, grpc_service: grpcService, grpc_type: grpcType
+    }, value);
+  }
+
+  observeHandlingSeconds(grpcMethod: string, grpcService: string, grpcType: string, value: number): void {
+    this._handlingSeconds.observe({grpc_method: grpcMethod, grpc_service: grpcService, grpc_type: grpcType
+    }, value);
+  }
+
+  startTimerHandlingSeconds(grpcMethod: string, grpcService: string, grpcType: string): () => number {
+    const end = this._handlingSeconds.startTimer({grpc_method: grpcMethod, grpc_service: grpcService, grpc_type: grpcType
+    });
+    return () => end();
+  }
+
+  setInFlight(grpcService: string, value: number): void {
+    this._inFlight.set({grpc_service: grpcService
+    }, value);
+  }
+
+  incInFlight(grpcService: string): void {
+    this._inFlight.inc({grpc_service: grpcService
+    });
+  }
+
+  decInFlight(grpcService: string): void {
+    this._inFlight.dec({grpc_service: grpcService
+    });
+  }
+
+  addInFlight(grpcService: string, value: number): void {
+    this._inFlight.inc({grpc_service: grpcService
+    }, value);
+  }
+
+  subInFlight(grpcService: string, value: number): void {
+    this._inFlight.dec({grpc_service: grpcService
+    }, value);
+  }
+
+  trackInFlight(grpcService: string): () => void {
+    const labels = {grpc_service: grpcService
+    };
+    this._inFlight.inc(labels);
+    return () => this._inFlight.dec(labels);
+  }
+
+  incMsgReceivedTotal(grpcMethod: string, grpcService: string, grpcType: string): void {
+    this._msgReceivedTotal.inc({grpc_method: grpcMethod, grpc_service: grpcService, grpc_type: grpcType
+    });
+  }
+
+  addMsgReceivedTotal(grpcMethod: string, grpcService: string, grpcType: string, value: number): void {
+    this._msgReceivedTotal.inc({grpc_method: grpcMethod, grpc_service: grpcService, grpc_type: grpcType
+    }, value);
+  }
+
+  incStartedTotal(grpcMethod: string, grpcService: string, grpcType: string): void {
+    this._startedTotal.inc({grpc_method: grpcMethod, grpc_service: grpcService, grpc_type: grpcType
+    });
+  }
+
+  addStartedTotal(grpcMethod: string, grpcService: string, grpcType: string, value: number): void {
+    this._startedTotal.inc({grpc_method: grpcMethod, grpc_service: grpcService, grpc_type: grpcType
+    }, value);
+  }
+}
+
+/**
+ * Interface for Http.Server metrics
+ */
+export interface IHttpServerMetrics {
+  /**
+   * Increment http_request_count_total by 1
+   * @deprecated Since 1.2.0. Use http_requests_total instead. Renamed for consistency with Prometheus naming conventions
+   */
+  incHttpRequestCountTotal(code: string, method: string): void;
+
+  /**
+   * Increment http_request_count_total by a specific value
+   * @deprecated Since 1.2.0. Use http_requests_total instead. Renamed for consistency with Prometheus naming conventions
+   */
+  addHttpRequestCountTotal(code: string, method: string, value: number): void;
+  /**
+   * Observe a value for http_request_duration_seconds
+   *
+   * Unit: seconds
+   */
+  observeHttpRequestDurationSeconds(method: string, path: string, status: string, value: number): void;
+
+  /**
+   * Start timing http_request_duration_seconds. Call the returned function to record the elapsed seconds, which it returns.
+   */
+  startTimerHttpRequestDurationSeconds(method: string, path: string, status: string): () => number;
+  /**
+   * Observe a value for http_request_size_bytes
+   */
+  observeHttpRequestSizeBytes(method: string, path: string, value: number): void;
+  /**
+   * Set http_requests_in_flight to a specific value
+   */
+  setHttpRequestsInFlight(value: number): void;
+
+  /**
+   * Increment http_requests_in_flight by 1
+   */
+  incHttpRequestsInFlight(): void;
+
+  /**
+   * Decrement http_requests_in_flight by 1
+   */
+  decHttpRequestsInFlight(): void;
+
+  /**
+   * Add a value to http_requests_in_flight
+   */
+  addHttpRequestsInFlight(value: number): void;
+
+  /**
+   * Subtract a value from http_requests_in_flight
+   */
+  subHttpRequestsInFlight(value: number): void;
+
+  /**
+   * Increment http_requests_in_flight. Call the returned function to decrement it.
+   */
+  trackHttpRequestsInFlight(): () => void;
+  /**
+   * Increment http_requests_total by 1
+   */
+  incHttpRequestsTotal(method: string, path: string, status: string): void;
+
+  /**
+   * Increment http_requests_total by a specific value
+   */
+  addHttpRequestsTotal(method: string, path: string, status: string, value: number): void;
+  /**
+   * Observe a value for http_response_size_bytes
+   */
+  observeHttpResponseSizeBytes(method: string, path: string, status: string, value: number): void;
+}
+
+/**
+ * Implementation of Http.Server metrics
+ */
+export class HttpServerMetricsImpl implements IHttpServerMetrics {
+  private readonly _httpRequestCountTotal: Counter;
+  private readonly _httpRequestDurationSeconds: Histogram;
+  private readonly _httpRequestSizeBytes: Histogram;
+  private readonly _httpRequestsInFlight: Gauge;
+  private readonly _httpRequestsTotal: Counter;
+  private readonly _httpResponseSizeBytes: Histogram;
+
+  constructor(registry: Registry) {
+    this._httpRequestCountTotal = new Counter({
+      name: 'http_server_http_request_count_total',
+      help: 'Total HTTP request count',
+      registers: [registry],
+      labelNames: ['code', 'method'
+      ],
+    });
+    this._httpRequestDurationSeconds = new Histogram({
+      name: 'http_server_http_request_duration_seconds',
+      help: 'HTTP request duration in seconds',
+      registers: [registry],
+      labelNames: ['method', 'path', 'status'
+      ],
+      buckets: [0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10],
+    });
+    this._httpRequestSizeBytes = new Histogram({
+      name: 'http_server_http_request_size_bytes',
+      help: 'HTTP request body size in bytes',
+      registers: [registry],
+      labelNames: ['method', 'path'
+      ],
+      buckets: [100, 1000, 10000, 100000, 1e+06, 1e+07],
+    });
+    this._httpRequestsInFlight = new Gauge({
+      name: 'http_server_http_requests_in_flight',
+      help: 'Current number of HTTP requests being processed',
+      registers: [registry],
+    });
+    this._httpRequestsTotal = new Counter({
+      name: 'http_server_http_requests_total',
+      help: 'Total number of HTTP requests processed by the order service',
+      registers: [registry],
+      labelNames: ['method', 'path', 'status', 'app', 'env'
+      ],
+    });
+    this._httpResponseSizeBytes = new Histogram({
+      name: 'http_server_http_response_size_bytes',
+      help: 'HTTP response body size in bytes',
+      registers: [registry],
+      labelNames: ['method', 'path', 'status'
+      ],
+      buckets: [100, 1000, 10000, 100000, 1e+06, 1e+07],
+    });
+  }
+
+  incHttpRequestCountTotal(code: string, method: string): void {
+    this._httpRequestCountTotal.inc({code: code, method: method
+    });
+  }
+
+  addHttpRequestCountTotal(code: string, method: string, value: number): void {
+    this._httpRequestCountTotal.inc({code: code, method: method
+    }, value);
+  }
+
+  observeHttpRequestDurationSeconds(method: string, path: string, status: string, value: number): void {
+    this._httpRequestDurationSeconds.observe({method: method, path: path, status: status
+    }, value);
+  }
+
+  startTimerHttpRequestDurationSeconds(method: string, path: string, status: string): () => number {
+    const end = this._httpRequestDurationSeconds.startTimer({method: method, path: path, status: status
+    });
+    return () => end();
+  }
+
+  observeHttpRequestSizeBytes(method: string, path: string, value: number): void {
+    this._httpRequestSizeBytes.observe({method: method, path: path
+    }, value);
+  }
+
+  setHttpRequestsInFlight(value: number): void {
+    this._httpRequestsInFlight.set(value);
+  }
+
+  incHttpRequestsInFlight(): void {
+    this._httpRequestsInFlight.inc();
+  }
+
+  decHttpRequestsInFlight(): void {
+    this._httpRequestsInFlight.dec();
+  }
+
+  addHttpRequestsInFlight(value: number): void {
+    this._httpRequestsInFlight.inc(value);
+  }
+
+  subHttpRequestsInFlight(value: number): void {
+    this._httpRequestsInFlight.dec(value);
+  }
+
+  trackHttpRequestsInFlight(): () => void {
+    this._httpRequestsInFlight.inc();
+    return () => this._httpRequestsInFlight.dec();
+  }
+
+  incHttpRequestsTotal(method: string, path: string, status: string): void {
+    const app = 'order-service';
+    const env = process.env.ENVIRONMENT || 'production';
+    this._httpRequestsTotal.inc({method: method, path: path, status: status, app: app, env: env
+    });
+  }
+
+  addHttpRequestsTotal(method: string, path: string, status: string, value: number): void {
+    const app = 'order-service';
+    const env = process.env.ENVIRONMENT || 'production';
+    this._httpRequestsTotal.inc({method: method, path: path, status: status, app: app, env: env
+    }, value);
+  }
+
+  observeHttpResponseSizeBytes(method: string, path: string, status: string, value: number): void {
+    this._httpResponseSizeBytes.observe({method: method, path: path, status: status
+    }, value);
+  }
+}
+
+/**
+ * Main metrics registry
+ */
+export class MetricsRegistry {
+  public readonly registry: Registry;
+  public readonly businessOrders: IBusinessOrdersMetrics;
+  public readonly cacheRedis: ICacheRedisMetrics;
+  public readonly dbPostgres: IDbPostgresMetrics;
+  public readonly grpcClient: IGrpcClientMetrics;
+  public readonly grpcServer: IGrpcServerMetrics;
+  public readonly httpServer: IHttpServerMetrics;
+
+  private static _instance: MetricsRegistry | null = null;
+
+  /**
+   * Gets the default singleton instance
+   */
+  static get default(): MetricsRegistry {
+    if (!MetricsRegistry._instance) {
+      MetricsRegistry._instance = new MetricsRegistry();
+    }
+    return MetricsRegistry._instance;
+  }
+
+  constructor(registry?: Registry) {
+    this.registry = registry || new Registry();
+    this.businessOrders = new BusinessOrdersMetricsImpl(this.registry);
+    this.cacheRedis = new CacheRedisMetricsImpl(this.registry);
+    this.dbPostgres = new DbPostgresMetricsImpl(this.registry);
+    this.grpcClient = new GrpcClientMetricsImpl(this.registry);
+    this.grpcServer = new GrpcServerMetricsImpl(this.registry);
+    this.httpServer = new HttpServerMetricsImpl(this.registry);
+  }
+
+  /**
+   * Get metrics in Prometheus format
+   */
+  async getMetrics(): Promise<string> {
+    return this.registry.metrics();
+  }
+}
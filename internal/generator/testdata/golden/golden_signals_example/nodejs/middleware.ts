@@ -0,0 +1,130 @@
+// This code was generated by Promener
+// Changes to this file may cause incorrect behavior and will be lost if the code is regenerated.
+
+import { MetricsRegistry } from './metrics';
+
+/**
+ * Route label value used when a request did not match any route
+ */
+export const UNMATCHED_ROUTE = '/unmatched';
+
+/**
+ * Returns the class of an HTTP status code (e.g. "2xx" for 204)
+ */
+export function toStatusClass(status: number): string {
+  return `${Math.floor(status / 100)}xx`;
+}
+
+/**
+ * Minimal shape of the Express request used by the middlewares
+ */
+export interface ExpressRequestLike {
+  method: string;
+  baseUrl?: string;
+  route?: { path?: unknown };
+}
+
+/**
+ * Minimal shape of the Express response used by the middlewares
+ */
+export interface ExpressResponseLike {
+  statusCode: number;
+  once(event: 'finish' | 'close', listener: () => void): unknown;
+}
+
+/**
+ * Minimal shape of the Fastify request used by the hooks
+ */
+export interface FastifyRequestLike {
+  method: string;
+  routeOptions?: { url?: string };
+  routerPath?: string;
+}
+
+/**
+ * Minimal shape of the Fastify reply used by the hooks
+ */
+export interface FastifyReplyLike {
+  statusCode: number;
+}
+
+/**
+ * Minimal shape of the Fastify instance used to register the hooks
+ */
+export interface FastifyInstanceLike {
+  addHook(name: 'onRequest' | 'onResponse', hook: (request: any, reply: any, done: () => void) => void): unknown;
+}
+
+/**
+ * Returns the route template matched by Express (e.g. "/orders/:id")
+ */
+export function expressRoute(req: ExpressRequestLike): string {
+  if (!req.route || typeof req.route.path !== 'string') {
+    return UNMATCHED_ROUTE;
+  }
+  return `${req.baseUrl ?? ''}${req.route.path}` || UNMATCHED_ROUTE;
+}
+
+/**
+ * Returns the route template matched by Fastify (e.g. "/orders/:id")
+ */
+export function fastifyRoute(request: FastifyRequestLike): string {
+  return request.routeOptions?.url ?? request.routerPath ?? UNMATCHED_ROUTE;
+}
+
+/**
+ * Records the beginning of a http/server request and returns the function recording its completion
+ */
+function startHttpServer(registry: MetricsRegistry, method: string): (route: string, status: number) => void {
+  const begin = process.hrtime.bigint();
+  registry.httpServer.incHttpRequestsInFlight();
+
+  return (route: string, status: number): void => {
+    registry.httpServer.decHttpRequestsInFlight();
+    const statusCode = String(status);
+    registry.httpServer.incHttpRequestsTotal(method, route, statusCode);
+    const duration = Number(process.hrtime.bigint() - begin) / 1e9;
+    registry.httpServer.observeHttpRequestDurationSeconds(method, route, statusCode, duration);
+  };
+}
+
+/**
+ * Creates an Express middleware recording the http/server golden signals for every request
+ */
+export function createHttpServerExpressMiddleware(registry: MetricsRegistry = MetricsRegistry.default) {
+  return (req: ExpressRequestLike, res: ExpressResponseLike, next: () => void): void => {
+    const done = startHttpServer(registry, req.method);
+    let recorded = false;
+    const record = (): void => {
+      if (!recorded) {
+        recorded = true;
+        done(expressRoute(req), res.statusCode);
+      }
+    };
+    res.once('finish', record);
+    res.once('close', record);
+    next();
+  };
+}
+
+/**
+ * Registers Fastify hooks recording the http/server golden signals for every request.
+ * Register them on the root instance (or wrap them with fastify-plugin) to cover all routes.
+ */
+export function registerHttpServerFastifyHooks(fastify: FastifyInstanceLike, registry: MetricsRegistry = MetricsRegistry.default): void {
+  const pending = new WeakMap<object, (route: string, status: number) => void>();
+
+  fastify.addHook('onRequest', (request: FastifyRequestLike, _reply: FastifyReplyLike, done: () => void) => {
+    pending.set(request, startHttpServer(registry, request.method));
+    done();
+  });
+
+  fastify.addHook('onResponse', (request: FastifyRequestLike, reply: FastifyReplyLike, done: () => void) => {
+    const record = pending.get(request);
+    if (record) {
+      pending.delete(request);
+      record(fastifyRoute(request), reply.statusCode);
+    }
+    done();
+  });
+}
@@ -0,0 +1,259 @@
+// <auto-generated>
+// This code was generated by Promener
+// Changes to this file may cause incorrect behavior and will be lost if the code is regenerated.
+// </auto-generated>
+
+using Prometheus;
+using System;
+using System.Collections.Generic;
+
+namespace Golden.Metrics
+{
+    /// <summary>
+    /// Values of the environment variables read by the const labels
+    /// </summary>
+    public class MetricsConfig
+    {
+        /// <summary>Value of REGION, defaults to "eu-west-1" when null</summary>
+        public string Region { get; set; }
+
+        /// <summary>
+        /// Reads the config from the environment variables
+        /// </summary>
+        public static MetricsConfig FromEnvironment() =>
+            FromProvider(global::System.Environment.GetEnvironmentVariable);
+
+        /// <summary>
+        /// Reads the config from values keyed by environment variable name
+        /// </summary>
+        public static MetricsConfig FromDictionary(IReadOnlyDictionary<string, string> values) =>
+            FromProvider(name => values.TryGetValue(name, out var value) ? value : null);
+
+        /// <summary>
+        /// Reads the config from a provider returning the value of an environment variable name, or null
+        /// </summary>
+        public static MetricsConfig FromProvider(Func<string, string> provider) => new MetricsConfig
+        {
+            Region = provider("REGION"),
+        };
+
+        /// <summary>
+        /// Throws an InvalidOperationException listing all the required values that are null or empty
+        /// </summary>
+        public void Validate()
+        {
+            var missing = new List<string>();
+            if (missing.Count > 0)
+            {
+                throw new InvalidOperationException($"Missing required const label values: {string.Join(", ", missing)}");
+            }
+        }
+    }
+    /// <summary>
+    /// Interface for Payments.Api metrics
+    /// </summary>
+    public interface IPaymentsApiMetrics
+    {
+        /// <summary>Observe a value for payload_size_bytes</summary>
+        /// <remarks>
+        /// <para>Unit: bytes</para>
+        /// <para>Stability: ALPHA</para>
+        /// <para>Owner: platform</para>
+        /// </remarks>
+        void ObservePayloadSizeBytes(double value);
+        /// <summary>Observe a value for payment_duration_seconds</summary>
+        /// <remarks>
+        /// <para>Unit: seconds</para>
+        /// <para>Stability: BETA</para>
+        /// <para>Owner: payments</para>
+        /// <para>Since: 2.1.0</para>
+        /// <para>SLO: 99% over 7d: Authorizations faster than 500ms</para>
+        /// </remarks>
+        void ObservePaymentDurationSeconds(string provider, double value);
+        /// <summary>Start timing payment_duration_seconds, the elapsed seconds are recorded when the returned timer is disposed</summary>
+        IDisposable TimePaymentDurationSeconds(string provider);
+        /// <summary>Increment payments_total by 1</summary>
+        /// <remarks>
+        /// <para>Stability: STABLE</para>
+        /// <para>Owner: payments (#payments-oncall)</para>
+        /// <para>Since: 1.0.0</para>
+        /// <para>SLO: 99.9% over 30d: Payment attempts not failing with an error</para>
+        /// </remarks>
+        void IncPaymentsTotal(string outcome, string provider);
+        /// <summary>Increment payments_total by a specific value</summary>
+        void AddPaymentsTotal(string outcome, string provider, double value);
+    }
+    /// <summary>
+    /// Interface for Payments.Fraud metrics
+    /// </summary>
+    public interface IPaymentsFraudMetrics
+    {
+        /// <summary>Set fraud_score_ratio to a specific value</summary>
+        /// <remarks>
+        /// <para>Unit: ratio</para>
+        /// <para>Stability: ALPHA</para>
+        /// <para>Owner: risk (risk@example.com)</para>
+        /// <para>Since: 2.3.0</para>
+        /// </remarks>
+        void SetFraudScoreRatio(double value);
+        /// <summary>Increment fraud_score_ratio by 1</summary>
+        void IncFraudScoreRatio();
+        /// <summary>Decrement fraud_score_ratio by 1</summary>
+        void DecFraudScoreRatio();
+        /// <summary>Add a value to fraud_score_ratio</summary>
+        void AddFraudScoreRatio(double value);
+        /// <summary>Subtract a value from fraud_score_ratio</summary>
+        void SubFraudScoreRatio(double value);
+    }
+
+    /// <summary>
+    /// Implementation of Payments.Api metrics
+    /// </summary>
+    public class PaymentsApiMetricsImpl : IPaymentsApiMetrics
+    {
+        private readonly Summary _payloadSizeBytes;
+        private readonly Histogram _paymentDurationSeconds;
+        private readonly Counter _paymentsTotal;
+        private readonly MetricsConfig _config;
+
+        public PaymentsApiMetricsImpl() : this(MetricsConfig.FromEnvironment())
+        {
+        }
+
+        public PaymentsApiMetricsImpl(MetricsConfig config)
+        {
+            _config = config;
+            _payloadSizeBytes = Prometheus.Metrics.CreateSummary(
+                "payments_api_payload_size_bytes",
+                "Size of the payment requests"
+            );
+            _paymentDurationSeconds = Prometheus.Metrics.CreateHistogram(
+                "payments_api_payment_duration_seconds",
+                "Duration of payment authorizations",
+                new HistogramConfiguration
+                {
+                    LabelNames = new[] {"provider"},
+                    Buckets = new[] {0.05d, 0.1d, 0.25d, 0.5d, 1d, 2.5d}
+                }
+            );
+            _paymentsTotal = Prometheus.Metrics.CreateCounter(
+                "payments_api_payments_total",
+                "Total number of payment attempts",
+                new CounterConfiguration
+                {
+                    LabelNames = new[] {"outcome", "provider", "region", }
+                }
+            );
+        }
+
+        public void ObservePayloadSizeBytes(double value)
+        {
+            _payloadSizeBytes.Observe(value);
+        }
+
+        public void ObservePaymentDurationSeconds(string provider, double value)
+        {
+            _paymentDurationSeconds.WithLabels(provider).Observe(value);
+        }
+
+        public IDisposable TimePaymentDurationSeconds(string provider)
+        {
+            return _paymentDurationSeconds.WithLabels(provider).NewTimer();
+        }
+
+        public void IncPaymentsTotal(string outcome, string provider)
+        {
+            var region = string.IsNullOrEmpty(_config.Region) ? "eu-west-1" : _config.Region;
+            _paymentsTotal.WithLabels(outcome, provider, region).Inc();
+        }
+
+        public void AddPaymentsTotal(string outcome, string provider, double value)
+        {
+            var region = string.IsNullOrEmpty(_config.Region) ? "eu-west-1" : _config.Region;
+            _paymentsTotal.WithLabels(outcome, provider, region).Inc(value);
+        }
+    }
+
+    /// <summary>
+    /// Implementation of Payments.Fraud metrics
+    /// </summary>
+    public class PaymentsFraudMetricsImpl : IPaymentsFraudMetrics
+    {
+        private readonly Gauge _fraudScoreRatio;
+
+        public PaymentsFraudMetricsImpl()
+        {
+            _fraudScoreRatio = Prometheus.Metrics.CreateGauge(
+                "payments_fraud_fraud_score_ratio",
+                "Latest fraud score of the payment flow"
+            );
+        }
+
+        public void SetFraudScoreRatio(double value)
+        {
+            _fraudScoreRatio.Set(value);
+        }
+
+        public void IncFraudScoreRatio()
+        {
+            _fraudScoreRatio.Inc();
+        }
+
+        public void DecFraudScoreRatio()
+        {
+            _fraudScoreRatio.Dec();
+        }
+
+        public void AddFraudScoreRatio(double value)
+        {
+            _fraudScoreRatio.Inc(value);
+        }
+
+        public void SubFraudScoreRatio(double value)
+        {
+            _fraudScoreRatio.Dec(value);
+        }
+    }
+
+    /// <summary>
+    /// Main metrics registry
+    /// </summary>
+    public class MetricsRegistry
+    {
+        public IPaymentsApiMetrics PaymentsApi { get; }
+        public IPaymentsFraudMetrics PaymentsFraud { get; }
+
+        private static readonly Lazy<MetricsRegistry> _instance =
+            new Lazy<MetricsRegistry>(() => new MetricsRegistry());
+
+        /// <summary>
+        /// Gets the default singleton instance
+        /// </summary>
+        public static MetricsRegistry Default => _instance.Value;
+
+        /// <summary>
+        /// Creates the metrics, whose const labels read the environment variables
+        /// </summary>
+        public MetricsRegistry() : this(MetricsConfig.FromEnvironment(), false)
+        {
+        }
+
+        /// <summary>
+        /// Creates the metrics with the const label values of the config.
+        /// Throws an InvalidOperationException listing the required values that are missing.
+        /// </summary>
+        public MetricsRegistry(MetricsConfig config) : this(config, true)
+        {
+        }
+
+        private MetricsRegistry(MetricsConfig config, bool validate)
+        {
+            if (validate)
+            {
+                config.Validate();
+            }
+            PaymentsApi = new PaymentsApiMetricsImpl(config);
+            PaymentsFraud = new PaymentsFraudMetricsImpl();
+        }
+    }
+}
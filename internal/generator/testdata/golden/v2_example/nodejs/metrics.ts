@@ -0,0 +1,210 @@
+// This code was generated by Promener
+// Changes to this file may cause incorrect behavior and will be lost if the code is regenerated.
+
+import { Registry, Counter, Gauge, Histogram, Summary } from 'prom-client';
+
+/**
+ * Interface for Payments.Api metrics
+ */
+export interface IPaymentsApiMetrics {
+  /**
+   * Observe a value for payload_size_bytes
+   *
+   * Unit: bytes
+   * Stability: ALPHA
+   * Owner: platform
+   */
+  observePayloadSizeBytes(value: number): void;
+  /**
+   * Observe a value for payment_duration_seconds
+   *
+   * Unit: seconds
+   * Stability: BETA
+   * Owner: payments
+   * Since: 2.1.0
+   * SLO: 99% over 7d: Authorizations faster than 500ms
+   */
+  observePaymentDurationSeconds(provider: string, value: number): void;
+
+  /**
+   * Start timing payment_duration_seconds. Call the returned function to record the elapsed seconds, which it returns.
+   */
+  startTimerPaymentDurationSeconds(provider: string): () => number;
+  /**
+   * Increment payments_total by 1
+   *
+   * Stability: STABLE
+   * Owner: payments (#payments-oncall)
+   * Since: 1.0.0
+   * SLO: 99.9% over 30d: Payment attempts not failing with an error
+   */
+  incPaymentsTotal(outcome: string, provider: string): void;
+
+  /**
+   * Increment payments_total by a specific value
+   */
+  addPaymentsTotal(outcome: string, provider: string, value: number): void;
+}
+
+/**
+ * Implementation of Payments.Api metrics
+ */
+export class PaymentsApiMetricsImpl implements IPaymentsApiMetrics {
+  private readonly _payloadSizeBytes: Summary;
+  private readonly _paymentDurationSeconds: Histogram;
+  private readonly _paymentsTotal: Counter;
+
+  constructor(registry: Registry) {
+    this._payloadSizeBytes = new Summary({
+      name: 'payments_api_payload_size_bytes',
+      help: 'Size of the payment requests',
+      registers: [registry],
+    });
+    this._paymentDurationSeconds = new Histogram({
+      name: 'payments_api_payment_duration_seconds',
+      help: 'Duration of payment authorizations',
+      registers: [registry],
+      labelNames: ['provider'
+      ],
+      buckets: [0.05, 0.1, 0.25, 0.5, 1, 2.5],
+    });
+    this._paymentsTotal = new Counter({
+      name: 'payments_api_payments_total',
+      help: 'Total number of payment attempts',
+      registers: [registry],
+      labelNames: ['outcome', 'provider', 'region'
+      ],
+    });
+  }
+
+  observePayloadSizeBytes(value: number): void {
+    this._payloadSizeBytes.observe(value);
+  }
+
+  observePaymentDurationSeconds(provider: string, value: number): void {
+    this._paymentDurationSeconds.observe({provider: provider
+    }, value);
+  }
+
+  startTimerPaymentDurationSeconds(provider: string): () => number {
+    const end = this._paymentDurationSeconds.startTimer({provider: provider
+    });
+    return () => end();
+  }
+
+  incPaymentsTotal(outcome: string, provider: string): void {
+    const region = process.env.REGION || 'eu-west-1';
+    this._paymentsTotal.inc({outcome: outcome, provider: provider, region: region
+    });
+  }
+
+  addPaymentsTotal(outcome: string, provider: string, value: number): void {
+    const region = process.env.REGION || 'eu-west-1';
+    this._paymentsTotal.inc({outcome: outcome, provider: provider, region: region
+    }, value);
+  }
+}
+
+/**
+ * Interface for Payments.Fraud metrics
+ */
+export interface IPaymentsFraudMetrics {
+  /**
+   * Set fraud_score_ratio to a specific value
+   *
+   * Unit: ratio
+   * Stability: ALPHA
+   * Owner: risk (risk@example.com)
+   * Since: 2.3.0
+   */
+  setFraudScoreRatio(value: number): void;
+
+  /**
+   * Increment fraud_score_ratio by 1
+   */
+  incFraudScoreRatio(): void;
+
+  /**
+   * Decrement fraud_score_ratio by 1
+   */
+  decFraudScoreRatio(): void;
+
+  /**
+   * Add a value to fraud_score_ratio
+   */
+  addFraudScoreRatio(value: number): void;
+
+  /**
+   * Subtract a value from fraud_score_ratio
+   */
+  subFraudScoreRatio(value: number): void;
+}
+
+/**
+ * Implementation of Payments.Fraud metrics
+ */
+export class PaymentsFraudMetricsImpl implements IPaymentsFraudMetrics {
+  private readonly _fraudScoreRatio: Gauge;
+
+  constructor(registry: Registry) {
+    this._fraudScoreRatio = new Gauge({
+      name: 'payments_fraud_fraud_score_ratio',
+      help: 'Latest fraud score of the payment flow',
+      registers: [registry],
+    });
+  }
+
+  setFraudScoreRatio(value: number): void {
+    this._fraudScoreRatio.set(value);
+  }
+
+  incFraudScoreRatio(): void {
+    this._fraudScoreRatio.inc();
+  }
+
+  decFraudScoreRatio(): void {
+    this._fraudScoreRatio.dec();
+  }
+
+  addFraudScoreRatio(value: number): void {
+    this._fraudScoreRatio.inc(value);
+  }
+
+  subFraudScoreRatio(value: number): void {
+    this._fraudScoreRatio.dec(value);
+  }
+}
+
+/**
+ * Main metrics registry
+ */
+export class MetricsRegistry {
+  public readonly registry: Registry;
+  public readonly paymentsApi: IPaymentsApiMetrics;
+  public readonly paymentsFraud: IPaymentsFraudMetrics;
+
+  private static _instance: MetricsRegistry | null = null;
+
+  /**
+   * Gets the default singleton instance
+   */
+  static get default(): MetricsRegistry {
+    if (!MetricsRegistry._instance) {
+      MetricsRegistry._instance = new MetricsRegistry();
+    }
+    return MetricsRegistry._instance;
+  }
+
+  constructor(registry?: Registry) {
+    this.registry = registry || new Registry();
+    this.paymentsApi = new PaymentsApiMetricsImpl(this.registry);
+    this.paymentsFraud = new PaymentsFraudMetricsImpl(this.registry);
+  }
+
+  /**
+   * Get metrics in Prometheus format
+   */
+  async getMetrics(): Promise<string> {
+    return this.registry.metrics();
+  }
+}
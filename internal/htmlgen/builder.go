@@ -45,6 +45,11 @@ func (b *Builder) AddFromSpec(spec *domain.Specification) *Builder {
 	return b
 }
 
+// Spec returns the aggregated specification.
+func (b *Builder) Spec() *domain.Specification {
+	return b.spec
+}
+
 // Build generates the HTML documentation and writes it to a file.
 func (b *Builder) Build(outputPath string) error {
 	if len(b.spec.Services) == 0 {
@@ -63,6 +68,20 @@ func (b *Builder) Build(outputPath string) error {
 	return nil
 }
 
+// BuildSite generates the static documentation site in outputDir, see SiteGenerator.
+func (b *Builder) BuildSite(outputDir, baseURL string) error {
+	if len(b.spec.Services) == 0 {
+		return fmt.Errorf("no services added to builder")
+	}
+
+	site, err := NewSiteGenerator(baseURL)
+	if err != nil {
+		return err
+	}
+
+	return site.GenerateSite(b.spec, outputDir)
+}
+
 // NewGenerator creates a new HTML generator that can be used directly
 // for single-specification HTML generation.
 func NewGenerator() *Generator {
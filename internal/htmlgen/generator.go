@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"html/template"
 	"os"
+	"slices"
+	"strings"
 
 	"github.com/jycamier/promener/internal/domain"
 )
@@ -33,6 +35,24 @@ type MetricJSON struct {
 	ConstLabels []ConstLabelJSON `json:"constLabels,omitempty"`
 	Examples    *ExamplesJSON    `json:"examples,omitempty"`
 	Deprecated  *DeprecatedJSON  `json:"deprecated,omitempty"`
+	Unit        string           `json:"unit,omitempty"`
+	Stability   string           `json:"stability,omitempty"`
+	Owner       *OwnerJSON       `json:"owner,omitempty"`
+	Since       string           `json:"since,omitempty"`
+	SLO         *SLOJSON         `json:"slo,omitempty"`
+}
+
+// OwnerJSON represents the owner of a metric for JSON serialization
+type OwnerJSON struct {
+	Team    string `json:"team"`
+	Contact string `json:"contact,omitempty"`
+}
+
+// SLOJSON represents a service level objective for JSON serialization
+type SLOJSON struct {
+	Objective   float64 `json:"objective"`
+	Window      string  `json:"window"`
+	Description string  `json:"description,omitempty"`
 }
 
 // LabelJSON represents a label for JSON serialization
@@ -40,6 +60,8 @@ type LabelJSON struct {
 	Name        string `json:"name"`
 	Description string `json:"description,omitempty"`
 	Inherited   string `json:"inherited,omitempty"`
+	Shared      string `json:"shared,omitempty"` // Name of the shared label of the library
+	UsedBy      int    `json:"usedBy,omitempty"` // Number of metrics using the shared label
 }
 
 // ConstLabelJSON represents a constant label for JSON serialization
@@ -136,8 +158,9 @@ func New() (*Generator, error) {
 	return &Generator{tmpl: tmpl}, nil
 }
 
-// convertMetricToJSON converts a domain metric to JSON representation
-func convertMetricToJSON(key string, metric domain.Metric) MetricJSON {
+// convertMetricToJSON converts a domain metric to JSON representation.
+// sharedLabelUsage counts the metrics using each shared label.
+func convertMetricToJSON(key string, metric domain.Metric, sharedLabelUsage map[string]int) MetricJSON {
 	if metric.Name == "" {
 		metric.Name = key
 	}
@@ -149,6 +172,24 @@ func convertMetricToJSON(key string, metric domain.Metric) MetricJSON {
 		Subsystem: metric.Subsystem,
 		Type:      string(metric.Type),
 		Help:      metric.Help,
+		Unit:      metric.Unit,
+		Stability: string(metric.Stability),
+		Since:     metric.Since,
+	}
+
+	if metric.Owner != nil {
+		m.Owner = &OwnerJSON{
+			Team:    metric.Owner.Team,
+			Contact: metric.Owner.Contact,
+		}
+	}
+
+	if metric.SLO != nil {
+		m.SLO = &SLOJSON{
+			Objective:   metric.SLO.Objective,
+			Window:      metric.SLO.Window,
+			Description: metric.SLO.Description,
+		}
 	}
 
 	// Convert labels
@@ -157,6 +198,8 @@ func convertMetricToJSON(key string, metric domain.Metric) MetricJSON {
 			Name:        label.Name,
 			Description: label.Description,
 			Inherited:   label.Inherited,
+			Shared:      label.Ref,
+			UsedBy:      sharedLabelUsage[label.Ref],
 		})
 	}
 
@@ -244,11 +287,10 @@ func convertGoldenSignalsToJSON(signals domain.GoldenSignals) GoldenSignalsJSON
 	}
 }
 
-// Generate generates HTML documentation from a specification
-func (g *Generator) Generate(spec *domain.Specification) ([]byte, error) {
-	var data TemplateData
-	data.Info = spec.Info
-
+// ConvertServicesToJSON converts the services of a specification to JSON representation,
+// sorted by name with their metrics sorted by full name
+func ConvertServicesToJSON(spec *domain.Specification) []ServiceJSON {
+	sharedLabelUsage := spec.SharedLabelUsage()
 	services := make([]ServiceJSON, 0, len(spec.Services))
 	for serviceName, service := range spec.Services {
 		svc := ServiceJSON{
@@ -266,8 +308,11 @@ func (g *Generator) Generate(spec *domain.Specification) ([]byte, error) {
 
 		// Convert metrics
 		for key, metric := range service.Metrics {
-			svc.Metrics = append(svc.Metrics, convertMetricToJSON(key, metric))
+			svc.Metrics = append(svc.Metrics, convertMetricToJSON(key, metric, sharedLabelUsage))
 		}
+		slices.SortFunc(svc.Metrics, func(a, b MetricJSON) int {
+			return strings.Compare(a.FullName, b.FullName)
+		})
 
 		// Convert golden signals
 		if len(service.GoldenSignals) > 0 {
@@ -280,6 +325,18 @@ func (g *Generator) Generate(spec *domain.Specification) ([]byte, error) {
 		services = append(services, svc)
 	}
 
+	slices.SortFunc(services, func(a, b ServiceJSON) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return services
+}
+
+// Generate generates HTML documentation from a specification
+func (g *Generator) Generate(spec *domain.Specification) ([]byte, error) {
+	var data TemplateData
+	data.Info = spec.Info
+
+	services := ConvertServicesToJSON(spec)
 	data.Services = services
 
 	jsonData, err := json.Marshal(services)
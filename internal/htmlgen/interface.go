@@ -20,12 +20,26 @@ type HTMLBuilder interface {
 	// AddFromSpec merges all services from the given specification into the builder.
 	AddFromSpec(spec *domain.Specification) *Builder
 
+	// Spec returns the aggregated specification.
+	Spec() *domain.Specification
+
 	// Build generates the HTML documentation and writes it to a file.
 	Build(outputPath string) error
+
+	// BuildSite generates the static documentation site in a directory.
+	BuildSite(outputDir, baseURL string) error
+}
+
+// HTMLSiteGenerator is the interface for generating static multi-page documentation sites.
+// This interface is useful for mocking in tests.
+type HTMLSiteGenerator interface {
+	// GenerateSite generates the site of a specification in a directory.
+	GenerateSite(spec *domain.Specification, outputDir string) error
 }
 
 // Ensure the concrete types implement their interfaces.
 var (
-	_ HTMLGenerator = (*Generator)(nil)
-	_ HTMLBuilder   = (*Builder)(nil)
+	_ HTMLGenerator     = (*Generator)(nil)
+	_ HTMLBuilder       = (*Builder)(nil)
+	_ HTMLSiteGenerator = (*SiteGenerator)(nil)
 )
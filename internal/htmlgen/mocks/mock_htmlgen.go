@@ -121,3 +121,69 @@ func (mr *MockHTMLBuilderMockRecorder) Build(outputPath any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Build", reflect.TypeOf((*MockHTMLBuilder)(nil).Build), outputPath)
 }
+
+// BuildSite mocks base method.
+func (m *MockHTMLBuilder) BuildSite(outputDir, baseURL string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BuildSite", outputDir, baseURL)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BuildSite indicates an expected call of BuildSite.
+func (mr *MockHTMLBuilderMockRecorder) BuildSite(outputDir, baseURL any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BuildSite", reflect.TypeOf((*MockHTMLBuilder)(nil).BuildSite), outputDir, baseURL)
+}
+
+// Spec mocks base method.
+func (m *MockHTMLBuilder) Spec() *domain.Specification {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Spec")
+	ret0, _ := ret[0].(*domain.Specification)
+	return ret0
+}
+
+// Spec indicates an expected call of Spec.
+func (mr *MockHTMLBuilderMockRecorder) Spec() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Spec", reflect.TypeOf((*MockHTMLBuilder)(nil).Spec))
+}
+
+// MockHTMLSiteGenerator is a mock of HTMLSiteGenerator interface.
+type MockHTMLSiteGenerator struct {
+	ctrl     *gomock.Controller
+	recorder *MockHTMLSiteGeneratorMockRecorder
+	isgomock struct{}
+}
+
+// MockHTMLSiteGeneratorMockRecorder is the mock recorder for MockHTMLSiteGenerator.
+type MockHTMLSiteGeneratorMockRecorder struct {
+	mock *MockHTMLSiteGenerator
+}
+
+// NewMockHTMLSiteGenerator creates a new mock instance.
+func NewMockHTMLSiteGenerator(ctrl *gomock.Controller) *MockHTMLSiteGenerator {
+	mock := &MockHTMLSiteGenerator{ctrl: ctrl}
+	mock.recorder = &MockHTMLSiteGeneratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHTMLSiteGenerator) EXPECT() *MockHTMLSiteGeneratorMockRecorder {
+	return m.recorder
+}
+
+// GenerateSite mocks base method.
+func (m *MockHTMLSiteGenerator) GenerateSite(spec *domain.Specification, outputDir string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateSite", spec, outputDir)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GenerateSite indicates an expected call of GenerateSite.
+func (mr *MockHTMLSiteGeneratorMockRecorder) GenerateSite(spec, outputDir any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSite", reflect.TypeOf((*MockHTMLSiteGenerator)(nil).GenerateSite), spec, outputDir)
+}
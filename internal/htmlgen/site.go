@@ -0,0 +1,274 @@
+package htmlgen
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html/template"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/jycamier/promener/internal/domain"
+)
+
+//go:embed site/*.html site/search.js
+var siteFS embed.FS
+
+const (
+	// siteSearchIndexFile is loaded with a script tag rather than fetched, so that the site
+	// can be browsed from the file system
+	siteSearchIndexFile = "search-index.js"
+	siteSearchScript    = "search.js"
+	siteSitemapFile     = "sitemap.xml"
+)
+
+// Breadcrumb is a link of the breadcrumbs of a site page
+type Breadcrumb struct {
+	Title string
+	URL   string // Relative to the root of the site, empty for the current page
+}
+
+// SitePage contains data for the templates of the site pages
+type SitePage struct {
+	Title       string
+	Root        string // Relative path from the page to the root of the site, e.g. ../../
+	Info        domain.Info
+	Breadcrumbs []Breadcrumb
+	Services    []ServiceJSON
+	Service     *ServiceJSON
+	Metric      *MetricJSON
+	MetricURLs  map[string]string // Metric name and full name -> URL relative to the service page
+}
+
+// SearchEntry is an entry of the client-side search index of the site
+type SearchEntry struct {
+	FullName string `json:"n"`
+	Service  string `json:"s"`
+	Type     string `json:"t"`
+	Help     string `json:"h"`
+	URL      string `json:"u"` // Relative to the root of the site
+}
+
+// SiteGenerator generates a static multi-page documentation site: an index of the services,
+// one page per service and one page per metric with a stable URL, a search index and, when the
+// base URL is known, a sitemap
+type SiteGenerator struct {
+	tmpl    *template.Template
+	baseURL string
+}
+
+// NewSiteGenerator creates a new site generator. baseURL is the absolute URL the site is
+// published at, used for the URLs of the sitemap; when empty, no sitemap is written since the
+// sitemap protocol requires absolute URLs.
+func NewSiteGenerator(baseURL string) (*SiteGenerator, error) {
+	if baseURL != "" {
+		if u, err := url.Parse(baseURL); err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid base URL %q: expected an absolute URL, e.g. https://metrics.example.com/", baseURL)
+		}
+	}
+
+	tmpl, err := template.New("site").Funcs(template.FuncMap{
+		"serviceURL": ServiceURL,
+		"list": func(args ...any) []any {
+			return args
+		},
+		"percent": func(objective float64) string {
+			return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%.4f", objective*100), "0"), ".") + "%"
+		},
+	}).ParseFS(siteFS, "site/*.html")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse site templates: %w", err)
+	}
+
+	if baseURL != "" && !strings.HasSuffix(baseURL, "/") {
+		baseURL += "/"
+	}
+	return &SiteGenerator{tmpl: tmpl, baseURL: baseURL}, nil
+}
+
+// ServiceURL returns the URL of the page of a service, relative to the root of the site
+func ServiceURL(service string) string {
+	return "services/" + pageSlug(service) + "/"
+}
+
+// MetricURL returns the permalink of the page of a metric, relative to the root of the site
+func MetricURL(service, fullName string) string {
+	return ServiceURL(service) + "metrics/" + pageSlug(fullName) + "/"
+}
+
+// pageSlug returns the directory name of the page of a service or a metric. The characters
+// other than ASCII letters, digits, dots, dashes and underscores are replaced with dashes, as
+// well as a name made of dots only, so that the URL is also the path of the page file and a
+// name cannot add a directory or leave its parent.
+func pageSlug(name string) string {
+	slug := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		default:
+			return '-'
+		}
+	}, name)
+	if strings.Trim(slug, ".") == "" {
+		return strings.Repeat("-", max(len(slug), 1))
+	}
+	return slug
+}
+
+// GenerateSite generates the site of a specification in outputDir
+func (g *SiteGenerator) GenerateSite(spec *domain.Specification, outputDir string) error {
+	services := ConvertServicesToJSON(spec)
+	pages := []string{""}
+
+	// Names differing only by the characters replaced in their slug share a page
+	owners := make(map[string]string)
+	addPage := func(pageURL, name string) error {
+		if owner, ok := owners[pageURL]; ok {
+			return fmt.Errorf("%s and %s have the same page %s", owner, name, pageURL)
+		}
+		owners[pageURL] = name
+		pages = append(pages, pageURL)
+		return nil
+	}
+
+	if err := g.writePage(outputDir, "", "index.html", SitePage{
+		Title:    spec.Info.Title,
+		Info:     spec.Info,
+		Services: services,
+	}); err != nil {
+		return err
+	}
+
+	var index []SearchEntry
+	for i := range services {
+		service := &services[i]
+		serviceURL := ServiceURL(service.Name)
+		home := Breadcrumb{Title: spec.Info.Title, URL: ""}
+		if err := addPage(serviceURL, fmt.Sprintf("service %q", service.Name)); err != nil {
+			return err
+		}
+
+		metricURLs := make(map[string]string, 2*len(service.Metrics))
+		for _, metric := range service.Metrics {
+			metricURL := strings.TrimPrefix(MetricURL(service.Name, metric.FullName), serviceURL)
+			metricURLs[metric.Name] = metricURL
+			metricURLs[metric.FullName] = metricURL
+		}
+
+		if err := g.writePage(outputDir, serviceURL, "service.html", SitePage{
+			Title:       service.Info.Title,
+			Info:        spec.Info,
+			Breadcrumbs: []Breadcrumb{home, {Title: service.Info.Title}},
+			Service:     service,
+			MetricURLs:  metricURLs,
+		}); err != nil {
+			return err
+		}
+
+		for j := range service.Metrics {
+			metric := &service.Metrics[j]
+			metricURL := MetricURL(service.Name, metric.FullName)
+			if err := addPage(metricURL, fmt.Sprintf("metric %q of service %q", metric.FullName, service.Name)); err != nil {
+				return err
+			}
+			if err := g.writePage(outputDir, metricURL, "metric.html", SitePage{
+				Title:       metric.FullName,
+				Info:        spec.Info,
+				Breadcrumbs: []Breadcrumb{home, {Title: service.Info.Title, URL: serviceURL}, {Title: metric.FullName}},
+				Service:     service,
+				Metric:      metric,
+			}); err != nil {
+				return err
+			}
+
+			index = append(index, SearchEntry{
+				FullName: metric.FullName,
+				Service:  service.Name,
+				Type:     metric.Type,
+				Help:     metric.Help,
+				URL:      metricURL,
+			})
+		}
+	}
+
+	if err := writeSearchIndex(outputDir, index); err != nil {
+		return err
+	}
+	if g.baseURL != "" {
+		if err := g.writeSitemap(outputDir, pages); err != nil {
+			return err
+		}
+	}
+
+	script, err := siteFS.ReadFile("site/" + siteSearchScript)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", siteSearchScript, err)
+	}
+	return writeSiteFile(outputDir, siteSearchScript, script)
+}
+
+// writePage renders the template of a page at pageURL, a directory relative to the root of the
+// site which is also the path of the page file
+func (g *SiteGenerator) writePage(outputDir, pageURL, templateName string, page SitePage) error {
+	page.Root = strings.Repeat("../", strings.Count(pageURL, "/"))
+
+	var buf bytes.Buffer
+	if err := g.tmpl.ExecuteTemplate(&buf, templateName, page); err != nil {
+		return fmt.Errorf("failed to execute template %s: %w", templateName, err)
+	}
+	return writeSiteFile(outputDir, path.Join(pageURL, "index.html"), buf.Bytes())
+}
+
+// writeSearchIndex writes the search index as a script defining window.PROMENER_SEARCH_INDEX
+func writeSearchIndex(outputDir string, index []SearchEntry) error {
+	data, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("failed to marshal search index: %w", err)
+	}
+	script := fmt.Sprintf("window.PROMENER_SEARCH_INDEX = %s;\n", data)
+	return writeSiteFile(outputDir, siteSearchIndexFile, []byte(script))
+}
+
+type sitemapURL struct {
+	Loc string `xml:"loc"`
+}
+
+type sitemap struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+// writeSitemap writes the sitemap of the pages, with URLs relative to the base URL
+func (g *SiteGenerator) writeSitemap(outputDir string, pages []string) error {
+	s := sitemap{Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9"}
+	for _, page := range pages {
+		s.URLs = append(s.URLs, sitemapURL{Loc: g.baseURL + page})
+	}
+
+	data, err := xml.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal sitemap: %w", err)
+	}
+	return writeSiteFile(outputDir, siteSitemapFile, append([]byte(xml.Header), append(data, '\n')...))
+}
+
+// writeSiteFile writes a file of the site, name being a slash-separated path relative to outputDir
+func writeSiteFile(outputDir, name string, data []byte) error {
+	if !filepath.IsLocal(filepath.FromSlash(name)) {
+		return fmt.Errorf("%s is outside of the site directory", name)
+	}
+	file := filepath.Join(outputDir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// Client-side search of the metrics, over the index defined by search-index.js
+(function () {
+    const root = document.body.dataset.root || '';
+    const input = document.getElementById('search');
+    const results = document.getElementById('search-results');
+    const maxResults = 20;
+
+    document.getElementById('dark-mode').addEventListener('click', function () {
+        const dark = document.documentElement.classList.toggle('dark');
+        localStorage.setItem('darkMode', dark);
+    });
+
+    function search(query) {
+        const terms = query.toLowerCase().split(/\s+/).filter(Boolean);
+        if (terms.length === 0) {
+            return [];
+        }
+        return (window.PROMENER_SEARCH_INDEX || []).filter(function (entry) {
+            const text = (entry.n + ' ' + entry.s + ' ' + entry.t + ' ' + entry.h).toLowerCase();
+            return terms.every(function (term) { return text.includes(term); });
+        }).slice(0, maxResults);
+    }
+
+    function render(entries) {
+        results.replaceChildren();
+        entries.forEach(function (entry) {
+            const link = document.createElement('a');
+            link.href = root + entry.u + 'index.html';
+            link.className = 'block px-4 py-2 hover:bg-gray-100 dark:hover:bg-gray-700';
+
+            const name = document.createElement('span');
+            name.className = 'font-mono text-sm font-semibold text-gray-900 dark:text-white';
+            name.textContent = entry.n;
+            const service = document.createElement('span');
+            service.className = 'ml-2 text-xs text-gray-500 dark:text-gray-400';
+            service.textContent = entry.s + ' · ' + entry.t;
+            const help = document.createElement('p');
+            help.className = 'text-xs text-gray-600 dark:text-gray-400 truncate';
+            help.textContent = entry.h;
+
+            link.append(name, service, help);
+            const item = document.createElement('li');
+            item.append(link);
+            results.append(item);
+        });
+        results.classList.toggle('hidden', entries.length === 0);
+    }
+
+    input.addEventListener('input', function () {
+        render(search(input.value));
+    });
+    input.addEventListener('keydown', function (event) {
+        if (event.key === 'Enter') {
+            const first = results.querySelector('a');
+            if (first) {
+                window.location.href = first.href;
+            }
+        } else if (event.key === 'Escape') {
+            input.value = '';
+            render([]);
+        }
+    });
+    document.addEventListener('click', function (event) {
+        if (!results.contains(event.target) && event.target !== input) {
+            results.classList.add('hidden');
+        }
+    });
+})();
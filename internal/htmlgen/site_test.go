@@ -0,0 +1,208 @@
+package htmlgen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jycamier/promener/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func siteSpec(services ...string) *domain.Specification {
+	spec := &domain.Specification{
+		Info:     domain.Info{Title: "Shop Metrics", Version: "1.0.0"},
+		Services: map[string]domain.Service{},
+	}
+	for _, name := range services {
+		spec.Services[name] = domain.Service{
+			Info: domain.Info{Title: name + " service", Version: "1.0.0"},
+			Metrics: map[string]domain.Metric{
+				"requests_total": {
+					Namespace: "http",
+					Subsystem: "server",
+					Type:      domain.MetricTypeCounter,
+					Help:      "Total requests",
+				},
+			},
+		}
+	}
+	return spec
+}
+
+func generateSite(t *testing.T, baseURL string, spec *domain.Specification) string {
+	t.Helper()
+	site, err := NewSiteGenerator(baseURL)
+	require.NoError(t, err)
+
+	outputDir := t.TempDir()
+	require.NoError(t, site.GenerateSite(spec, outputDir))
+	return outputDir
+}
+
+func readSiteFile(t *testing.T, outputDir, name string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(outputDir, filepath.FromSlash(name)))
+	require.NoError(t, err)
+	return string(content)
+}
+
+func TestSiteGenerator_Layout(t *testing.T) {
+	outputDir := generateSite(t, "", siteSpec("orders"))
+
+	for _, name := range []string{
+		"index.html",
+		"services/orders/index.html",
+		"services/orders/metrics/http_server_requests_total/index.html",
+		"search-index.js",
+		"search.js",
+	} {
+		assert.FileExists(t, filepath.Join(outputDir, filepath.FromSlash(name)))
+	}
+	assert.NoFileExists(t, filepath.Join(outputDir, "sitemap.xml"))
+
+	assert.Contains(t, readSiteFile(t, outputDir, "index.html"), `href="services/orders/index.html"`)
+	assert.Contains(t, readSiteFile(t, outputDir, "services/orders/index.html"), `href="metrics/http_server_requests_total/index.html"`)
+	assert.Contains(t, readSiteFile(t, outputDir, "search-index.js"), `"u":"services/orders/metrics/http_server_requests_total/"`)
+}
+
+func TestSiteGenerator_RelativeRoot(t *testing.T) {
+	outputDir := generateSite(t, "", siteSpec("orders"))
+
+	tests := []struct {
+		page string
+		root string
+	}{
+		{page: "index.html", root: ""},
+		{page: "services/orders/index.html", root: "../../"},
+		{page: "services/orders/metrics/http_server_requests_total/index.html", root: "../../../../"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.page, func(t *testing.T) {
+			page := readSiteFile(t, outputDir, tt.page)
+			assert.Contains(t, page, `data-root="`+tt.root+`"`)
+			assert.Contains(t, page, `<script defer src="`+tt.root+`search-index.js"></script>`)
+			assert.Contains(t, page, `href="`+tt.root+`index.html"`)
+		})
+	}
+
+	assert.Contains(t, readSiteFile(t, outputDir, "services/orders/metrics/http_server_requests_total/index.html"), `href="../../../../services/orders/index.html"`)
+}
+
+func TestSiteGenerator_Sitemap(t *testing.T) {
+	tests := []struct {
+		name    string
+		baseURL string
+		want    []string
+	}{
+		{
+			name:    "absolute URLs",
+			baseURL: "https://metrics.example.com/docs/",
+			want: []string{
+				"<loc>https://metrics.example.com/docs/</loc>",
+				"<loc>https://metrics.example.com/docs/services/orders/</loc>",
+				"<loc>https://metrics.example.com/docs/services/orders/metrics/http_server_requests_total/</loc>",
+			},
+		},
+		{
+			name:    "base URL without trailing slash",
+			baseURL: "https://metrics.example.com",
+			want: []string{
+				"<loc>https://metrics.example.com/</loc>",
+				"<loc>https://metrics.example.com/services/orders/</loc>",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sitemap := readSiteFile(t, generateSite(t, tt.baseURL, siteSpec("orders")), "sitemap.xml")
+			for _, want := range tt.want {
+				assert.Contains(t, sitemap, want)
+			}
+		})
+	}
+}
+
+func TestNewSiteGenerator_InvalidBaseURL(t *testing.T) {
+	for _, baseURL := range []string{"metrics.example.com", "/docs/", "https://"} {
+		_, err := NewSiteGenerator(baseURL)
+		assert.Error(t, err, baseURL)
+	}
+}
+
+func TestSiteGenerator_UnsafeNames(t *testing.T) {
+	spec := siteSpec("team/orders", "..", "café")
+	spec.Services["team/orders"].Metrics["rate"] = domain.Metric{
+		Name: "job:http_requests:rate5m",
+		Type: domain.MetricTypeGauge,
+		Help: "Recorded request rate",
+	}
+	outputDir := generateSite(t, "", spec)
+
+	tests := []struct {
+		page string
+		root string
+	}{
+		{page: "services/team-orders/index.html", root: "../../"},
+		{page: "services/team-orders/metrics/job-http_requests-rate5m/index.html", root: "../../../../"},
+		{page: "services/--/index.html", root: "../../"},
+		{page: "services/--/metrics/http_server_requests_total/index.html", root: "../../../../"},
+		{page: "services/caf-/index.html", root: "../../"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.page, func(t *testing.T) {
+			assert.Contains(t, readSiteFile(t, outputDir, tt.page), `data-root="`+tt.root+`"`)
+		})
+	}
+
+	assert.NoFileExists(t, filepath.Join(outputDir, "metrics", "http_server_requests_total", "index.html"))
+	assert.Contains(t, readSiteFile(t, outputDir, "index.html"), `href="services/team-orders/index.html"`)
+}
+
+func TestSiteGenerator_SameSlug(t *testing.T) {
+	site, err := NewSiteGenerator("")
+	require.NoError(t, err)
+
+	err = site.GenerateSite(siteSpec("team/orders", "team-orders"), t.TempDir())
+	assert.EqualError(t, err, `service "team-orders" and service "team/orders" have the same page services/team-orders/`)
+}
+
+func TestPageSlug(t *testing.T) {
+	tests := map[string]string{
+		"orders":                   "orders",
+		"orders-v2.1":              "orders-v2.1",
+		"team/orders":              "team-orders",
+		`team\orders`:              "team-orders",
+		"job:http_requests:rate5m": "job-http_requests-rate5m",
+		"..":                       "--",
+		".":                        "-",
+		"":                         "-",
+		"a b?c#d%2F":               "a-b-c-d-2F",
+	}
+	for name, want := range tests {
+		assert.Equal(t, want, pageSlug(name), name)
+	}
+}
+
+func TestWriteSiteFile_OutsideDirectory(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "site")
+
+	err := writeSiteFile(outputDir, "../escaped/index.html", []byte("x"))
+	assert.EqualError(t, err, "../escaped/index.html is outside of the site directory")
+	assert.NoFileExists(t, filepath.Join(filepath.Dir(outputDir), "escaped", "index.html"))
+}
+
+func TestBuilder_BuildSite(t *testing.T) {
+	outputDir := t.TempDir()
+
+	err := NewBuilder("Shop Metrics", "1.0.0").BuildSite(outputDir, "")
+	assert.EqualError(t, err, "no services added to builder")
+
+	builder := NewBuilder("Shop Metrics", "1.0.0").AddFromSpec(siteSpec("orders")).AddFromSpec(siteSpec("users"))
+	require.NoError(t, builder.BuildSite(outputDir, "https://metrics.example.com/"))
+	assert.FileExists(t, filepath.Join(outputDir, "services", "users", "index.html"))
+	assert.Contains(t, readSiteFile(t, outputDir, "sitemap.xml"), "<loc>https://metrics.example.com/services/users/</loc>")
+}
@@ -0,0 +1,165 @@
+// Package migrate upgrades Promener specifications to newer schema versions.
+package migrate
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"cuelang.org/go/cue/ast"
+	"cuelang.org/go/cue/format"
+	"cuelang.org/go/cue/parser"
+	"cuelang.org/go/cue/token"
+	"github.com/jycamier/promener/internal/validator"
+)
+
+// TargetVersion is the version written in the migrated specifications
+const TargetVersion = "2.0.0"
+
+// Result is a migrated specification
+type Result struct {
+	// Source is the migrated CUE source, formatted with cue fmt
+	Source []byte
+
+	// Changes describes the changes applied to the source
+	Changes []string
+
+	// Warnings describes the changes left to the user, the migrated specification
+	// does not validate against the schema v2 until they are done
+	Warnings []string
+}
+
+// V1ToV2 migrates a v1 specification file to the schema v2: it sets the version to 2.0.0 and declares
+// the unit of the metrics whose name ends with a base unit (e.g. _seconds, or _bytes_total for counters).
+// Comments and formatting of the file are kept; metrics not defined as a struct literal in the file
+// (e.g. imported from a CUE module) are reported as warnings.
+func V1ToV2(path string) (*Result, error) {
+	spec, _, err := validator.New().ValidateAndExtract(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", path, err)
+	}
+	if spec.MajorVersion() != 1 {
+		return nil, fmt.Errorf("%s uses the schema v%d, only v1 specifications can be migrated", path, spec.MajorVersion())
+	}
+
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	file, err := parser.ParseFile(path, source, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	result := &Result{}
+	if err := setVersion(file); err != nil {
+		return nil, err
+	}
+	result.Changes = append(result.Changes, fmt.Sprintf("version: %q -> %q", spec.Version, TargetVersion))
+
+	for _, serviceName := range sortedKeys(spec.Services) {
+		metrics := spec.Services[serviceName].Metrics
+		for _, key := range sortedKeys(metrics) {
+			metric := metrics[key]
+			metricPath := fmt.Sprintf("services.%s.metrics.%s", serviceName, key)
+
+			if metric.Unit == "" {
+				if unit := metric.SuffixUnit(); unit != "" {
+					metric.Unit = unit
+					if structs := lookupStructs(file.Decls, "services", serviceName, "metrics", key); len(structs) > 0 {
+						addUnit(structs[0], unit)
+						result.Changes = append(result.Changes, fmt.Sprintf("%s: unit: %q", metricPath, unit))
+					} else {
+						result.Warnings = append(result.Warnings, fmt.Sprintf("%s: add unit: %q, the metric is not defined as a literal in %s", metricPath, unit, path))
+					}
+				}
+			}
+
+			if err := metric.ValidateUnit(); err != nil {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("%s: %v", metricPath, err))
+			}
+		}
+	}
+
+	if result.Source, err = format.Node(file); err != nil {
+		return nil, fmt.Errorf("failed to format the migrated specification: %w", err)
+	}
+	return result, nil
+}
+
+// setVersion replaces the top-level version of the file
+func setVersion(file *ast.File) error {
+	for _, decl := range file.Decls {
+		field, ok := decl.(*ast.Field)
+		if !ok || labelName(field.Label) != "version" {
+			continue
+		}
+		if lit, ok := field.Value.(*ast.BasicLit); !ok || lit.Kind != token.STRING {
+			return fmt.Errorf("version must be a string literal to be migrated")
+		}
+		field.Value = ast.NewString(TargetVersion)
+		return nil
+	}
+	return fmt.Errorf("no top-level version field found")
+}
+
+// addUnit declares the unit of a metric, after its type when the struct defines it
+func addUnit(metric *ast.StructLit, unit string) {
+	field := &ast.Field{Label: ast.NewIdent("unit"), Value: ast.NewString(unit)}
+	for i, elt := range metric.Elts {
+		if f, ok := elt.(*ast.Field); ok && labelName(f.Label) == "type" {
+			metric.Elts = append(metric.Elts[:i+1], append([]ast.Decl{field}, metric.Elts[i+1:]...)...)
+			return
+		}
+	}
+	metric.Elts = append(metric.Elts, field)
+}
+
+// lookupStructs returns the struct literals declared at the path of fields in decls, following nested
+// declarations (services: api: metrics: {...}) and unifications with a struct literal (#Metric & {...})
+func lookupStructs(decls []ast.Decl, path ...string) []*ast.StructLit {
+	var result []*ast.StructLit
+	for _, decl := range decls {
+		field, ok := decl.(*ast.Field)
+		if !ok || labelName(field.Label) != path[0] {
+			continue
+		}
+		for _, lit := range structLits(field.Value) {
+			if len(path) == 1 {
+				result = append(result, lit)
+			} else {
+				result = append(result, lookupStructs(lit.Elts, path[1:]...)...)
+			}
+		}
+	}
+	return result
+}
+
+func structLits(expr ast.Expr) []*ast.StructLit {
+	switch x := expr.(type) {
+	case *ast.StructLit:
+		return []*ast.StructLit{x}
+	case *ast.BinaryExpr:
+		if x.Op == token.AND {
+			return append(structLits(x.X), structLits(x.Y)...)
+		}
+	}
+	return nil
+}
+
+func labelName(label ast.Label) string {
+	name, _, err := ast.LabelName(label)
+	if err != nil {
+		return ""
+	}
+	return name
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,98 @@
+package migrate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const v1Spec = `package main
+
+version: "1.0.0"
+
+info: {
+	title:   "Test"
+	version: "1.0.0"
+}
+
+services: {
+	api: {
+		info: {
+			title:   "API"
+			version: "1.0.0"
+		}
+		metrics: {
+			// Request latency
+			request_duration_seconds: {
+				namespace: "http"
+				subsystem: "server"
+				type:      "histogram"
+				help:      "Request duration"
+				buckets: [0.1, 1]
+			}
+			sent_bytes_total: {
+				namespace: "http"
+				subsystem: "server"
+				type:      "counter"
+				help:      "Bytes sent"
+			}
+			queue_seconds: {
+				namespace: "jobs"
+				subsystem: "queue"
+				type:      "counter"
+				help:      "Time spent in the queue"
+			}
+			requests_total: {
+				namespace: "http"
+				subsystem: "server"
+				type:      "counter"
+				help:      "Requests"
+			}
+		}
+	}
+}
+`
+
+func writeSpec(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "metrics.cue")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestV1ToV2(t *testing.T) {
+	result, err := V1ToV2(writeSpec(t, v1Spec))
+	require.NoError(t, err)
+
+	source := string(result.Source)
+	assert.Contains(t, source, `version: "2.0.0"`)
+	assert.Contains(t, source, "// Request latency")
+	assert.Contains(t, source, "type:      \"histogram\"\n\t\t\t\tunit:      \"seconds\"")
+	assert.Contains(t, source, "type:      \"counter\"\n\t\t\t\tunit:      \"bytes\"")
+	assert.Contains(t, source, "type:      \"counter\"\n\t\t\t\tunit:      \"seconds\"")
+
+	assert.Equal(t, []string{
+		`version: "1.0.0" -> "2.0.0"`,
+		`services.api.metrics.queue_seconds: unit: "seconds"`,
+		`services.api.metrics.request_duration_seconds: unit: "seconds"`,
+		`services.api.metrics.sent_bytes_total: unit: "bytes"`,
+	}, result.Changes)
+
+	require.Len(t, result.Warnings, 1)
+	assert.Contains(t, result.Warnings[0], "services.api.metrics.queue_seconds: metric name queue_seconds must end with _seconds_total")
+}
+
+func TestV1ToV2_RejectsV2Spec(t *testing.T) {
+	result, err := V1ToV2(writeSpec(t, v1Spec))
+	require.NoError(t, err)
+
+	// Once the warnings are fixed, the migrated specification is a valid v2 one
+	migrated := strings.ReplaceAll(string(result.Source), "queue_seconds:", "queue_seconds_total:")
+	_, err = V1ToV2(writeSpec(t, migrated))
+	assert.ErrorContains(t, err, "only v1 specifications can be migrated")
+}
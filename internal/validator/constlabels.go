@@ -0,0 +1,79 @@
+package validator
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/jycamier/promener/internal/domain"
+)
+
+// labelNameRegex matches the Prometheus label names. Names starting with __ are reserved.
+var labelNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// checkConstLabels reports the const labels the generated code would reject or fail to read:
+// invalid names, names of dynamic labels, malformed environment variable references, environment variables without a default, and
+// environment variables with different defaults.
+func checkConstLabels(spec *domain.Specification) []ValidationError {
+	var result []ValidationError
+	constLabelError := func(path, ruleID, message string) {
+		result = append(result, ValidationError{
+			Path:     path,
+			Message:  message,
+			Source:   "domain",
+			Severity: "error",
+			RuleID:   ruleID,
+		})
+	}
+
+	for _, serviceName := range slices.Sorted(maps.Keys(spec.Services)) {
+		service := spec.Services[serviceName]
+		for _, key := range slices.Sorted(maps.Keys(service.Metrics)) {
+			metric := service.Metrics[key]
+			for _, label := range metric.ConstLabels {
+				path := fmt.Sprintf("services[%s].metrics[%s].constLabels[%s]", serviceName, key, label.Name)
+
+				switch {
+				case !labelNameRegex.MatchString(label.Name):
+					constLabelError(path, "const-label-name", fmt.Sprintf("Const label name '%s' is invalid: expected [a-zA-Z_][a-zA-Z0-9_]*", label.Name))
+				case strings.HasPrefix(label.Name, "__"):
+					constLabelError(path, "const-label-name", fmt.Sprintf("Const label name '%s' is invalid: names starting with __ are reserved by Prometheus", label.Name))
+				}
+
+				if slices.Contains(metric.GetLabelNames(), label.Name) {
+					constLabelError(path, "const-label-conflict", fmt.Sprintf("Const label '%s' of metric '%s' is also a label of the metric", label.Name, metric.FullName()))
+				}
+
+				if err := domain.ValidateEnvVarRef(label.Value); err != nil {
+					constLabelError(path, "env-var-syntax", fmt.Sprintf("Const label '%s' has a %v", label.Name, err))
+				} else if ref, ok := domain.ParseEnvVarRef(label.Value); ok && ref.Default == "" {
+					result = append(result, ValidationError{
+						Path:     path,
+						Message:  fmt.Sprintf("Const label '%s' reads %s without a default: the generated .NET and Node.js code throws when the metric is recorded while it is unset, and Go uses an empty value", label.Name, ref.Name),
+						Source:   "domain",
+						Severity: "warning",
+						RuleID:   "env-var-required",
+						Fix:      fmt.Sprintf("Give a default with ${%s:default}, or check that every deployment sets %s (see promener env)", ref.Name, ref.Name),
+					})
+				}
+			}
+		}
+	}
+
+	for _, usage := range spec.EnvVars() {
+		if len(usage.Defaults) > 1 {
+			result = append(result, ValidationError{
+				Path:     usage.Labels[0],
+				Message:  fmt.Sprintf("Environment variable %s has different defaults: %s", usage.Name, strings.Join(usage.Defaults, ", ")),
+				Source:   "domain",
+				Severity: "warning",
+				RuleID:   "env-var-default-mismatch",
+				Fix:      fmt.Sprintf("Use the same default in %s", strings.Join(usage.Labels, ", ")),
+			})
+		}
+	}
+
+	return result
+}
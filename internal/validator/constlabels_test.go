@@ -0,0 +1,126 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/jycamier/promener/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func withConstLabels(metric domain.Metric, labels ...string) domain.Metric {
+	for i := 0; i+1 < len(labels); i += 2 {
+		metric.ConstLabels = append(metric.ConstLabels, domain.ConstLabelDefinition{Name: labels[i], Value: labels[i+1]})
+	}
+	return metric
+}
+
+func TestCheckConstLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		metrics map[string]domain.Metric
+		want    []ValidationError
+	}{
+		{
+			name: "valid const labels",
+			metrics: map[string]domain.Metric{
+				"requests_total": withConstLabels(counter("Requests", "method"), "env", "${ENVIRONMENT:production}", "team", "payments"),
+			},
+		},
+		{
+			name: "reserved name",
+			metrics: map[string]domain.Metric{
+				"requests_total": withConstLabels(counter("Requests"), "__env", "production"),
+			},
+			want: []ValidationError{{
+				Path:     "services[orders].metrics[requests_total].constLabels[__env]",
+				Message:  "Const label name '__env' is invalid: names starting with __ are reserved by Prometheus",
+				Source:   "domain",
+				Severity: "error",
+				RuleID:   "const-label-name",
+			}},
+		},
+		{
+			name: "invalid name",
+			metrics: map[string]domain.Metric{
+				"requests_total": withConstLabels(counter("Requests"), "deploy:env", "production"),
+			},
+			want: []ValidationError{{
+				Path:     "services[orders].metrics[requests_total].constLabels[deploy:env]",
+				Message:  "Const label name 'deploy:env' is invalid: expected [a-zA-Z_][a-zA-Z0-9_]*",
+				Source:   "domain",
+				Severity: "error",
+				RuleID:   "const-label-name",
+			}},
+		},
+		{
+			name: "conflict with a label",
+			metrics: map[string]domain.Metric{
+				"requests_total": withConstLabels(counter("Requests", "method"), "method", "GET"),
+			},
+			want: []ValidationError{{
+				Path:     "services[orders].metrics[requests_total].constLabels[method]",
+				Message:  "Const label 'method' of metric 'http_server_requests_total' is also a label of the metric",
+				Source:   "domain",
+				Severity: "error",
+				RuleID:   "const-label-conflict",
+			}},
+		},
+		{
+			name: "value with quotes and backslashes",
+			metrics: map[string]domain.Metric{
+				"requests_total": withConstLabels(counter("Requests"), "team", `platform's "core" \ infra`),
+			},
+		},
+		{
+			name: "malformed env var reference",
+			metrics: map[string]domain.Metric{
+				"requests_total": withConstLabels(counter("Requests"), "region", "${REGION"),
+			},
+			want: []ValidationError{{
+				Path:     "services[orders].metrics[requests_total].constLabels[region]",
+				Message:  `Const label 'region' has a malformed environment variable reference "${REGION": the whole value must be ${VAR} or ${VAR:default}`,
+				Source:   "domain",
+				Severity: "error",
+				RuleID:   "env-var-syntax",
+			}},
+		},
+		{
+			name: "required env var",
+			metrics: map[string]domain.Metric{
+				"requests_total": withConstLabels(counter("Requests"), "region", "${REGION}"),
+			},
+			want: []ValidationError{{
+				Path:     "services[orders].metrics[requests_total].constLabels[region]",
+				Message:  "Const label 'region' reads REGION without a default: the generated .NET and Node.js code throws when the metric is recorded while it is unset, and Go uses an empty value",
+				Source:   "domain",
+				Severity: "warning",
+				RuleID:   "env-var-required",
+				Fix:      "Give a default with ${REGION:default}, or check that every deployment sets REGION (see promener env)",
+			}},
+		},
+		{
+			name: "different defaults",
+			metrics: map[string]domain.Metric{
+				"errors_total":   withConstLabels(counter("Errors"), "region", "${REGION:eu-west-1}"),
+				"requests_total": withConstLabels(counter("Requests"), "region", "${REGION:us-east-1}"),
+			},
+			want: []ValidationError{{
+				Path:     "services[orders].metrics[errors_total].constLabels[region]",
+				Message:  "Environment variable REGION has different defaults: eu-west-1, us-east-1",
+				Source:   "domain",
+				Severity: "warning",
+				RuleID:   "env-var-default-mismatch",
+				Fix:      "Use the same default in services[orders].metrics[errors_total].constLabels[region], services[orders].metrics[requests_total].constLabels[region]",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &domain.Specification{
+				Services: map[string]domain.Service{"orders": {Metrics: named(tt.metrics)}},
+			}
+			assert.Equal(t, tt.want, checkConstLabels(spec))
+		})
+	}
+}
@@ -55,6 +55,11 @@ func (e *CueExtractor) Extract(value cue.Value) (*domain.Specification, error) {
 		spec.Services[serviceName] = service
 	}
 
+	// Expand metric templates and shared labels into the metrics
+	if err := spec.ResolveReferences(); err != nil {
+		return nil, fmt.Errorf("failed to resolve references: %w", err)
+	}
+
 	// Perform domain validation
 	if err := spec.Validate(); err != nil {
 		return nil, fmt.Errorf("domain validation failed: %w", err)
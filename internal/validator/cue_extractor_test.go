@@ -350,6 +350,82 @@ services: {
 				}
 			},
 		},
+		{
+			name: "shared labels and metric templates",
+			cueContent: `
+version: "1.0.0"
+info: {
+	title: "Test"
+	version: "1.0.0"
+}
+labels: {
+	http_method: {
+		description: "HTTP method"
+		validations: ["value in ['GET', 'POST']"]
+	}
+}
+metricTemplates: {
+	http_server_base: {
+		namespace: "http"
+		subsystem: "server"
+		labelRefs: ["http_method"]
+	}
+}
+services: {
+	default: {
+		info: {
+			title: "Default Service"
+			version: "1.0.0"
+		}
+		metrics: {
+			requests_total: {
+				extends: "http_server_base"
+				type: "counter"
+				help: "Total requests"
+				labels: status: description: "HTTP status code"
+			}
+		}
+	}
+}`,
+			wantErr: false,
+			checks: func(t *testing.T, spec *domain.Specification) {
+				metric := spec.Services["default"].Metrics["requests_total"]
+
+				if metric.FullName() != "http_server_requests_total" {
+					t.Errorf("FullName() = %q, want %q", metric.FullName(), "http_server_requests_total")
+				}
+				if got := metric.Labels.ToStringSlice(); len(got) != 2 || got[0] != "http_method" || got[1] != "status" {
+					t.Errorf("Labels = %v, want [http_method status]", got)
+				}
+				if metric.Labels[0].Ref != "http_method" || len(metric.Labels[0].Validations) != 1 {
+					t.Errorf("Labels[0] = %+v, want the shared http_method label", metric.Labels[0])
+				}
+			},
+		},
+		{
+			name: "unknown metric template",
+			cueContent: `
+version: "1.0.0"
+info: {
+	title: "Test"
+	version: "1.0.0"
+}
+services: {
+	default: {
+		info: {
+			title: "Default Service"
+			version: "1.0.0"
+		}
+		metrics: {
+			requests_total: {
+				extends: "missing"
+				help: "Total requests"
+			}
+		}
+	}
+}`,
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
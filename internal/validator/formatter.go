@@ -5,6 +5,7 @@ package validator
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -54,6 +55,10 @@ func (f *Formatter) formatText(result *ValidationResult) string {
 	sb.WriteString("\n")
 	if !result.HasErrors() {
 		sb.WriteString("✓ Validation passed\n")
+		if len(result.Suppressed) > 0 {
+			sb.WriteString("\n")
+			f.writeSuppressed(&sb, result.Suppressed)
+		}
 		return sb.String()
 	}
 
@@ -63,10 +68,12 @@ func (f *Formatter) formatText(result *ValidationResult) string {
 	if len(result.DomainErrors) > 0 {
 		sb.WriteString(fmt.Sprintf("Domain Validation Errors (%d):\n", len(result.DomainErrors)))
 		for i, err := range result.DomainErrors {
-			sb.WriteString(fmt.Sprintf("  %d. %s\n", i+1, err.Message))
-			if err.Path != "" {
-				sb.WriteString(fmt.Sprintf("     Path: %s\n", err.Path))
+			severityStr := ""
+			if err.Severity != "" && err.Severity != "error" {
+				severityStr = fmt.Sprintf("[%s] ", strings.ToUpper(err.Severity))
 			}
+			sb.WriteString(fmt.Sprintf("  %d. %s%s\n", i+1, severityStr, err.Message))
+			f.writeRuleDetails(&sb, err)
 		}
 		sb.WriteString("\n")
 	}
@@ -76,6 +83,9 @@ func (f *Formatter) formatText(result *ValidationResult) string {
 		sb.WriteString(fmt.Sprintf("CUE Schema Validation Errors (%d):\n", len(result.CueErrors)))
 		for i, err := range result.CueErrors {
 			sb.WriteString(fmt.Sprintf("  %d. %s\n", i+1, err.Message))
+			if err.File != "" {
+				sb.WriteString(fmt.Sprintf("     File: %s\n", err.File))
+			}
 			if err.Path != "" {
 				sb.WriteString(fmt.Sprintf("     Path: %s\n", err.Path))
 			}
@@ -95,19 +105,81 @@ func (f *Formatter) formatText(result *ValidationResult) string {
 				severityStr = fmt.Sprintf("[%s] ", strings.ToUpper(err.Severity))
 			}
 			sb.WriteString(fmt.Sprintf("  %d. %s%s\n", i+1, severityStr, err.Message))
-			if err.Path != "" {
-				sb.WriteString(fmt.Sprintf("     Path: %s\n", err.Path))
+			f.writeRuleDetails(&sb, err)
+		}
+		sb.WriteString("\n")
+	}
+
+	// Workspace errors
+	if len(result.WorkspaceErrors) > 0 {
+		sb.WriteString(fmt.Sprintf("Workspace Errors (%d):\n", len(result.WorkspaceErrors)))
+		for i, err := range result.WorkspaceErrors {
+			severityStr := ""
+			if err.Severity != "" && err.Severity != "error" {
+				severityStr = fmt.Sprintf("[%s] ", strings.ToUpper(err.Severity))
 			}
+			sb.WriteString(fmt.Sprintf("  %d. %s%s\n", i+1, severityStr, err.Message))
+			f.writeRuleDetails(&sb, err)
 		}
 		sb.WriteString("\n")
 	}
 
+	if len(result.Suppressed) > 0 {
+		f.writeSuppressed(&sb, result.Suppressed)
+	}
+
 	// Summary
 	sb.WriteString(fmt.Sprintf("Total errors: %d\n", result.TotalErrors()))
 
 	return sb.String()
 }
 
+// writeRuleDetails writes the file, the path and the structured fields of a Rego or workspace error
+func (f *Formatter) writeRuleDetails(sb *strings.Builder, err ValidationError) {
+	if err.RuleID != "" {
+		rule := err.RuleID
+		if err.Category != "" {
+			rule += fmt.Sprintf(" (%s)", err.Category)
+		}
+		sb.WriteString(fmt.Sprintf("     Rule: %s\n", rule))
+	}
+	if err.File != "" {
+		sb.WriteString(fmt.Sprintf("     File: %s\n", err.File))
+	}
+	if err.Path != "" {
+		sb.WriteString(fmt.Sprintf("     Path: %s\n", err.Path))
+	}
+	if err.Fix != "" {
+		sb.WriteString(fmt.Sprintf("     Fix: %s\n", err.Fix))
+	}
+	if err.DocsURL != "" {
+		sb.WriteString(fmt.Sprintf("     Docs: %s\n", err.DocsURL))
+	}
+	if len(err.Metadata) > 0 {
+		keys := make([]string, 0, len(err.Metadata))
+		for key := range err.Metadata {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		pairs := make([]string, 0, len(keys))
+		for _, key := range keys {
+			pairs = append(pairs, fmt.Sprintf("%s=%v", key, err.Metadata[key]))
+		}
+		sb.WriteString(fmt.Sprintf("     Metadata: %s\n", strings.Join(pairs, ", ")))
+	}
+}
+
+// writeSuppressed writes the Rego errors suppressed by the specification
+func (f *Formatter) writeSuppressed(sb *strings.Builder, suppressed []ValidationError) {
+	sb.WriteString(fmt.Sprintf("Suppressed Policy Errors (%d):\n", len(suppressed)))
+	for i, err := range suppressed {
+		sb.WriteString(fmt.Sprintf("  %d. %s\n", i+1, err.Message))
+		f.writeRuleDetails(sb, err)
+	}
+	sb.WriteString("\n")
+}
+
 // formatJSON formats the validation result as JSON.
 func (f *Formatter) formatJSON(result *ValidationResult) (string, error) {
 	type jsonOutput struct {
@@ -116,6 +188,8 @@ func (f *Formatter) formatJSON(result *ValidationResult) (string, error) {
 		CueErrors    []ValidationError `json:"cue_errors"`
 		DomainErrors []ValidationError `json:"domain_errors"`
 		RegoErrors   []ValidationError `json:"rego_errors"`
+		Suppressed   []ValidationError `json:"suppressed"`
+		Workspace    []ValidationError `json:"workspace_errors,omitempty"`
 	}
 
 	output := jsonOutput{
@@ -124,6 +198,8 @@ func (f *Formatter) formatJSON(result *ValidationResult) (string, error) {
 		CueErrors:    result.CueErrors,
 		DomainErrors: result.DomainErrors,
 		RegoErrors:   result.RegoErrors,
+		Suppressed:   result.Suppressed,
+		Workspace:    result.WorkspaceErrors,
 	}
 
 	// Handle nil slices for cleaner JSON output
@@ -136,6 +212,9 @@ func (f *Formatter) formatJSON(result *ValidationResult) (string, error) {
 	if output.RegoErrors == nil {
 		output.RegoErrors = []ValidationError{}
 	}
+	if output.Suppressed == nil {
+		output.Suppressed = []ValidationError{}
+	}
 
 	bytes, err := json.MarshalIndent(output, "", "  ")
 	if err != nil {
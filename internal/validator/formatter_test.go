@@ -266,3 +266,156 @@ func TestValidationResult_TotalErrors(t *testing.T) {
 		t.Errorf("TotalErrors() = %d, expected %d", got, expected)
 	}
 }
+
+func TestFormatter_FormatText_RegoDetails(t *testing.T) {
+	f := NewFormatter(FormatText)
+
+	rule := ValidationError{
+		Path:     "services[api].metrics[requests]",
+		Message:  "Counter requests should end with _total",
+		Source:   "rego",
+		Severity: "warning",
+		RuleID:   "counter-total-suffix",
+		Category: "naming",
+		DocsURL:  "https://prometheus.io/docs/practices/naming/",
+		Fix:      "Rename the metric to requests_total",
+		Metadata: map[string]interface{}{"team": "platform", "since": "1.2.0"},
+	}
+	result := &ValidationResult{
+		RegoErrors: []ValidationError{rule},
+		Suppressed: []ValidationError{{Path: "services[api].metrics[legacy]", Message: "Counter legacy should end with _total", RuleID: "counter-total-suffix"}},
+	}
+
+	output, err := f.Format(result)
+	if err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"  1. [WARNING] Counter requests should end with _total\n",
+		"     Rule: counter-total-suffix (naming)\n",
+		"     Fix: Rename the metric to requests_total\n",
+		"     Docs: https://prometheus.io/docs/practices/naming/\n",
+		"     Metadata: since=1.2.0, team=platform\n",
+		"Suppressed Policy Errors (1):\n  1. Counter legacy should end with _total\n",
+		"Total errors: 1",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Output should contain %q, got:\n%s", want, output)
+		}
+	}
+
+	// Suppressed errors alone do not fail the validation
+	result.RegoErrors = nil
+	output, err = f.Format(result)
+	if err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	if !strings.Contains(output, "✓ Validation passed") || !strings.Contains(output, "Suppressed Policy Errors (1)") {
+		t.Errorf("Output should pass and list the suppressed errors, got:\n%s", output)
+	}
+}
+
+func TestFormatter_FormatJSON_RegoDetails(t *testing.T) {
+	f := NewFormatter(FormatJSON)
+
+	result := &ValidationResult{
+		RegoErrors: []ValidationError{{Message: "no owner", Source: "rego", Severity: "info", RuleID: "owner-required", Metadata: map[string]interface{}{"team": "platform"}}},
+		Suppressed: []ValidationError{{Message: "legacy", Source: "rego", RuleID: "counter-total-suffix"}},
+	}
+
+	output, err := f.Format(result)
+	if err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	var parsed struct {
+		RegoErrors []map[string]interface{} `json:"rego_errors"`
+		Suppressed []map[string]interface{} `json:"suppressed"`
+	}
+	if err := json.Unmarshal([]byte(output), &parsed); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+
+	if len(parsed.RegoErrors) != 1 || parsed.RegoErrors[0]["RuleID"] != "owner-required" {
+		t.Errorf("JSON should have the rule id of the rego error, got %v", parsed.RegoErrors)
+	}
+	if _, ok := parsed.RegoErrors[0]["DocsURL"]; ok {
+		t.Errorf("JSON should omit empty rule fields")
+	}
+	if len(parsed.Suppressed) != 1 || parsed.Suppressed[0]["RuleID"] != "counter-total-suffix" {
+		t.Errorf("JSON should have the suppressed errors, got %v", parsed.Suppressed)
+	}
+}
+
+func TestFormatter_FormatText_Workspace(t *testing.T) {
+	f := NewFormatter(FormatText)
+
+	result := &ValidationResult{
+		CueErrors: []ValidationError{{Message: "invalid type", Path: "services.orders", File: "a.cue"}},
+		WorkspaceErrors: []ValidationError{{
+			Path:     "services[orders].metrics[requests]",
+			Message:  "Metric 'requests' is defined identically in a.cue and b.cue",
+			Source:   "workspace",
+			Severity: "info",
+			RuleID:   "shared-metric",
+			File:     "a.cue",
+		}},
+	}
+
+	output, err := f.Format(result)
+	if err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"  1. invalid type\n     File: a.cue\n     Path: services.orders\n",
+		"Workspace Errors (1):\n  1. [INFO] Metric 'requests' is defined identically in a.cue and b.cue\n",
+		"     Rule: shared-metric\n     File: a.cue\n     Path: services[orders].metrics[requests]\n",
+		"Total errors: 2",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Output should contain %q, got:\n%s", want, output)
+		}
+	}
+
+	output, err = NewFormatter(FormatJSON).Format(result)
+	if err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+	if !strings.Contains(output, `"workspace_errors"`) || !strings.Contains(output, `"File": "a.cue"`) {
+		t.Errorf("JSON should have the workspace errors and the files, got:\n%s", output)
+	}
+}
+
+func TestFormatter_FormatText_DomainDetails(t *testing.T) {
+	f := NewFormatter(FormatText)
+
+	result := &ValidationResult{
+		DomainErrors: []ValidationError{
+			{Message: "domain validation failed: metric subsystem is required", Source: "domain", Severity: "error"},
+			{
+				Path:     "services[orders].metrics[requests_total].constLabels[region]",
+				Message:  "Const label 'region' reads REGION without a default",
+				Source:   "domain",
+				Severity: "warning",
+				RuleID:   "env-var-required",
+				Fix:      "Give a default with ${REGION:default}",
+			},
+		},
+	}
+
+	output, err := f.Format(result)
+	if err != nil {
+		t.Fatalf("Format failed: %v", err)
+	}
+
+	for _, want := range []string{
+		"Domain Validation Errors (2):\n  1. domain validation failed: metric subsystem is required\n  2. [WARNING] Const label 'region' reads REGION without a default\n",
+		"     Rule: env-var-required\n     Path: services[orders].metrics[requests_total].constLabels[region]\n     Fix: Give a default with ${REGION:default}\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Output should contain %q, got:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package validator
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LockFile is the default name of the lock file of the remote rule sources
+const LockFile = "promener.lock"
+
+// lockVersion is the version of the lock file format
+const lockVersion = 1
+
+// Lock pins the content of remote sources: the commit of Git sources and the SHA-256 of HTTP
+// downloads. When a resolver has a lock, remote content that does not match it, or remote sources
+// missing from it, are rejected.
+type Lock struct {
+	Version int            `yaml:"version"`
+	Sources []LockedSource `yaml:"sources"`
+}
+
+// LockedSource is the pinned content of a remote source
+type LockedSource struct {
+	Source string `yaml:"source"` // Source without the path inside the repository or the archive
+	URL    string `yaml:"url"`
+	Ref    string `yaml:"ref,omitempty"`
+	Commit string `yaml:"commit,omitempty"` // Git sources
+	SHA256 string `yaml:"sha256,omitempty"` // HTTP sources
+}
+
+// ReadLock reads a lock file. The error wraps os.ErrNotExist when the file does not exist.
+func ReadLock(path string) (*Lock, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lock file: %w", err)
+	}
+
+	var lock Lock
+	if err := yaml.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse lock file %s: %w", path, err)
+	}
+	if lock.Version != lockVersion {
+		return nil, fmt.Errorf("unsupported lock file version %d in %s", lock.Version, path)
+	}
+	return &lock, nil
+}
+
+// Write writes the lock file, with its sources sorted
+func (l *Lock) Write(path string) error {
+	l.Version = lockVersion
+	slices.SortFunc(l.Sources, func(a, b LockedSource) int {
+		return strings.Compare(a.Source, b.Source)
+	})
+
+	var buf bytes.Buffer
+	buf.WriteString("# Code generated by promener rules update. DO NOT EDIT.\n")
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(l); err != nil {
+		return fmt.Errorf("failed to marshal lock file: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write lock file: %w", err)
+	}
+	return nil
+}
+
+// Find returns the pinned content of a source, or nil if the source is not locked
+func (l *Lock) Find(source string) *LockedSource {
+	for i := range l.Sources {
+		if l.Sources[i].Source == source {
+			return &l.Sources[i]
+		}
+	}
+	return nil
+}
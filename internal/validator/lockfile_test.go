@@ -0,0 +1,42 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLock_WriteRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), LockFile)
+
+	lock := &Lock{Sources: []LockedSource{
+		{Source: "https://example.com/rules.tar.gz", URL: "https://example.com/rules.tar.gz", SHA256: "abc"},
+		{Source: "github:org/rules@v1.0.0", URL: "https://github.com/org/rules.git", Ref: "v1.0.0", Commit: "0123"},
+	}}
+	require.NoError(t, lock.Write(path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# Code generated by promener rules update. DO NOT EDIT.\nversion: 1\n")
+
+	read, err := ReadLock(path)
+	require.NoError(t, err)
+	assert.Equal(t, lock, read)
+	assert.Equal(t, "0123", read.Find("github:org/rules@v1.0.0").Commit)
+	assert.Nil(t, read.Find("github:org/rules@v2.0.0"))
+}
+
+func TestReadLock_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := ReadLock(filepath.Join(dir, LockFile))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	path := filepath.Join(dir, "future.lock")
+	require.NoError(t, os.WriteFile(path, []byte("version: 2\nsources: []\n"), 0644))
+	_, err = ReadLock(path)
+	assert.ErrorContains(t, err, "unsupported lock file version 2")
+}
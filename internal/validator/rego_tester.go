@@ -0,0 +1,80 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/tester"
+)
+
+// RuleTestResult is the outcome of a test_ rule of the Rego rule sources
+type RuleTestResult struct {
+	Package  string // Package of the test, without the data. prefix
+	Name     string
+	Location string // file:line of the test rule
+	Fail     bool
+	Skip     bool
+	Error    error
+	Duration time.Duration
+	Output   []byte // Output of the print calls of the test
+}
+
+// Pass returns true if the test neither failed, errored nor was skipped
+func (r RuleTestResult) Pass() bool {
+	return !r.Fail && !r.Skip && r.Error == nil
+}
+
+// Test runs the test_ rules of the rule sources, usually in *_test.rego files. Only the tests whose
+// package and name match the run regular expression are run when it is not empty.
+func (v *RegoValidator) Test(ctx context.Context, run string) ([]RuleTestResult, error) {
+	regoFiles, err := v.loadFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(regoFiles) == 0 {
+		return nil, nil
+	}
+
+	modules, store, err := tester.LoadWithRegoVersion(regoFiles, nil, ast.RegoV1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rules: %w", err)
+	}
+
+	txn, err := store.NewTransaction(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer store.Abort(ctx, txn)
+
+	runner := tester.NewRunner().
+		SetStore(store).
+		SetModules(modules).
+		SetDefaultRegoVersion(ast.RegoV1).
+		CapturePrintOutput(true).
+		Filter(run)
+	ch, err := runner.RunTests(ctx, txn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run rule tests: %w", err)
+	}
+
+	var results []RuleTestResult
+	for res := range ch {
+		result := RuleTestResult{
+			Package:  strings.TrimPrefix(res.Package, "data."),
+			Name:     res.Name,
+			Fail:     res.Fail,
+			Skip:     res.Skip,
+			Error:    res.Error,
+			Duration: res.Duration,
+			Output:   res.Output,
+		}
+		if res.Location != nil {
+			result.Location = fmt.Sprintf("%s:%d", res.Location.File, res.Location.Row)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
@@ -0,0 +1,72 @@
+package validator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const policyTests = `package PromenerPolicy
+
+test_counter_reported if {
+	count(PromenerPolicy) == 1 with input as {"services": {"api": {"metrics": {"requests": {"type": "counter"}}}}}
+}
+
+test_counter_not_reported if {
+	count(PromenerPolicy) == 1 with input as {"services": {"api": {"metrics": {"requests_total": {"type": "counter"}}}}}
+}
+`
+
+func TestRegoValidator_Test(t *testing.T) {
+	dir := writePolicies(t)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "naming_test.rego"), []byte(policyTests), 0644))
+	v := NewRegoValidator([]string{dir})
+
+	results, err := v.Test(context.Background(), "")
+	require.NoError(t, err)
+
+	outcomes := map[string]bool{}
+	for _, result := range results {
+		assert.Equal(t, "PromenerPolicy", result.Package)
+		assert.Contains(t, result.Location, "naming_test.rego:")
+		outcomes[result.Name] = result.Pass()
+	}
+	assert.Equal(t, map[string]bool{
+		"test_counter_reported":     true,
+		"test_counter_not_reported": false,
+	}, outcomes)
+
+	results, err = v.Test(context.Background(), "not_reported")
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].Fail)
+}
+
+func TestRegoValidator_Test_CompileError(t *testing.T) {
+	dir := writePolicies(t)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "naming_test.rego"), []byte("package PromenerPolicy\n\ntest_undefined if {\n\tundefined_rule == 1\n}\n"), 0644))
+
+	_, err := NewRegoValidator([]string{dir}).Test(context.Background(), "")
+	assert.ErrorContains(t, err, "undefined_rule is unsafe")
+}
+
+func TestRegoValidator_Test_NoRules(t *testing.T) {
+	results, err := NewRegoValidator([]string{t.TempDir()}).Test(context.Background(), "")
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestRegoValidator_Test_Builtin(t *testing.T) {
+	t.Setenv("PROMENER_CACHE_DIR", t.TempDir())
+
+	results, err := NewRegoValidator([]string{BuiltinSource}).Test(context.Background(), "")
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	for _, result := range results {
+		assert.True(t, result.Pass(), "%s (%s): %v", result.Name, result.Location, result.Error)
+	}
+}
@@ -3,31 +3,106 @@ package validator
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/open-policy-agent/opa/ast"
 	"github.com/open-policy-agent/opa/rego"
 )
 
+// DefaultRegoQuery is the query evaluated when no query is configured: the PromenerPolicy rule
+// of the PromenerPolicy package
+const DefaultRegoQuery = "data.PromenerPolicy.PromenerPolicy"
+
 // RegoValidator validates specifications using Rego rules.
 type RegoValidator struct {
 	rulesDirs []string
+	queries   []string
+	lock      *Lock
+	offline   bool
 }
 
 // NewRegoValidator creates a new Rego validator.
 func NewRegoValidator(rulesDirs []string) *RegoValidator {
 	return &RegoValidator{
 		rulesDirs: rulesDirs,
+		queries:   []string{DefaultRegoQuery},
 	}
 }
 
+// SetQueries sets the queries evaluated against the specification, references to rules returning
+// a set of results such as data.acme.metrics.violations. Each query can come from another package.
+func (v *RegoValidator) SetQueries(queries []string) error {
+	if err := checkQueries(queries); err != nil {
+		return err
+	}
+	if len(queries) == 0 {
+		queries = []string{DefaultRegoQuery}
+	}
+	v.queries = queries
+	return nil
+}
+
+// checkQueries returns an error if a query is not a reference to a rule of a package
+func checkQueries(queries []string) error {
+	for _, query := range queries {
+		if _, err := ast.ParseRef(query); err != nil || !strings.HasPrefix(query, "data.") {
+			return fmt.Errorf("invalid Rego query %q: expected a reference like data.<package>.<rule>", query)
+		}
+	}
+	return nil
+}
+
 // Validate runs Rego rules against the provided specification.
 func (v *RegoValidator) Validate(ctx context.Context, input interface{}) ([]ValidationError, error) {
-	if len(v.rulesDirs) == 0 {
+	regoFiles, err := v.loadFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(regoFiles) == 0 {
 		return nil, nil
 	}
 
-	// Use RuleSourceResolver to load rules from all sources (local, HTTP, Git)
+	var validationErrors []ValidationError
+	for _, q := range v.queries {
+		// Prepare Rego evaluation with Rego v1 syntax support
+		query, err := rego.New(
+			rego.Query(q),
+			rego.Load(regoFiles, nil),
+			rego.SetRegoVersion(ast.RegoV1),
+		).PrepareForEval(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prepare rego query %s: %w", q, err)
+		}
+
+		// Evaluate rules
+		results, err := query.Eval(ctx, rego.EvalInput(input))
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate rego query %s: %w", q, err)
+		}
+
+		for _, result := range results {
+			for _, expr := range result.Expressions {
+				if val, ok := expr.Value.([]interface{}); ok {
+					for _, item := range val {
+						if res, ok := item.(map[string]interface{}); ok {
+							if validationError, ok := newRegoError(res); ok {
+								validationErrors = append(validationErrors, validationError)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return validationErrors, nil
+}
+
+// loadFiles returns the Rego files of all the rule sources (local, HTTP, Git)
+func (v *RegoValidator) loadFiles(ctx context.Context) ([]string, error) {
 	resolver := NewRuleSourceResolver()
+	resolver.SetLock(v.lock)
+	resolver.SetOffline(v.offline)
 
 	var regoFiles []string
 	for _, source := range v.rulesDirs {
@@ -37,62 +112,32 @@ func (v *RegoValidator) Validate(ctx context.Context, input interface{}) ([]Vali
 		}
 		regoFiles = append(regoFiles, files...)
 	}
+	return regoFiles, nil
+}
 
-	if len(regoFiles) == 0 {
-		return nil, nil
-	}
-
-	// Prepare Rego evaluation with Rego v1 syntax support
-	query, err := rego.New(
-		rego.Query("data.PromenerPolicy.PromenerPolicy"),
-		rego.Load(regoFiles, nil),
-		rego.SetRegoVersion(ast.RegoV1),
-	).PrepareForEval(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to prepare rego query: %w", err)
+// newRegoError converts a result object of a rule, ignored when it has no message
+func newRegoError(res map[string]interface{}) (ValidationError, bool) {
+	str := func(key string) string {
+		s, _ := res[key].(string)
+		return s
 	}
 
-	// Evaluate rules
-	results, err := query.Eval(ctx, rego.EvalInput(input))
-	if err != nil {
-		return nil, fmt.Errorf("failed to evaluate rego rules: %w", err)
+	severity := str("severity")
+	if severity == "" {
+		severity = "error"
 	}
 
-	var validationErrors []ValidationError
-	for _, result := range results {
-		for _, expr := range result.Expressions {
-			// Simplified style using 'path' and 'message'
-			if val, ok := expr.Value.([]interface{}); ok {
-				for _, item := range val {
-					if res, ok := item.(map[string]interface{}); ok {
-						msg := ""
-						if m, ok := res["message"].(string); ok {
-							msg = m
-						}
+	metadata, _ := res["metadata"].(map[string]interface{})
 
-						path := ""
-						if p, ok := res["path"].(string); ok {
-							path = p
-						}
-
-						severity := "error"
-						if s, ok := res["severity"].(string); ok {
-							severity = s
-						}
-
-						if msg != "" {
-							validationErrors = append(validationErrors, ValidationError{
-								Path:     path,
-								Message:  msg,
-								Source:   "rego",
-								Severity: severity,
-							})
-						}
-					}
-				}
-			}
-		}
-	}
-
-	return validationErrors, nil
+	return ValidationError{
+		Path:     str("path"),
+		Message:  str("message"),
+		Source:   "rego",
+		Severity: severity,
+		RuleID:   str("rule_id"),
+		Category: str("category"),
+		DocsURL:  str("docs_url"),
+		Fix:      str("fix"),
+		Metadata: metadata,
+	}, str("message") != ""
 }
@@ -0,0 +1,99 @@
+package validator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const namingPolicy = `package PromenerPolicy
+
+PromenerPolicy contains {
+	"message": sprintf("Counter %s should end with _total", [key]),
+	"path": sprintf("services[%s].metrics[%s]", [service, key]),
+	"severity": "warning",
+	"rule_id": "counter-total-suffix",
+	"category": "naming",
+	"docs_url": "https://prometheus.io/docs/practices/naming/",
+	"fix": "Add the _total suffix",
+	"metadata": {"since": "1.2.0"},
+} if {
+	some service, key
+	input.services[service].metrics[key].type == "counter"
+	not endswith(key, "_total")
+}
+`
+
+const ownershipPolicy = `package acme.ownership
+
+violations contains {"message": sprintf("Metric %s has no owner", [key])} if {
+	some service, key
+	metric := input.services[service].metrics[key]
+	not metric.owner
+}
+
+ignored contains {"path": "no message"}
+`
+
+func writePolicies(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "naming.rego"), []byte(namingPolicy), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ownership.rego"), []byte(ownershipPolicy), 0644))
+	return dir
+}
+
+var policyInput = map[string]interface{}{
+	"services": map[string]interface{}{
+		"orders": map[string]interface{}{
+			"metrics": map[string]interface{}{
+				"requests": map[string]interface{}{"type": "counter"},
+			},
+		},
+	},
+}
+
+func TestRegoValidator_DefaultQuery(t *testing.T) {
+	v := NewRegoValidator([]string{writePolicies(t)})
+
+	errs, err := v.Validate(context.Background(), policyInput)
+	require.NoError(t, err)
+	assert.Equal(t, []ValidationError{{
+		Path:     "services[orders].metrics[requests]",
+		Message:  "Counter requests should end with _total",
+		Source:   "rego",
+		Severity: "warning",
+		RuleID:   "counter-total-suffix",
+		Category: "naming",
+		DocsURL:  "https://prometheus.io/docs/practices/naming/",
+		Fix:      "Add the _total suffix",
+		Metadata: map[string]interface{}{"since": "1.2.0"},
+	}}, errs)
+}
+
+func TestRegoValidator_Queries(t *testing.T) {
+	v := NewRegoValidator([]string{writePolicies(t)})
+	require.NoError(t, v.SetQueries([]string{DefaultRegoQuery, "data.acme.ownership.violations", "data.acme.ownership.ignored", "data.acme.undefined"}))
+
+	errs, err := v.Validate(context.Background(), policyInput)
+	require.NoError(t, err)
+	require.Len(t, errs, 2)
+	assert.Equal(t, "counter-total-suffix", errs[0].RuleID)
+	assert.Equal(t, "Metric requests has no owner", errs[1].Message)
+	assert.Equal(t, "error", errs[1].Severity, "default severity")
+}
+
+func TestRegoValidator_SetQueries_Invalid(t *testing.T) {
+	v := NewRegoValidator(nil)
+	for _, query := range []string{"acme.ownership", "data.acme[", "input.services"} {
+		assert.ErrorContains(t, v.SetQueries([]string{query}), "invalid Rego query", query)
+	}
+
+	require.NoError(t, v.SetQueries(nil))
+	assert.Equal(t, []string{DefaultRegoQuery}, v.queries)
+}
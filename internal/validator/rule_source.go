@@ -8,18 +8,23 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
+	"maps"
 	"net/http"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/jycamier/promener/rules"
 	"gopkg.in/yaml.v3"
 )
 
@@ -30,14 +35,29 @@ const (
 	SourceLocal SourceType = "local"
 	SourceHTTP  SourceType = "http"
 	SourceGit   SourceType = "git"
+
+	// SourceBuiltin is the policy pack embedded in the binary
+	SourceBuiltin SourceType = "builtin"
+)
+
+const (
+	// BuiltinSource enables the policy pack embedded in the binary
+	BuiltinSource = "builtin"
+
+	// BuiltinStrictSource enables the embedded policy pack, reporting every violation as an error
+	BuiltinStrictSource = "builtin:strict"
 )
 
 // ParsedSource contains the parsed information from a source string.
 type ParsedSource struct {
 	Type     SourceType
+	Source   string // source without the path, key of the lock
 	URL      string
-	Ref      string // branch or tag for Git
+	Ref      string // branch, tag or commit for Git
+	Branch   bool   // ref given after "#", always a branch
 	Host     string // github, gitlab, bitbucket for auth
+	Path     string // file or directory inside a Git repository or an archive, after "//"
+	Strict   bool   // builtin:strict, every violation of the embedded rules is an error
 	CacheKey string
 }
 
@@ -46,6 +66,8 @@ type CacheMetadata struct {
 	Source    string    `json:"source"`
 	FetchedAt time.Time `json:"fetched_at"`
 	TTL       string    `json:"ttl"`
+	Commit    string    `json:"commit,omitempty"` // commit checked out for Git sources
+	SHA256    string    `json:"sha256,omitempty"` // digest of the download for HTTP sources
 }
 
 // RuleSourceConfig represents the rules section in .promener.yaml
@@ -53,11 +75,15 @@ type RuleSourceConfig struct {
 	Rules []string `yaml:"rules"`
 }
 
-// RuleSourceResolver loads Rego rules from various sources.
+// RuleSourceResolver loads Rego rules from various sources, and resolves the sources of CUE
+// specifications the same way.
 type RuleSourceResolver struct {
 	cacheDir string
 	cacheTTL time.Duration
 	visited  map[string]bool
+	lock     *Lock
+	offline  bool
+	resolved map[string]LockedSource // remote sources fetched or read from the cache
 }
 
 // gitHosts maps shorthand prefixes to Git URLs.
@@ -68,7 +94,10 @@ var gitHosts = map[string]string{
 }
 
 // gitSourcePattern matches git source formats like "github:org/repo@tag" or "github:org/repo#branch"
-var gitSourcePattern = regexp.MustCompile(`^(github|gitlab|bitbucket):([^@#]+)(?:[@#](.+))?$`)
+var gitSourcePattern = regexp.MustCompile(`^(github|gitlab|bitbucket):([^@#]+)(?:([@#])(.+))?$`)
+
+// commitPattern matches a full commit SHA given as Git ref
+var commitPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
 
 // NewRuleSourceResolver creates a new resolver with default settings.
 func NewRuleSourceResolver() *RuleSourceResolver {
@@ -76,6 +105,7 @@ func NewRuleSourceResolver() *RuleSourceResolver {
 		cacheDir: getCacheDir(),
 		cacheTTL: time.Hour,
 		visited:  make(map[string]bool),
+		resolved: make(map[string]LockedSource),
 	}
 }
 
@@ -91,13 +121,36 @@ func getCacheDir() string {
 	return filepath.Join(home, ".promener", "cache")
 }
 
-// ParseSource parses a source string and returns its components.
+// ParseSource parses a source string and returns its components. Git and HTTP sources can point
+// to a path inside the repository or the archive after "//", e.g.
+// "github:org/specs@v1.2.0//services/orders.cue".
 func ParseSource(source string) (*ParsedSource, error) {
+	// Check for the embedded policy pack
+	if source == BuiltinSource || source == BuiltinStrictSource {
+		return &ParsedSource{
+			Type:     SourceBuiltin,
+			Source:   source,
+			URL:      source,
+			Strict:   source == BuiltinStrictSource,
+			CacheKey: hashSource(source),
+		}, nil
+	}
+
 	// Check for Git shorthand (github:, gitlab:, bitbucket:)
 	if matches := gitSourcePattern.FindStringSubmatch(source); matches != nil {
+		repository, subPath := splitSubPath(source)
+		if subPath != "" {
+			parsed, err := ParseSource(repository)
+			if err != nil {
+				return nil, err
+			}
+			parsed.Path = subPath
+			return parsed, nil
+		}
+
 		host := matches[1]
 		path := matches[2]
-		ref := matches[3]
+		ref := matches[4]
 		if ref == "" {
 			ref = "HEAD"
 		}
@@ -109,8 +162,10 @@ func ParseSource(source string) (*ParsedSource, error) {
 
 		return &ParsedSource{
 			Type:     SourceGit,
+			Source:   source,
 			URL:      fmt.Sprintf(urlTemplate, path),
 			Ref:      ref,
+			Branch:   matches[3] == "#",
 			Host:     host,
 			CacheKey: hashSource(source),
 		}, nil
@@ -118,10 +173,13 @@ func ParseSource(source string) (*ParsedSource, error) {
 
 	// Check for HTTP/HTTPS URLs
 	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		url, subPath := splitSubPath(source)
 		return &ParsedSource{
 			Type:     SourceHTTP,
-			URL:      source,
-			CacheKey: hashSource(source),
+			Source:   url,
+			URL:      url,
+			Path:     subPath,
+			CacheKey: hashSource(url),
 		}, nil
 	}
 
@@ -133,11 +191,39 @@ func ParseSource(source string) (*ParsedSource, error) {
 
 	return &ParsedSource{
 		Type:     SourceLocal,
+		Source:   source,
 		URL:      absPath,
 		CacheKey: hashSource(absPath),
 	}, nil
 }
 
+// splitSubPath splits a remote source on the first "//" after its scheme, into the source of the
+// repository or archive and the path inside it
+func splitSubPath(source string) (string, string) {
+	start := 0
+	if i := strings.Index(source, "://"); i >= 0 {
+		start = i + len("://")
+	}
+	i := strings.Index(source[start:], "//")
+	if i < 0 {
+		return source, ""
+	}
+	return source[:start+i], strings.Trim(source[start+i+len("//"):], "/")
+}
+
+// IsArchive returns true if the source is an HTTP archive, extracted when fetched
+func (p *ParsedSource) IsArchive() bool {
+	if p.Type != SourceHTTP {
+		return false
+	}
+	for _, ext := range []string{".tar.gz", ".tgz", ".zip"} {
+		if strings.HasSuffix(p.URL, ext) {
+			return true
+		}
+	}
+	return false
+}
+
 // hashSource creates a SHA256 hash of the source for cache key.
 func hashSource(source string) string {
 	h := sha256.New()
@@ -159,7 +245,7 @@ func (r *RuleSourceResolver) Load(ctx context.Context, source string) ([]string,
 	r.visited[parsed.CacheKey] = true
 
 	// Resolve source to a local directory
-	dir, err := r.resolveToDir(ctx, parsed)
+	dir, err := r.resolvePath(ctx, parsed)
 	if err != nil {
 		return nil, err
 	}
@@ -174,6 +260,76 @@ func (r *RuleSourceResolver) Load(ctx context.Context, source string) ([]string,
 	return r.collectRegoFiles(dir)
 }
 
+// SetLock sets the lock the content of the remote sources must match. Remote sources missing
+// from the lock are rejected.
+func (r *RuleSourceResolver) SetLock(lock *Lock) {
+	r.lock = lock
+}
+
+// SetOffline restricts the resolver to the cached content of the remote sources, whatever its age
+func (r *RuleSourceResolver) SetOffline(offline bool) {
+	r.offline = offline
+}
+
+// Resolved returns a lock of the remote sources resolved so far
+func (r *RuleSourceResolver) Resolved() *Lock {
+	lock := &Lock{Version: lockVersion, Sources: []LockedSource{}}
+	for _, source := range r.resolved {
+		lock.Sources = append(lock.Sources, source)
+	}
+	slices.SortFunc(lock.Sources, func(a, b LockedSource) int {
+		return strings.Compare(a.Source, b.Source)
+	})
+	return lock
+}
+
+// SetCacheTTL sets how long fetched HTTP and Git sources are reused before being fetched again.
+// With a zero TTL, the sources are fetched at each resolution, even when the cache holds the
+// locked content.
+func (r *RuleSourceResolver) SetCacheTTL(ttl time.Duration) {
+	r.cacheTTL = ttl
+}
+
+// Resolve fetches a source and returns its local path: the directory of a local, Git or archive
+// source, the file of a local file or single file download, or the path inside the repository or
+// the archive. Git repositories and archives are kept whole, so that the files next to the path
+// (CUE imports, cue.mod) are available.
+func (r *RuleSourceResolver) Resolve(ctx context.Context, source string) (string, error) {
+	parsed, err := ParseSource(source)
+	if err != nil {
+		return "", err
+	}
+	return r.resolvePath(ctx, parsed)
+}
+
+// resolvePath resolves a parsed source to its local path
+func (r *RuleSourceResolver) resolvePath(ctx context.Context, parsed *ParsedSource) (string, error) {
+	dir, err := r.resolveToDir(ctx, parsed)
+	if err != nil {
+		return "", err
+	}
+
+	if parsed.Type == SourceHTTP && !parsed.IsArchive() {
+		if parsed.Path != "" {
+			return "", fmt.Errorf("a path can only be given inside a Git repository or an archive: %s", parsed.URL)
+		}
+		return filepath.Join(dir, filepath.Base(parsed.URL)), nil
+	}
+	if parsed.Path == "" {
+		return dir, nil
+	}
+
+	// Security: prevent path traversal
+	path := filepath.Join(dir, filepath.FromSlash(parsed.Path))
+	if !strings.HasPrefix(path, filepath.Clean(dir)+string(os.PathSeparator)) {
+		return "", fmt.Errorf("invalid path in source: %s", parsed.Path)
+	}
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("path %s not found in %s", parsed.Path, parsed.URL)
+	}
+	return path, nil
+}
+
 // resolveToDir resolves a source to a local directory.
 func (r *RuleSourceResolver) resolveToDir(ctx context.Context, parsed *ParsedSource) (string, error) {
 	switch parsed.Type {
@@ -189,11 +345,72 @@ func (r *RuleSourceResolver) resolveToDir(ctx context.Context, parsed *ParsedSou
 	case SourceGit:
 		return r.cloneGit(ctx, parsed)
 
+	case SourceBuiltin:
+		return r.extractBuiltin(parsed)
+
 	default:
 		return "", fmt.Errorf("unknown source type: %s", parsed.Type)
 	}
 }
 
+// extractBuiltin writes the embedded policy pack to the cache, in a directory named after its
+// content so that each version of the binary uses its own rules. Strict mode adds a strict.rego
+// file raising the severity of every violation.
+func (r *RuleSourceResolver) extractBuiltin(parsed *ParsedSource) (string, error) {
+	files, err := fs.Glob(rules.FS, "*.rego")
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	for _, file := range files {
+		data, err := rules.FS.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s\x00%d\x00", file, len(data))
+		h.Write(data)
+	}
+	name := "builtin-" + hex.EncodeToString(h.Sum(nil))[:16]
+	if parsed.Strict {
+		name += "-strict"
+	}
+
+	dir := filepath.Join(r.cacheDir, name)
+	if _, err := os.Stat(dir); err == nil {
+		return dir, nil
+	}
+
+	if err := os.MkdirAll(r.cacheDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	tmpDir, err := os.MkdirTemp(r.cacheDir, "."+name+"-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	for _, file := range files {
+		data, _ := rules.FS.ReadFile(file)
+		if err := os.WriteFile(filepath.Join(tmpDir, file), data, 0644); err != nil {
+			return "", fmt.Errorf("failed to write built-in rules: %w", err)
+		}
+	}
+	if parsed.Strict {
+		if err := os.WriteFile(filepath.Join(tmpDir, "strict.rego"), []byte("package PromenerPolicy\n\nstrict := true\n"), 0644); err != nil {
+			return "", fmt.Errorf("failed to write built-in rules: %w", err)
+		}
+	}
+
+	// Another process may have extracted the same rules in the meantime
+	if err := os.Rename(tmpDir, dir); err != nil {
+		if _, statErr := os.Stat(dir); statErr != nil {
+			return "", fmt.Errorf("failed to write built-in rules: %w", err)
+		}
+	}
+	return dir, nil
+}
+
 // loadFromConfig reads .promener.yaml and resolves rules recursively.
 func (r *RuleSourceResolver) loadFromConfig(ctx context.Context, baseDir, configPath string) ([]string, error) {
 	data, err := os.ReadFile(configPath)
@@ -240,110 +457,266 @@ func (r *RuleSourceResolver) collectRegoFiles(dir string) ([]string, error) {
 	return files, err
 }
 
-// fetchHTTP downloads and extracts rules from an HTTP source.
+// fetchHTTP downloads and extracts rules from an HTTP source. The cache keeps the download,
+// hashed and extracted again each time it is used, so that the content matches the lock file even
+// when the cache was modified.
 func (r *RuleSourceResolver) fetchHTTP(ctx context.Context, parsed *ParsedSource) (string, error) {
 	cacheDir := filepath.Join(r.cacheDir, "http", parsed.CacheKey)
+	download := filepath.Join(cacheDir, "download")
+	contentDir := filepath.Join(cacheDir, "content")
+
+	// Use the cache when valid
+	if meta := r.cachedContent(cacheDir, parsed); meta != nil {
+		if digest, err := hashFile(download); err == nil {
+			if err := r.checkLock(parsed, "", digest); err != nil {
+				return "", err
+			}
+			if err := extractDownload(parsed, download, contentDir); err != nil {
+				return "", err
+			}
+			return contentDir, nil
+		}
+	}
+	if r.offline {
+		return "", fmt.Errorf("%s is not in the cache, run once without --offline", parsed.Source)
+	}
 
-	// Check cache validity
-	if r.isCacheValid(cacheDir) {
-		return cacheDir, nil
+	// Download to a temporary file, to check its digest before replacing the cache
+	if err := os.MkdirAll(filepath.Dir(cacheDir), 0755); err != nil {
+		return "", fmt.Errorf("failed to create cache dir: %w", err)
+	}
+	tmpFile, digest, err := r.download(ctx, filepath.Dir(cacheDir), parsed.URL)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmpFile)
+
+	if err := r.checkLock(parsed, "", digest); err != nil {
+		return "", err
 	}
 
-	// Create cache directory
+	// Replace the cache directory
+	os.RemoveAll(cacheDir)
 	if err := os.MkdirAll(cacheDir, 0755); err != nil {
 		return "", fmt.Errorf("failed to create cache dir: %w", err)
 	}
-
-	// Download
-	req, err := http.NewRequestWithContext(ctx, "GET", parsed.URL, nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
+	if err := os.Rename(tmpFile, download); err != nil {
+		return "", fmt.Errorf("failed to cache %s: %w", parsed.URL, err)
+	}
+	if err := extractDownload(parsed, download, contentDir); err != nil {
+		return "", err
 	}
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return "", fmt.Errorf("failed to fetch %s: %w", parsed.URL, err)
+	// Write cache metadata
+	r.writeCacheMetadata(cacheDir, parsed.URL, "", digest)
+
+	return contentDir, nil
+}
+
+// extractDownload replaces dest with the content of the download of an HTTP source: the files
+// of an archive, or the downloaded file itself
+func extractDownload(parsed *ParsedSource, download, dest string) error {
+	os.RemoveAll(dest)
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return fmt.Errorf("failed to create cache dir: %w", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("HTTP %d for %s", resp.StatusCode, parsed.URL)
+	in, err := os.Open(download)
+	if err != nil {
+		return err
 	}
+	defer in.Close()
 
 	// Extract based on content type or URL
 	if strings.HasSuffix(parsed.URL, ".tar.gz") || strings.HasSuffix(parsed.URL, ".tgz") {
-		if err := extractTarGz(resp.Body, cacheDir); err != nil {
-			return "", fmt.Errorf("failed to extract tar.gz: %w", err)
+		if err := extractTarGz(in, dest); err != nil {
+			return fmt.Errorf("failed to extract tar.gz: %w", err)
 		}
 	} else if strings.HasSuffix(parsed.URL, ".zip") {
-		if err := extractZip(resp.Body, cacheDir); err != nil {
-			return "", fmt.Errorf("failed to extract zip: %w", err)
+		if err := extractZip(in, dest); err != nil {
+			return fmt.Errorf("failed to extract zip: %w", err)
 		}
-	} else if strings.HasSuffix(parsed.URL, ".rego") {
+	} else if strings.HasSuffix(parsed.URL, ".rego") || strings.HasSuffix(parsed.URL, ".cue") {
 		// Single file download
-		outPath := filepath.Join(cacheDir, filepath.Base(parsed.URL))
-		out, err := os.Create(outPath)
+		out, err := os.Create(filepath.Join(dest, filepath.Base(parsed.URL)))
 		if err != nil {
-			return "", err
+			return err
 		}
 		defer out.Close()
-		if _, err := io.Copy(out, resp.Body); err != nil {
-			return "", err
+		if _, err := io.Copy(out, in); err != nil {
+			return err
 		}
 	} else {
-		return "", fmt.Errorf("unsupported HTTP content type for %s", parsed.URL)
+		return fmt.Errorf("unsupported HTTP content type for %s", parsed.URL)
 	}
+	return nil
+}
 
-	// Write cache metadata
-	r.writeCacheMetadata(cacheDir, parsed.URL)
+// download writes the content of a URL to a temporary file of dir and returns its path and SHA-256
+func (r *RuleSourceResolver) download(ctx context.Context, dir, url string) (string, string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create request: %w", err)
+	}
 
-	return cacheDir, nil
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("HTTP %d for %s", resp.StatusCode, url)
+	}
+
+	tmpFile, err := os.CreateTemp(dir, "promener-download-*")
+	if err != nil {
+		return "", "", err
+	}
+	defer tmpFile.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmpFile, h), resp.Body); err != nil {
+		os.Remove(tmpFile.Name())
+		return "", "", fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	return tmpFile.Name(), hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashFile returns the SHA-256 of a file
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
 }
 
 // cloneGit clones a Git repository.
 func (r *RuleSourceResolver) cloneGit(ctx context.Context, parsed *ParsedSource) (string, error) {
 	cacheDir := filepath.Join(r.cacheDir, "git", parsed.CacheKey)
 
-	// Check cache validity
-	if r.isCacheValid(cacheDir) {
+	// Use the cache when valid, at the commit checked out and without local changes
+	if meta := r.cachedContent(cacheDir, parsed); meta != nil {
+		commit, err := checkedOutCommit(cacheDir)
+		if err != nil {
+			return "", fmt.Errorf("cache of %s: %w, run promener rules update", parsed.Source, err)
+		}
+		if err := r.checkLock(parsed, commit, ""); err != nil {
+			return "", err
+		}
 		return cacheDir, nil
 	}
+	if r.offline {
+		return "", fmt.Errorf("%s is not in the cache, run once without --offline", parsed.Source)
+	}
 
 	// Remove old cache if exists
 	os.RemoveAll(cacheDir)
 
+	commit, err := r.clone(ctx, parsed, cacheDir)
+	if err != nil {
+		os.RemoveAll(cacheDir)
+		return "", err
+	}
+
+	if err := r.checkLock(parsed, commit, ""); err != nil {
+		os.RemoveAll(cacheDir)
+		return "", err
+	}
+
+	// Write cache metadata
+	r.writeCacheMetadata(cacheDir, fmt.Sprintf("%s@%s", parsed.URL, parsed.Ref), commit, "")
+
+	return cacheDir, nil
+}
+
+// clone clones the ref of a Git source in dir and returns the commit checked out. A ref given
+// after "#" is a branch, a full commit SHA is checked out after a full clone, and any other ref
+// given after "@" is a tag, or a branch when no such tag exists.
+func (r *RuleSourceResolver) clone(ctx context.Context, parsed *ParsedSource, dir string) (string, error) {
 	// Prepare clone options
 	cloneOpts := &git.CloneOptions{
 		URL:   parsed.URL,
 		Depth: 1,
 	}
 
-	// Set reference if not HEAD
-	if parsed.Ref != "" && parsed.Ref != "HEAD" {
-		// Determine if it's a tag (@) or branch (#)
-		if strings.Contains(parsed.Ref, ".") || strings.HasPrefix(parsed.Ref, "v") {
-			cloneOpts.ReferenceName = plumbing.NewTagReferenceName(parsed.Ref)
-		} else {
-			cloneOpts.ReferenceName = plumbing.NewBranchReferenceName(parsed.Ref)
-		}
-		cloneOpts.SingleBranch = true
-	}
-
 	// Set authentication if available
 	if auth := getGitAuth(parsed.Host); auth != nil {
 		cloneOpts.Auth = auth
 	}
 
+	isCommit := commitPattern.MatchString(parsed.Ref)
+	if isCommit {
+		cloneOpts.Depth = 0
+	} else if parsed.Ref != "" && parsed.Ref != "HEAD" {
+		if parsed.Branch {
+			cloneOpts.ReferenceName = plumbing.NewBranchReferenceName(parsed.Ref)
+		} else {
+			cloneOpts.ReferenceName = plumbing.NewTagReferenceName(parsed.Ref)
+		}
+		cloneOpts.SingleBranch = true
+	}
+
 	// Clone
-	_, err := git.PlainCloneContext(ctx, cacheDir, false, cloneOpts)
+	repo, err := git.PlainCloneContext(ctx, dir, false, cloneOpts)
+	if err != nil && !parsed.Branch && cloneOpts.ReferenceName.IsTag() && errors.Is(err, git.NoMatchingRefSpecError{}) {
+		os.RemoveAll(dir)
+		cloneOpts.ReferenceName = plumbing.NewBranchReferenceName(parsed.Ref)
+		repo, err = git.PlainCloneContext(ctx, dir, false, cloneOpts)
+	}
 	if err != nil {
 		return "", fmt.Errorf("failed to clone %s: %w", parsed.URL, err)
 	}
 
-	// Write cache metadata
-	r.writeCacheMetadata(cacheDir, fmt.Sprintf("%s@%s", parsed.URL, parsed.Ref))
+	if isCommit {
+		worktree, err := repo.Worktree()
+		if err != nil {
+			return "", err
+		}
+		if err := worktree.Checkout(&git.CheckoutOptions{Hash: plumbing.NewHash(parsed.Ref)}); err != nil {
+			return "", fmt.Errorf("failed to check out %s in %s: %w", parsed.Ref, parsed.URL, err)
+		}
+	}
 
-	return cacheDir, nil
+	head, err := repo.Head()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the commit of %s: %w", parsed.URL, err)
+	}
+	return head.Hash().String(), nil
+}
+
+// checkedOutCommit returns the commit checked out in the clone of a Git source, after checking
+// that its files were not modified. The cache metadata file is the only file allowed.
+func checkedOutCommit(dir string) (string, error) {
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		return "", err
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return "", err
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return "", err
+	}
+	status, err := worktree.Status()
+	if err != nil {
+		return "", err
+	}
+	for _, file := range slices.Sorted(maps.Keys(status)) {
+		if file != "metadata.json" {
+			return "", fmt.Errorf("%s was modified", file)
+		}
+	}
+	return head.Hash().String(), nil
 }
 
 // getGitAuth returns authentication for Git based on environment variables.
@@ -363,28 +736,74 @@ func getGitAuth(host string) *githttp.BasicAuth {
 	}
 }
 
-// isCacheValid checks if cache exists and is not expired.
-func (r *RuleSourceResolver) isCacheValid(cacheDir string) bool {
+// cachedContent returns the metadata of the cache of a source when it can be used: offline,
+// before it expires, or when it holds the locked content, which does not change
+func (r *RuleSourceResolver) cachedContent(cacheDir string, parsed *ParsedSource) *CacheMetadata {
 	metaPath := filepath.Join(cacheDir, "metadata.json")
 	data, err := os.ReadFile(metaPath)
 	if err != nil {
-		return false
+		return nil
 	}
 
 	var meta CacheMetadata
 	if err := json.Unmarshal(data, &meta); err != nil {
-		return false
+		return nil
+	}
+
+	if r.offline || time.Since(meta.FetchedAt) < r.cacheTTL {
+		return &meta
+	}
+	if r.cacheTTL <= 0 {
+		return nil
+	}
+	if locked := r.locked(parsed); locked != nil && locked.Commit == meta.Commit && locked.SHA256 == meta.SHA256 {
+		return &meta
+	}
+	return nil
+}
+
+// locked returns the locked content of a source, or nil without lock or when it is not locked
+func (r *RuleSourceResolver) locked(parsed *ParsedSource) *LockedSource {
+	if r.lock == nil {
+		return nil
+	}
+	return r.lock.Find(parsed.Source)
+}
+
+// checkLock records the content of a remote source and, with a lock, checks that it matches the
+// locked content
+func (r *RuleSourceResolver) checkLock(parsed *ParsedSource, commit, digest string) error {
+	r.resolved[parsed.Source] = LockedSource{
+		Source: parsed.Source,
+		URL:    parsed.URL,
+		Ref:    parsed.Ref,
+		Commit: commit,
+		SHA256: digest,
 	}
 
-	return time.Since(meta.FetchedAt) < r.cacheTTL
+	if r.lock == nil {
+		return nil
+	}
+	locked := r.lock.Find(parsed.Source)
+	switch {
+	case locked == nil:
+		return fmt.Errorf("%s is not in the lock file, run promener rules update", parsed.Source)
+	case locked.Commit != commit:
+		return fmt.Errorf("%s is at commit %s but the lock file expects %s, run promener rules update if the change is expected", parsed.Source, commit, locked.Commit)
+	case locked.SHA256 != digest:
+		return fmt.Errorf("integrity check failed for %s: sha256 %s does not match the lock file (%s)", parsed.Source, digest, locked.SHA256)
+	}
+	return nil
 }
 
 // writeCacheMetadata writes cache metadata file.
-func (r *RuleSourceResolver) writeCacheMetadata(cacheDir, source string) {
+func (r *RuleSourceResolver) writeCacheMetadata(cacheDir, source, commit, digest string) {
 	meta := CacheMetadata{
 		Source:    source,
 		FetchedAt: time.Now(),
 		TTL:       r.cacheTTL.String(),
+		Commit:    commit,
+		SHA256:    digest,
 	}
 
 	data, _ := json.MarshalIndent(meta, "", "  ")
@@ -410,6 +829,9 @@ func extractTarGz(r io.Reader, dest string) error {
 		}
 
 		target := filepath.Join(dest, header.Name)
+		if target == filepath.Clean(dest) {
+			continue // Root entry of archives created from "."
+		}
 
 		// Security: prevent path traversal
 		if !strings.HasPrefix(target, filepath.Clean(dest)+string(os.PathSeparator)) {
@@ -463,6 +885,9 @@ func extractZip(r io.Reader, dest string) error {
 
 	for _, f := range zipReader.File {
 		target := filepath.Join(dest, f.Name)
+		if target == filepath.Clean(dest) {
+			continue // Root entry of archives created from "."
+		}
 
 		// Security: prevent path traversal
 		if !strings.HasPrefix(target, filepath.Clean(dest)+string(os.PathSeparator)) {
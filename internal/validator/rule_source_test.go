@@ -0,0 +1,483 @@
+package validator
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSource_Path(t *testing.T) {
+	tests := []struct {
+		source   string
+		typ      SourceType
+		url      string
+		ref      string
+		path     string
+		cacheKey string // Source sharing the cache of the parsed one
+	}{
+		{
+			source:   "github:org/specs@v1.2.0//services/orders.cue",
+			typ:      SourceGit,
+			url:      "https://github.com/org/specs.git",
+			ref:      "v1.2.0",
+			path:     "services/orders.cue",
+			cacheKey: "github:org/specs@v1.2.0",
+		},
+		{
+			source:   "gitlab:org/specs//orders.cue",
+			typ:      SourceGit,
+			url:      "https://gitlab.com/org/specs.git",
+			ref:      "HEAD",
+			path:     "orders.cue",
+			cacheKey: "gitlab:org/specs",
+		},
+		{
+			source:   "github:org/specs#main",
+			typ:      SourceGit,
+			url:      "https://github.com/org/specs.git",
+			ref:      "main",
+			cacheKey: "github:org/specs#main",
+		},
+		{
+			source:   "https://example.com/specs.tar.gz//services/orders.cue",
+			typ:      SourceHTTP,
+			url:      "https://example.com/specs.tar.gz",
+			path:     "services/orders.cue",
+			cacheKey: "https://example.com/specs.tar.gz",
+		},
+		{
+			source:   "https://example.com/rules/naming.rego",
+			typ:      SourceHTTP,
+			url:      "https://example.com/rules/naming.rego",
+			cacheKey: "https://example.com/rules/naming.rego",
+		},
+		{
+			source:   "builtin",
+			typ:      SourceBuiltin,
+			url:      "builtin",
+			cacheKey: "builtin",
+		},
+		{
+			source:   "builtin:strict",
+			typ:      SourceBuiltin,
+			url:      "builtin:strict",
+			cacheKey: "builtin:strict",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			parsed, err := ParseSource(tt.source)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.typ, parsed.Type)
+			assert.Equal(t, tt.url, parsed.URL)
+			assert.Equal(t, tt.ref, parsed.Ref)
+			assert.Equal(t, tt.path, parsed.Path)
+			assert.Equal(t, hashSource(tt.cacheKey), parsed.CacheKey)
+		})
+	}
+}
+
+func TestRuleSourceResolver_Builtin(t *testing.T) {
+	cacheDir := t.TempDir()
+	t.Setenv("PROMENER_CACHE_DIR", cacheDir)
+	ctx := context.Background()
+
+	files, err := NewRuleSourceResolver().Load(ctx, BuiltinSource)
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+	assert.FileExists(t, filepath.Join(filepath.Dir(files[0]), "utils.rego"))
+	assert.NoFileExists(t, filepath.Join(filepath.Dir(files[0]), "strict.rego"))
+
+	strictFiles, err := NewRuleSourceResolver().Load(ctx, BuiltinStrictSource)
+	require.NoError(t, err)
+	assert.Len(t, strictFiles, len(files)+1)
+	assert.FileExists(t, filepath.Join(filepath.Dir(strictFiles[0]), "strict.rego"))
+
+	// The extracted rules are reused
+	again, err := NewRuleSourceResolver().Load(ctx, BuiltinSource)
+	require.NoError(t, err)
+	assert.Equal(t, files, again)
+	entries, err := os.ReadDir(cacheDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestRegoValidator_Builtin(t *testing.T) {
+	t.Setenv("PROMENER_CACHE_DIR", t.TempDir())
+	input := map[string]interface{}{
+		"services": map[string]interface{}{
+			"jobs": map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"queue_count": map[string]interface{}{"namespace": "jobs", "subsystem": "worker", "type": "gauge"},
+				},
+			},
+		},
+	}
+
+	severities := func(source string) map[string]string {
+		errs, err := NewRegoValidator([]string{source}).Validate(context.Background(), input)
+		require.NoError(t, err)
+		result := map[string]string{}
+		for _, e := range errs {
+			result[e.RuleID] = e.Severity
+		}
+		return result
+	}
+
+	assert.Equal(t, map[string]string{
+		"gauge-reserved-suffix": "error",
+		"help-text":             "warning",
+	}, severities(BuiltinSource))
+	assert.Equal(t, map[string]string{
+		"gauge-reserved-suffix": "error",
+		"help-text":             "error",
+	}, severities(BuiltinStrictSource))
+}
+
+func TestParsedSource_IsArchive(t *testing.T) {
+	for source, want := range map[string]bool{
+		"https://example.com/specs.tar.gz":      true,
+		"https://example.com/specs.tgz//a.cue":  true,
+		"https://example.com/specs.zip":         true,
+		"https://example.com/metrics.cue":       false,
+		"github:org/specs@v1.2.0//specs.tar.gz": false,
+		"./testdata/specs.tar.gz":               false,
+	} {
+		parsed, err := ParseSource(source)
+		require.NoError(t, err)
+		assert.Equal(t, want, parsed.IsArchive(), source)
+	}
+}
+
+func tarGz(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "./", Mode: 0755, Typeflag: tar.TypeDir}))
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func TestRuleSourceResolver_Resolve(t *testing.T) {
+	t.Setenv("PROMENER_CACHE_DIR", t.TempDir())
+
+	archive := tarGz(t, map[string]string{
+		"cue.mod/module.cue":  "module: \"example.com/specs@v0\"\n",
+		"services/orders.cue": "package services\n",
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/specs.tar.gz":
+			_, _ = w.Write(archive)
+		case "/metrics.cue":
+			_, _ = w.Write([]byte("package main\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+
+	t.Run("path inside an archive", func(t *testing.T) {
+		path, err := NewRuleSourceResolver().Resolve(ctx, server.URL+"/specs.tar.gz//services/orders.cue")
+		require.NoError(t, err)
+		assert.Equal(t, "orders.cue", filepath.Base(path))
+		assert.FileExists(t, filepath.Join(filepath.Dir(path), "..", "cue.mod", "module.cue"), "archive kept whole")
+	})
+
+	t.Run("archive", func(t *testing.T) {
+		path, err := NewRuleSourceResolver().Resolve(ctx, server.URL+"/specs.tar.gz")
+		require.NoError(t, err)
+		assert.DirExists(t, filepath.Join(path, "services"))
+	})
+
+	t.Run("single file", func(t *testing.T) {
+		path, err := NewRuleSourceResolver().Resolve(ctx, server.URL+"/metrics.cue")
+		require.NoError(t, err)
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "package main\n", string(content))
+	})
+
+	t.Run("missing path", func(t *testing.T) {
+		_, err := NewRuleSourceResolver().Resolve(ctx, server.URL+"/specs.tar.gz//services/users.cue")
+		assert.ErrorContains(t, err, "path services/users.cue not found")
+	})
+
+	t.Run("path traversal", func(t *testing.T) {
+		_, err := NewRuleSourceResolver().Resolve(ctx, server.URL+"/specs.tar.gz//../../etc/passwd")
+		assert.ErrorContains(t, err, "invalid path in source")
+	})
+
+	t.Run("path in a single file", func(t *testing.T) {
+		_, err := NewRuleSourceResolver().Resolve(ctx, server.URL+"/metrics.cue//orders.cue")
+		assert.ErrorContains(t, err, "only be given inside a Git repository or an archive")
+	})
+}
+
+// archiveServer serves an archive whose content can be changed by the test, and counts the downloads
+type archiveServer struct {
+	*httptest.Server
+	archive   []byte
+	downloads int
+}
+
+func newArchiveServer(t *testing.T, archive []byte) *archiveServer {
+	s := &archiveServer{archive: archive}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.downloads++
+		_, _ = w.Write(s.archive)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestRuleSourceResolver_Lock(t *testing.T) {
+	t.Setenv("PROMENER_CACHE_DIR", t.TempDir())
+	ctx := context.Background()
+
+	rules := map[string]string{"naming.rego": "package PromenerPolicy\n"}
+	server := newArchiveServer(t, tarGz(t, rules))
+	source := server.URL + "/rules.tar.gz"
+
+	// Record the content
+	resolver := NewRuleSourceResolver()
+	files, err := resolver.Load(ctx, source)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	lock := resolver.Resolved()
+	require.Len(t, lock.Sources, 1)
+	assert.Equal(t, source, lock.Sources[0].Source)
+	assert.Len(t, lock.Sources[0].SHA256, 64)
+	assert.Empty(t, lock.Sources[0].Commit)
+
+	t.Run("locked content from the cache", func(t *testing.T) {
+		resolver := NewRuleSourceResolver()
+		resolver.SetLock(lock)
+		_, err := resolver.Load(ctx, source)
+		require.NoError(t, err)
+		assert.Equal(t, 1, server.downloads)
+	})
+
+	t.Run("locked content fetched again", func(t *testing.T) {
+		resolver := NewRuleSourceResolver()
+		resolver.SetCacheTTL(0)
+		resolver.SetLock(lock)
+		_, err := resolver.Load(ctx, source)
+		require.NoError(t, err)
+		assert.Equal(t, 2, server.downloads)
+	})
+
+	t.Run("source not locked", func(t *testing.T) {
+		resolver := NewRuleSourceResolver()
+		resolver.SetLock(&Lock{Version: lockVersion})
+		_, err := resolver.Load(ctx, source)
+		assert.ErrorContains(t, err, "is not in the lock file")
+	})
+
+	t.Run("modified archive", func(t *testing.T) {
+		server.archive = tarGz(t, map[string]string{"naming.rego": "package PromenerPolicy\n\n# compromised\n"})
+
+		resolver := NewRuleSourceResolver()
+		resolver.SetCacheTTL(0)
+		resolver.SetLock(lock)
+		_, err := resolver.Load(ctx, source)
+		assert.ErrorContains(t, err, "integrity check failed")
+
+		// The cache still holds the locked content
+		resolver = NewRuleSourceResolver()
+		resolver.SetLock(lock)
+		files, err := resolver.Load(ctx, source)
+		require.NoError(t, err)
+		content, err := os.ReadFile(files[0])
+		require.NoError(t, err)
+		assert.Equal(t, rules["naming.rego"], string(content))
+	})
+}
+
+func TestRuleSourceResolver_ModifiedCache(t *testing.T) {
+	cacheDir := t.TempDir()
+	t.Setenv("PROMENER_CACHE_DIR", cacheDir)
+	ctx := context.Background()
+
+	rules := map[string]string{"naming.rego": "package PromenerPolicy\n"}
+	server := newArchiveServer(t, tarGz(t, rules))
+	source := server.URL + "/rules.tar.gz"
+
+	resolver := NewRuleSourceResolver()
+	files, err := resolver.Load(ctx, source)
+	require.NoError(t, err)
+	lock := resolver.Resolved()
+	sourceDir := filepath.Join(cacheDir, "http", hashSource(source))
+
+	verify := func() ([]string, error) {
+		resolver := NewRuleSourceResolver()
+		resolver.SetOffline(true)
+		resolver.SetLock(lock)
+		return resolver.Load(ctx, source)
+	}
+
+	t.Run("modified rule", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(files[0], []byte("package PromenerPolicy\n\n# compromised\n"), 0644))
+
+		files, err := verify()
+		require.NoError(t, err)
+		content, err := os.ReadFile(files[0])
+		require.NoError(t, err)
+		assert.Equal(t, rules["naming.rego"], string(content), "rules extracted again from the download")
+	})
+
+	t.Run("modified download and metadata", func(t *testing.T) {
+		compromised := tarGz(t, map[string]string{"naming.rego": "package PromenerPolicy\n\n# compromised\n"})
+		require.NoError(t, os.WriteFile(filepath.Join(sourceDir, "download"), compromised, 0644))
+		sum := sha256.Sum256(compromised)
+		metadata, err := os.ReadFile(filepath.Join(sourceDir, "metadata.json"))
+		require.NoError(t, err)
+		metadata = []byte(strings.ReplaceAll(string(metadata), lock.Sources[0].SHA256, hex.EncodeToString(sum[:])))
+		require.NoError(t, os.WriteFile(filepath.Join(sourceDir, "metadata.json"), metadata, 0644))
+
+		_, err = verify()
+		assert.ErrorContains(t, err, "integrity check failed")
+	})
+}
+
+func TestRuleSourceResolver_Offline(t *testing.T) {
+	t.Setenv("PROMENER_CACHE_DIR", t.TempDir())
+	ctx := context.Background()
+
+	server := newArchiveServer(t, tarGz(t, map[string]string{"naming.rego": "package PromenerPolicy\n"}))
+	source := server.URL + "/rules.tar.gz"
+
+	resolver := NewRuleSourceResolver()
+	resolver.SetOffline(true)
+	_, err := resolver.Load(ctx, source)
+	assert.ErrorContains(t, err, "is not in the cache")
+	assert.Equal(t, 0, server.downloads)
+
+	_, err = NewRuleSourceResolver().Load(ctx, source)
+	require.NoError(t, err)
+
+	resolver = NewRuleSourceResolver()
+	resolver.SetCacheTTL(0)
+	resolver.SetOffline(true)
+	files, err := resolver.Load(ctx, source)
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+	assert.Equal(t, 1, server.downloads, "expired cache used offline")
+}
+
+// gitRepository creates a repository with a tag v1.0.0 on the first commit and a branch main
+// one commit ahead, and returns the commits
+func gitRepository(t *testing.T) (string, string, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	run := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+
+	run("init", "-q", "-b", "main")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "naming.rego"), []byte("package PromenerPolicy\n"), 0644))
+	run("add", ".")
+	run("commit", "-q", "-m", "first")
+	run("tag", "v1.0.0")
+	first := run("rev-parse", "HEAD")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "labels.rego"), []byte("package PromenerPolicy\n"), 0644))
+	run("add", ".")
+	run("commit", "-q", "-m", "second")
+	return dir, first, run("rev-parse", "HEAD")
+}
+
+func TestRuleSourceResolver_Git(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	t.Setenv("PROMENER_CACHE_DIR", t.TempDir())
+	ctx := context.Background()
+
+	repo, first, second := gitRepository(t)
+	gitSource := func(ref string, branch bool) *ParsedSource {
+		source := "git:" + ref
+		return &ParsedSource{Type: SourceGit, Source: source, URL: "file://" + repo, Ref: ref, Branch: branch, CacheKey: hashSource(source)}
+	}
+
+	tests := []struct {
+		name   string
+		parsed *ParsedSource
+		commit string
+		files  int
+	}{
+		{"tag", gitSource("v1.0.0", false), first, 1},
+		{"branch", gitSource("main", true), second, 2},
+		{"branch after @", gitSource("main", false), second, 2},
+		{"commit", gitSource(first, false), first, 1},
+		{"HEAD", gitSource("HEAD", false), second, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := NewRuleSourceResolver()
+			dir, err := resolver.resolvePath(ctx, tt.parsed)
+			require.NoError(t, err)
+
+			files, err := resolver.collectRegoFiles(dir)
+			require.NoError(t, err)
+			assert.Len(t, files, tt.files)
+			assert.Equal(t, tt.commit, resolver.Resolved().Find(tt.parsed.Source).Commit)
+		})
+	}
+
+	t.Run("modified cache", func(t *testing.T) {
+		parsed := gitSource("v1.0.0", false)
+		resolver := NewRuleSourceResolver()
+		dir, err := resolver.resolvePath(ctx, parsed)
+		require.NoError(t, err)
+		lock := resolver.Resolved()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "naming.rego"), []byte("package PromenerPolicy\n\n# compromised\n"), 0644))
+
+		resolver = NewRuleSourceResolver()
+		resolver.SetOffline(true)
+		resolver.SetLock(lock)
+		_, err = resolver.resolvePath(ctx, parsed)
+		assert.ErrorContains(t, err, "naming.rego was modified")
+	})
+
+	t.Run("moved branch", func(t *testing.T) {
+		parsed := gitSource("main", true)
+		resolver := NewRuleSourceResolver()
+		resolver.SetCacheTTL(0)
+		resolver.SetLock(&Lock{Version: lockVersion, Sources: []LockedSource{{Source: parsed.Source, Commit: first}}})
+		_, err := resolver.resolvePath(ctx, parsed)
+		assert.ErrorContains(t, err, "but the lock file expects "+first)
+	})
+}
@@ -0,0 +1,93 @@
+package validator
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/jycamier/promener/rules"
+)
+
+//go:embed templates/*.gotmpl
+var scaffoldFS embed.FS
+
+// ruleIDPattern matches the rule ids of scaffolded rules, e.g. gauge-count-suffix
+var ruleIDPattern = regexp.MustCompile(`^[a-z][a-z0-9]*([-_][a-z0-9]+)*$`)
+
+// RuleScaffold describes a rule to create with ScaffoldRule
+type RuleScaffold struct {
+	RuleID   string // Identifier of the rule, in kebab-case
+	Category string
+	Severity string
+}
+
+// Name returns the rule id in snake_case, used for the file and test names
+func (s RuleScaffold) Name() string {
+	return strings.ReplaceAll(s.RuleID, "-", "_")
+}
+
+// ScaffoldRule writes a rule and its test in the directory, and the utils.rego helpers used by the
+// rule if the directory does not have them. It never overwrites a file and returns the written
+// files.
+func ScaffoldRule(dir string, scaffold RuleScaffold) ([]string, error) {
+	if !ruleIDPattern.MatchString(scaffold.RuleID) {
+		return nil, fmt.Errorf("invalid rule id %q: expected lowercase words separated by dashes, e.g. gauge-count-suffix", scaffold.RuleID)
+	}
+	switch scaffold.Severity {
+	case "error", "warning", "info":
+	default:
+		return nil, fmt.Errorf("invalid severity %q: expected error, warning or info", scaffold.Severity)
+	}
+
+	name := scaffold.Name()
+	files := map[string]string{
+		name + ".rego":      "rule.rego.gotmpl",
+		name + "_test.rego": "rule_test.rego.gotmpl",
+	}
+	for file := range files {
+		if _, err := os.Stat(filepath.Join(dir, file)); err == nil {
+			return nil, fmt.Errorf("%s already exists", filepath.Join(dir, file))
+		}
+	}
+
+	tmpl, err := template.ParseFS(scaffoldFS, "templates/*.gotmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse templates: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	var written []string
+	for _, file := range []string{name + ".rego", name + "_test.rego"} {
+		var buf bytes.Buffer
+		if err := tmpl.ExecuteTemplate(&buf, files[file], scaffold); err != nil {
+			return nil, fmt.Errorf("failed to execute template %s: %w", files[file], err)
+		}
+		path := filepath.Join(dir, file)
+		if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		written = append(written, path)
+	}
+
+	utils := filepath.Join(dir, "utils.rego")
+	if _, err := os.Stat(utils); errors.Is(err, fs.ErrNotExist) {
+		data, err := rules.FS.ReadFile("utils.rego")
+		if err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(utils, data, 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", utils, err)
+		}
+		written = append(written, utils)
+	}
+	return written, nil
+}
@@ -0,0 +1,61 @@
+package validator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScaffoldRule(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "rules")
+
+	files, err := ScaffoldRule(dir, RuleScaffold{RuleID: "gauge-count-suffix", Category: "naming", Severity: "warning"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "gauge_count_suffix.rego"),
+		filepath.Join(dir, "gauge_count_suffix_test.rego"),
+		filepath.Join(dir, "utils.rego"),
+	}, files)
+
+	rule, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	assert.Contains(t, string(rule), `"rule_id": "gauge-count-suffix"`)
+	assert.Contains(t, string(rule), `"category": "naming"`)
+	assert.Contains(t, string(rule), "get_metrics_common[_]")
+
+	// The scaffolded tests pass against the scaffolded rule
+	v := New()
+	v.SetRulesDirs([]string{dir})
+	results, err := v.TestRules(context.Background(), "")
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for _, result := range results {
+		assert.True(t, result.Pass(), "%s: %v", result.Name, result.Error)
+	}
+
+	// Existing files are never overwritten, but utils.rego is only written when missing
+	_, err = ScaffoldRule(dir, RuleScaffold{RuleID: "gauge-count-suffix", Severity: "warning"})
+	assert.ErrorContains(t, err, "already exists")
+
+	files, err = ScaffoldRule(dir, RuleScaffold{RuleID: "owner-required", Severity: "error"})
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+}
+
+func TestScaffoldRule_Invalid(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := ScaffoldRule(dir, RuleScaffold{RuleID: "Gauge Count", Severity: "warning"})
+	assert.ErrorContains(t, err, "invalid rule id")
+
+	_, err = ScaffoldRule(dir, RuleScaffold{RuleID: "gauge-count", Severity: "fatal"})
+	assert.ErrorContains(t, err, "invalid severity")
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
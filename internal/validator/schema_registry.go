@@ -3,14 +3,17 @@ package validator
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strconv"
 
 	v1 "github.com/jycamier/promener/schema/v1"
+	v2 "github.com/jycamier/promener/schema/v2"
 )
 
 // schemaRegistry maps major versions to embedded CUE schemas.
 var schemaRegistry = map[int]string{
 	1: v1.Schema,
+	2: v2.Schema,
 }
 
 // versionRegex extracts the major version from a version string like "1.0.0" or "1.2".
@@ -50,5 +53,6 @@ func supportedVersions() []int {
 	for v := range schemaRegistry {
 		versions = append(versions, v)
 	}
+	sort.Ints(versions)
 	return versions
 }
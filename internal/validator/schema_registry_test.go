@@ -13,6 +13,7 @@ func TestGetSchemaForVersion_Valid(t *testing.T) {
 		{"v1.0.0", "1.0.0"},
 		{"v1.0", "1.0"},
 		{"v1.2.3", "1.2.3"},
+		{"v2.0.0", "2.0.0"},
 	}
 
 	for _, tt := range tests {
@@ -40,7 +41,7 @@ func TestGetSchemaForVersion_UnsupportedVersion(t *testing.T) {
 		version string
 	}{
 		{"v999", "999.0.0"},
-		{"v2", "2.0.0"},
+		{"v3", "3.0.0"},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,90 @@
+package validator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"cuelang.org/go/cue"
+)
+
+// suppressionAttribute is the name of the CUE attribute suppressing Rego rules on a service or a
+// metric, e.g. @promener(ignore=counter-total-suffix, ignore=plural-unit)
+const suppressionAttribute = "promener"
+
+// suppression lists the rules ignored under a path of the specification
+type suppression struct {
+	prefixes []string // Path of the service or the metric, in the bracket and dotted notations
+	rules    []string
+}
+
+// suppressions returns the rules ignored by the attributes of the services and metrics
+func suppressions(value cue.Value) []suppression {
+	var result []suppression
+
+	services, err := value.LookupPath(cue.ParsePath("services")).Fields()
+	if err != nil {
+		return nil
+	}
+	for services.Next() {
+		serviceName := services.Selector().Unquoted()
+		if rules := ignoredRules(services.Value()); len(rules) > 0 {
+			result = append(result, suppression{
+				prefixes: []string{fmt.Sprintf("services[%s]", serviceName), "services." + serviceName},
+				rules:    rules,
+			})
+		}
+
+		metrics, err := services.Value().LookupPath(cue.ParsePath("metrics")).Fields()
+		if err != nil {
+			continue
+		}
+		for metrics.Next() {
+			key := metrics.Selector().Unquoted()
+			if rules := ignoredRules(metrics.Value()); len(rules) > 0 {
+				result = append(result, suppression{
+					prefixes: []string{
+						fmt.Sprintf("services[%s].metrics[%s]", serviceName, key),
+						fmt.Sprintf("services.%s.metrics.%s", serviceName, key),
+					},
+					rules: rules,
+				})
+			}
+		}
+	}
+	return result
+}
+
+// ignoredRules returns the rules of the ignore arguments of the field and declaration attributes
+func ignoredRules(value cue.Value) []string {
+	var rules []string
+	for _, attr := range value.Attributes(cue.FieldAttr | cue.DeclAttr) {
+		if attr.Name() != suppressionAttribute {
+			continue
+		}
+		for i := 0; i < attr.NumArgs(); i++ {
+			if key, rule := attr.Arg(i); key == "ignore" && rule != "" {
+				rules = append(rules, strings.TrimSpace(rule))
+			}
+		}
+	}
+	return rules
+}
+
+// isSuppressed returns true if the error is reported by a rule ignored on its path or a parent path
+func isSuppressed(err ValidationError, suppressions []suppression) bool {
+	if err.RuleID == "" {
+		return false
+	}
+	for _, s := range suppressions {
+		if !slices.Contains(s.rules, err.RuleID) {
+			continue
+		}
+		for _, prefix := range s.prefixes {
+			if rest, ok := strings.CutPrefix(err.Path, prefix); ok && (rest == "" || rest[0] == '.' || rest[0] == '[') {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package validator
+
+import (
+	"testing"
+
+	"cuelang.org/go/cue/cuecontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const suppressedSpec = `
+services: {
+	orders: {
+		metrics: {
+			legacy_requests: {
+				type: "counter"
+			} @promener(ignore=counter-total-suffix, ignore=plural-unit)
+			errors: {
+				@promener(ignore=histogram-unit-suffix)
+				type: "counter"
+			}
+			requests_total: type: "counter"
+		}
+	}
+	carts: {
+		metrics: size: type: "gauge"
+	} @promener(ignore=reserved-label) @other(ignore=plural-unit)
+}
+`
+
+func TestSuppressions(t *testing.T) {
+	value := cuecontext.New().CompileString(suppressedSpec)
+	require.NoError(t, value.Err())
+
+	assert.ElementsMatch(t, []suppression{
+		{
+			prefixes: []string{"services[orders].metrics[legacy_requests]", "services.orders.metrics.legacy_requests"},
+			rules:    []string{"counter-total-suffix", "plural-unit"},
+		},
+		{
+			prefixes: []string{"services[orders].metrics[errors]", "services.orders.metrics.errors"},
+			rules:    []string{"histogram-unit-suffix"},
+		},
+		{
+			prefixes: []string{"services[carts]", "services.carts"},
+			rules:    []string{"reserved-label"},
+		},
+	}, suppressions(value))
+}
+
+func TestIsSuppressed(t *testing.T) {
+	value := cuecontext.New().CompileString(suppressedSpec)
+	require.NoError(t, value.Err())
+	ignored := suppressions(value)
+
+	tests := []struct {
+		name string
+		err  ValidationError
+		want bool
+	}{
+		{"metric", ValidationError{RuleID: "counter-total-suffix", Path: "services[orders].metrics[legacy_requests]"}, true},
+		{"second rule", ValidationError{RuleID: "plural-unit", Path: "services[orders].metrics[legacy_requests]"}, true},
+		{"dotted path", ValidationError{RuleID: "plural-unit", Path: "services.orders.metrics.legacy_requests"}, true},
+		{"label of the metric", ValidationError{RuleID: "plural-unit", Path: "services[orders].metrics[legacy_requests].labels[method]"}, true},
+		{"declaration attribute", ValidationError{RuleID: "histogram-unit-suffix", Path: "services[orders].metrics[errors]"}, true},
+		{"metric of the service", ValidationError{RuleID: "reserved-label", Path: "services[carts].metrics[size].labels[job]"}, true},
+		{"other rule", ValidationError{RuleID: "repeated-name-segment", Path: "services[orders].metrics[legacy_requests]"}, false},
+		{"other metric", ValidationError{RuleID: "counter-total-suffix", Path: "services[orders].metrics[errors]"}, false},
+		{"metric with the same prefix", ValidationError{RuleID: "counter-total-suffix", Path: "services[orders].metrics[legacy_requests_v2]"}, false},
+		{"other attribute", ValidationError{RuleID: "plural-unit", Path: "services[carts].metrics[size]"}, false},
+		{"no rule id", ValidationError{Path: "services[orders].metrics[legacy_requests]"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isSuppressed(tt.err, ignored))
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"cuelang.org/go/cue"
 	"github.com/jycamier/promener/internal/domain"
 )
 
@@ -13,6 +14,9 @@ type Validator struct {
 	loader    *CueLoader
 	extractor *CueExtractor
 	rego      *RegoValidator
+	queries   []string
+	lock      *Lock
+	offline   bool
 }
 
 // New creates a new Validator instance.
@@ -27,6 +31,40 @@ func New() *Validator {
 func (v *Validator) SetRulesDirs(dirs []string) {
 	if len(dirs) > 0 {
 		v.rego = NewRegoValidator(dirs)
+		v.rego.lock, v.rego.offline = v.lock, v.offline
+		if len(v.queries) > 0 {
+			v.rego.queries = v.queries
+		}
+	}
+}
+
+// SetRulesQueries sets the Rego queries evaluated against the specifications, the PromenerPolicy
+// rule of the PromenerPolicy package by default.
+func (v *Validator) SetRulesQueries(queries []string) error {
+	if err := checkQueries(queries); err != nil {
+		return err
+	}
+	v.queries = queries
+	if v.rego != nil {
+		return v.rego.SetQueries(queries)
+	}
+	return nil
+}
+
+// SetLock sets the lock the content of the remote rule sources must match, nil to disable the
+// verification.
+func (v *Validator) SetLock(lock *Lock) {
+	v.lock = lock
+	if v.rego != nil {
+		v.rego.lock = lock
+	}
+}
+
+// SetOffline restricts the remote rule sources to their cached content.
+func (v *Validator) SetOffline(offline bool) {
+	v.offline = offline
+	if v.rego != nil {
+		v.rego.offline = offline
 	}
 }
 
@@ -47,18 +85,18 @@ func (v *Validator) ValidateAndExtract(cuePath string) (*domain.Specification, *
 
 	// Post CUE export validation (Rego)
 	if v.rego != nil {
-		var input interface{}
-		// Convert CUE value to JSON-compatible structure
-		jsonData, err := json.Marshal(cueValue)
-		if err == nil {
-			if err := json.Unmarshal(jsonData, &input); err == nil {
-				regoErrors, err := v.rego.Validate(context.Background(), input)
-				if err != nil {
-					return nil, nil, fmt.Errorf("rego validation failed: %w", err)
-				}
-				if len(regoErrors) > 0 {
-					result.RegoErrors = append(result.RegoErrors, regoErrors...)
+		if input, err := regoInput(cueValue); err == nil {
+			regoErrors, err := v.rego.Validate(context.Background(), input)
+			if err != nil {
+				return nil, nil, fmt.Errorf("rego validation failed: %w", err)
+			}
+			ignored := suppressions(cueValue)
+			for _, regoError := range regoErrors {
+				if isSuppressed(regoError, ignored) {
+					result.Suppressed = append(result.Suppressed, regoError)
+					continue
 				}
+				result.RegoErrors = append(result.RegoErrors, regoError)
 			}
 		}
 	}
@@ -77,6 +115,16 @@ func (v *Validator) ValidateAndExtract(cuePath string) (*domain.Specification, *
 		return nil, result, err
 	}
 
+	// Const labels are only known once extracted, with their references to environment variables
+	ignored := suppressions(cueValue)
+	for _, domainError := range checkConstLabels(spec) {
+		if isSuppressed(domainError, ignored) {
+			result.Suppressed = append(result.Suppressed, domainError)
+			continue
+		}
+		result.DomainErrors = append(result.DomainErrors, domainError)
+	}
+
 	return spec, result, nil
 }
 
@@ -87,6 +135,47 @@ func (v *Validator) Validate(cuePath string) (*ValidationResult, error) {
 	return result, err
 }
 
+// RegoInput loads a CUE file, validates it against the embedded schema, and returns the input
+// document evaluated by the Rego rules. The input is nil when the CUE validation fails.
+func (v *Validator) RegoInput(cuePath string) (interface{}, *ValidationResult, error) {
+	cueValue, result, err := v.loader.LoadAndValidate(cuePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	if result.HasErrors() {
+		return nil, result, fmt.Errorf("CUE validation failed")
+	}
+
+	input, err := regoInput(cueValue)
+	if err != nil {
+		return nil, result, err
+	}
+	return input, result, nil
+}
+
+// regoInput converts a CUE value to the JSON-compatible structure given as input to the Rego rules
+func regoInput(value cue.Value) (interface{}, error) {
+	jsonData, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export specification: %w", err)
+	}
+
+	var input interface{}
+	if err := json.Unmarshal(jsonData, &input); err != nil {
+		return nil, fmt.Errorf("failed to export specification: %w", err)
+	}
+	return input, nil
+}
+
+// TestRules runs the test_ rules of the Rego rule sources, filtered by the run regular expression
+// when it is not empty.
+func (v *Validator) TestRules(ctx context.Context, run string) ([]RuleTestResult, error) {
+	if v.rego == nil {
+		return nil, fmt.Errorf("no rule source configured (via --rules flag or config file)")
+	}
+	return v.rego.Test(ctx, run)
+}
+
 // ValidationResult contains the combined results of domain, CUE, and Rego validation.
 type ValidationResult struct {
 	// CueErrors contains errors found during CUE schema validation.
@@ -97,6 +186,13 @@ type ValidationResult struct {
 
 	// RegoErrors contains errors found during Rego policy validation.
 	RegoErrors []ValidationError
+
+	// Suppressed contains the Rego errors ignored by a @promener(ignore=rule_id) attribute of
+	// the specification. They are reported but never fail the validation.
+	Suppressed []ValidationError
+
+	// WorkspaceErrors contains the conflicts between several specifications (see CheckWorkspace).
+	WorkspaceErrors []ValidationError
 }
 
 // ValidationError represents a single validation error with context.
@@ -115,11 +211,29 @@ type ValidationError struct {
 
 	// Line is the line number in the source file (if available).
 	Line int
+
+	// File is the specification file, when several specifications are validated together.
+	File string `json:",omitempty"`
+
+	// RuleID identifies the Rego rule, to document it or suppress it with @promener(ignore=rule_id).
+	RuleID string `json:",omitempty"`
+
+	// Category groups the Rego rules (e.g. "naming", "labels").
+	Category string `json:",omitempty"`
+
+	// DocsURL links to the documentation of the Rego rule.
+	DocsURL string `json:",omitempty"`
+
+	// Fix describes how to fix the error.
+	Fix string `json:",omitempty"`
+
+	// Metadata holds any other data returned by the Rego rule.
+	Metadata map[string]interface{} `json:",omitempty"`
 }
 
 // HasErrors returns true if there are any validation errors.
 func (r *ValidationResult) HasErrors() bool {
-	return len(r.CueErrors) > 0 || len(r.DomainErrors) > 0 || len(r.RegoErrors) > 0
+	return len(r.CueErrors) > 0 || len(r.DomainErrors) > 0 || len(r.RegoErrors) > 0 || len(r.WorkspaceErrors) > 0
 }
 
 // Failed returns true if any error matches or exceeds the given severity threshold.
@@ -151,11 +265,34 @@ func (r *ValidationResult) Failed(threshold string) bool {
 			return true
 		}
 	}
+	for _, err := range r.WorkspaceErrors {
+		if levels[err.Severity] >= thresholdLevel {
+			return true
+		}
+	}
 
 	return false
 }
 
 // TotalErrors returns the total number of validation errors.
 func (r *ValidationResult) TotalErrors() int {
-	return len(r.CueErrors) + len(r.DomainErrors) + len(r.RegoErrors)
+	return len(r.CueErrors) + len(r.DomainErrors) + len(r.RegoErrors) + len(r.WorkspaceErrors)
+}
+
+// Merge adds the errors of the result of a specification file, with their file set
+func (r *ValidationResult) Merge(file string, other *ValidationResult) {
+	withFile := func(errs []ValidationError) []ValidationError {
+		result := make([]ValidationError, 0, len(errs))
+		for _, err := range errs {
+			err.File = file
+			result = append(result, err)
+		}
+		return result
+	}
+
+	r.CueErrors = append(r.CueErrors, withFile(other.CueErrors)...)
+	r.DomainErrors = append(r.DomainErrors, withFile(other.DomainErrors)...)
+	r.RegoErrors = append(r.RegoErrors, withFile(other.RegoErrors)...)
+	r.Suppressed = append(r.Suppressed, withFile(other.Suppressed)...)
+	r.WorkspaceErrors = append(r.WorkspaceErrors, withFile(other.WorkspaceErrors)...)
 }
@@ -143,3 +143,57 @@ func TestValidator_Validate_NonExistentFile(t *testing.T) {
 		t.Error("Expected error for non-existent file")
 	}
 }
+
+func TestValidator_RegoInput(t *testing.T) {
+	cuePath := filepath.Join("..", "..", "testdata", "v2_example.cue")
+
+	input, result, err := New().RegoInput(cuePath)
+	if err != nil {
+		t.Fatalf("RegoInput failed: %v", err)
+	}
+	if result.HasErrors() {
+		t.Errorf("Expected no errors, got %d errors", result.TotalErrors())
+	}
+
+	doc, ok := input.(map[string]interface{})
+	if !ok {
+		t.Fatalf("Input should be a JSON object, got %T", input)
+	}
+	services, ok := doc["services"].(map[string]interface{})
+	if !ok || len(services) == 0 {
+		t.Errorf("Input should have the services of the specification, got %v", doc["services"])
+	}
+}
+
+// TestValidator_TestdataBuiltinRules checks that the shipped example specifications
+// pass the embedded policy pack, as with promener vet --rules builtin
+func TestValidator_TestdataBuiltinRules(t *testing.T) {
+	t.Setenv("PROMENER_CACHE_DIR", t.TempDir())
+
+	cuePaths, err := filepath.Glob(filepath.Join("..", "..", "testdata", "*.cue"))
+	if err != nil {
+		t.Fatalf("Glob failed: %v", err)
+	}
+	if len(cuePaths) == 0 {
+		t.Fatal("Expected example specifications in testdata")
+	}
+
+	for _, cuePath := range cuePaths {
+		t.Run(filepath.Base(cuePath), func(t *testing.T) {
+			v := New()
+			v.SetRulesDirs([]string{BuiltinSource})
+
+			result, err := v.Validate(cuePath)
+			if err != nil {
+				t.Fatalf("Validate failed: %v", err)
+			}
+			if result.Failed("error") {
+				for _, errs := range [][]ValidationError{result.CueErrors, result.DomainErrors, result.RegoErrors} {
+					for _, e := range errs {
+						t.Errorf("[%s] %s: %s", e.Severity, e.RuleID, e.Message)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package validator
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/jycamier/promener/internal/domain"
+)
+
+// WorkspaceSpec is a specification of a workspace with the file defining it
+type WorkspaceSpec struct {
+	File string
+	Spec *domain.Specification
+}
+
+// metricOccurrence is a metric of a service of a workspace specification
+type metricOccurrence struct {
+	file    string
+	service string
+	key     string
+	metric  domain.Metric
+}
+
+func (o metricOccurrence) path() string {
+	return fmt.Sprintf("services[%s].metrics[%s]", o.service, o.key)
+}
+
+func (o metricOccurrence) String() string {
+	return fmt.Sprintf("%s %s", o.file, o.path())
+}
+
+// CheckWorkspace reports the conflicts between the specifications of a workspace, which the
+// specifications cannot detect on their own:
+//   - services defined by several specifications (error), the last one wins when aggregated
+//   - metrics with the same full name but different types (error) or label names (error),
+//     rejected by Prometheus when scraped together
+//   - metrics with the same full name but a different help, unit, buckets or objectives (warning)
+//   - metrics defined identically by several specifications (info), candidates for a shared
+//     CUE fragment
+func CheckWorkspace(specs []WorkspaceSpec) []ValidationError {
+	var result []ValidationError
+
+	serviceFiles := map[string][]string{}
+	metrics := map[string][]metricOccurrence{}
+	for _, spec := range specs {
+		for _, name := range slices.Sorted(maps.Keys(spec.Spec.Services)) {
+			serviceFiles[name] = append(serviceFiles[name], spec.File)
+			for key, metric := range spec.Spec.Services[name].Metrics {
+				metrics[metric.FullName()] = append(metrics[metric.FullName()], metricOccurrence{
+					file:    spec.File,
+					service: name,
+					key:     key,
+					metric:  metric,
+				})
+			}
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(serviceFiles)) {
+		files := serviceFiles[name]
+		if len(files) < 2 {
+			continue
+		}
+		result = append(result, ValidationError{
+			Path:     fmt.Sprintf("services[%s]", name),
+			Message:  fmt.Sprintf("Service '%s' is defined by %s", name, joinAnd(files)),
+			Source:   "workspace",
+			Severity: "error",
+			RuleID:   "service-collision",
+			File:     files[0],
+		})
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(metrics)) {
+		occurrences := metrics[name]
+		if len(occurrences) < 2 {
+			continue
+		}
+		slices.SortFunc(occurrences, func(a, b metricOccurrence) int {
+			return strings.Compare(a.String(), b.String())
+		})
+		if err, ok := checkMetricOccurrences(name, occurrences); ok {
+			result = append(result, err)
+		}
+	}
+
+	return result
+}
+
+// checkMetricOccurrences compares the definitions of a metric, and returns the most severe conflict
+func checkMetricOccurrences(name string, occurrences []metricOccurrence) (ValidationError, bool) {
+	first := occurrences[0]
+	err := ValidationError{
+		Path:   first.path(),
+		Source: "workspace",
+		File:   first.file,
+	}
+
+	if variants := groupOccurrences(occurrences, func(o metricOccurrence) string { return string(o.metric.Type) }); len(variants.values) > 1 {
+		err.Severity, err.RuleID = "error", "metric-type-mismatch"
+		err.Message = fmt.Sprintf("Metric '%s' has different types: %s", name, variants)
+		return err, true
+	}
+
+	if variants := groupOccurrences(occurrences, labelNames); len(variants.values) > 1 {
+		err.Severity, err.RuleID = "error", "metric-label-mismatch"
+		err.Message = fmt.Sprintf("Metric '%s' has different labels: %s", name, variants)
+		return err, true
+	}
+
+	var fields []string
+	for _, field := range []struct {
+		name  string
+		value func(metricOccurrence) string
+	}{
+		{"help", func(o metricOccurrence) string { return o.metric.Help }},
+		{"unit", func(o metricOccurrence) string { return o.metric.Unit }},
+		{"buckets", func(o metricOccurrence) string { return fmt.Sprint(o.metric.Buckets) }},
+		{"objectives", func(o metricOccurrence) string { return fmt.Sprint(o.metric.Objectives) }},
+	} {
+		if len(groupOccurrences(occurrences, field.value).values) > 1 {
+			fields = append(fields, field.name)
+		}
+	}
+	if len(fields) > 0 {
+		err.Severity, err.RuleID = "warning", "metric-definition-mismatch"
+		err.Message = fmt.Sprintf("Metric '%s' has a different %s in %s", name, joinAnd(fields), joinAnd(occurrenceNames(occurrences)))
+		return err, true
+	}
+
+	files := map[string]bool{}
+	for _, o := range occurrences {
+		files[o.file] = true
+	}
+	if len(files) < 2 {
+		return ValidationError{}, false
+	}
+	err.Severity, err.RuleID = "info", "shared-metric"
+	err.Message = fmt.Sprintf("Metric '%s' is defined identically in %s", name, joinAnd(occurrenceNames(occurrences)))
+	err.Fix = "Move the definition to a shared CUE package imported by the specifications"
+	return err, true
+}
+
+// occurrenceVariants lists the occurrences of a metric by value of a field
+type occurrenceVariants struct {
+	values      []string
+	occurrences map[string][]metricOccurrence
+}
+
+func (v occurrenceVariants) String() string {
+	parts := make([]string, 0, len(v.values))
+	for _, value := range v.values {
+		parts = append(parts, fmt.Sprintf("%s in %s", value, joinAnd(occurrenceNames(v.occurrences[value]))))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// groupOccurrences groups the occurrences by value of a field, in the order of the occurrences
+func groupOccurrences(occurrences []metricOccurrence, value func(metricOccurrence) string) occurrenceVariants {
+	variants := occurrenceVariants{occurrences: map[string][]metricOccurrence{}}
+	for _, o := range occurrences {
+		v := value(o)
+		if _, ok := variants.occurrences[v]; !ok {
+			variants.values = append(variants.values, v)
+		}
+		variants.occurrences[v] = append(variants.occurrences[v], o)
+	}
+	return variants
+}
+
+// labelNames returns the sorted names of the labels and constant labels of a metric
+func labelNames(o metricOccurrence) string {
+	names := o.metric.GetLabelNames()
+	for _, label := range o.metric.ConstLabels {
+		names = append(names, label.Name)
+	}
+	slices.Sort(names)
+	return "[" + strings.Join(names, ", ") + "]"
+}
+
+func occurrenceNames(occurrences []metricOccurrence) []string {
+	names := make([]string, 0, len(occurrences))
+	for _, o := range occurrences {
+		names = append(names, o.String())
+	}
+	return names
+}
+
+// joinAnd joins words as "a, b and c"
+func joinAnd(words []string) string {
+	if len(words) < 2 {
+		return strings.Join(words, "")
+	}
+	return strings.Join(words[:len(words)-1], ", ") + " and " + words[len(words)-1]
+}
@@ -0,0 +1,168 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/jycamier/promener/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// named sets the names of the metrics to their keys, like the extractor
+func named(metrics map[string]domain.Metric) map[string]domain.Metric {
+	for key, metric := range metrics {
+		metric.Name = key
+		metrics[key] = metric
+	}
+	return metrics
+}
+
+func workspaceSpec(file, service string, metrics map[string]domain.Metric) WorkspaceSpec {
+	return WorkspaceSpec{
+		File: file,
+		Spec: &domain.Specification{
+			Services: map[string]domain.Service{service: {Metrics: named(metrics)}},
+		},
+	}
+}
+
+func counter(help string, labels ...string) domain.Metric {
+	metric := domain.Metric{Namespace: "http", Subsystem: "server", Type: domain.MetricTypeCounter, Help: help}
+	for _, label := range labels {
+		metric.Labels = append(metric.Labels, domain.LabelDefinition{Name: label})
+	}
+	return metric
+}
+
+func TestCheckWorkspace(t *testing.T) {
+	gauge := domain.Metric{Namespace: "http", Subsystem: "server", Type: domain.MetricTypeGauge, Help: "In flight"}
+
+	tests := []struct {
+		name  string
+		specs []WorkspaceSpec
+		want  []ValidationError
+	}{
+		{
+			name: "no conflict",
+			specs: []WorkspaceSpec{
+				workspaceSpec("a.cue", "orders", map[string]domain.Metric{"requests_total": counter("Requests")}),
+				workspaceSpec("b.cue", "payments", map[string]domain.Metric{"errors_total": counter("Errors")}),
+			},
+		},
+		{
+			name: "service collision",
+			specs: []WorkspaceSpec{
+				workspaceSpec("a.cue", "orders", map[string]domain.Metric{"requests_total": counter("Requests")}),
+				workspaceSpec("b.cue", "orders", map[string]domain.Metric{"errors_total": counter("Errors")}),
+			},
+			want: []ValidationError{{
+				Path:     "services[orders]",
+				Message:  "Service 'orders' is defined by a.cue and b.cue",
+				Source:   "workspace",
+				Severity: "error",
+				RuleID:   "service-collision",
+				File:     "a.cue",
+			}},
+		},
+		{
+			name: "type mismatch",
+			specs: []WorkspaceSpec{
+				workspaceSpec("a.cue", "orders", map[string]domain.Metric{"requests": counter("Requests")}),
+				workspaceSpec("b.cue", "payments", map[string]domain.Metric{"requests": gauge}),
+			},
+			want: []ValidationError{{
+				Path:     "services[orders].metrics[requests]",
+				Message:  "Metric 'http_server_requests' has different types: counter in a.cue services[orders].metrics[requests]; gauge in b.cue services[payments].metrics[requests]",
+				Source:   "workspace",
+				Severity: "error",
+				RuleID:   "metric-type-mismatch",
+				File:     "a.cue",
+			}},
+		},
+		{
+			name: "label mismatch in the same specification",
+			specs: []WorkspaceSpec{{
+				File: "a.cue",
+				Spec: &domain.Specification{Services: map[string]domain.Service{
+					"orders":   {Metrics: named(map[string]domain.Metric{"requests_total": counter("Requests", "method")})},
+					"payments": {Metrics: named(map[string]domain.Metric{"requests_total": counter("Requests", "status", "method")})},
+				}},
+			}},
+			want: []ValidationError{{
+				Path:     "services[orders].metrics[requests_total]",
+				Message:  "Metric 'http_server_requests_total' has different labels: [method] in a.cue services[orders].metrics[requests_total]; [method, status] in a.cue services[payments].metrics[requests_total]",
+				Source:   "workspace",
+				Severity: "error",
+				RuleID:   "metric-label-mismatch",
+				File:     "a.cue",
+			}},
+		},
+		{
+			name: "definition mismatch",
+			specs: []WorkspaceSpec{
+				workspaceSpec("a.cue", "orders", map[string]domain.Metric{"requests_total": counter("Requests", "method")}),
+				workspaceSpec("b.cue", "payments", map[string]domain.Metric{"requests_total": counter("HTTP requests", "method")}),
+			},
+			want: []ValidationError{{
+				Path:     "services[orders].metrics[requests_total]",
+				Message:  "Metric 'http_server_requests_total' has a different help in a.cue services[orders].metrics[requests_total] and b.cue services[payments].metrics[requests_total]",
+				Source:   "workspace",
+				Severity: "warning",
+				RuleID:   "metric-definition-mismatch",
+				File:     "a.cue",
+			}},
+		},
+		{
+			name: "shared metric",
+			specs: []WorkspaceSpec{
+				workspaceSpec("a.cue", "orders", map[string]domain.Metric{"requests_total": counter("Requests", "method")}),
+				workspaceSpec("b.cue", "payments", map[string]domain.Metric{"requests_total": counter("Requests", "method")}),
+			},
+			want: []ValidationError{{
+				Path:     "services[orders].metrics[requests_total]",
+				Message:  "Metric 'http_server_requests_total' is defined identically in a.cue services[orders].metrics[requests_total] and b.cue services[payments].metrics[requests_total]",
+				Source:   "workspace",
+				Severity: "info",
+				RuleID:   "shared-metric",
+				File:     "a.cue",
+				Fix:      "Move the definition to a shared CUE package imported by the specifications",
+			}},
+		},
+		{
+			name: "identical metrics of the same specification",
+			specs: []WorkspaceSpec{{
+				File: "a.cue",
+				Spec: &domain.Specification{Services: map[string]domain.Service{
+					"orders":   {Metrics: named(map[string]domain.Metric{"requests_total": counter("Requests")})},
+					"payments": {Metrics: named(map[string]domain.Metric{"requests_total": counter("Requests")})},
+				}},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, CheckWorkspace(tt.specs))
+		})
+	}
+}
+
+func TestValidationResult_Merge(t *testing.T) {
+	result := &ValidationResult{}
+	result.Merge("a.cue", &ValidationResult{
+		CueErrors:  []ValidationError{{Message: "invalid type", Severity: "error"}},
+		RegoErrors: []ValidationError{{Message: "missing help", Severity: "warning"}},
+	})
+	result.Merge("b.cue", &ValidationResult{
+		Suppressed: []ValidationError{{Message: "plural unit"}},
+	})
+
+	assert.Equal(t, []ValidationError{{Message: "invalid type", Severity: "error", File: "a.cue"}}, result.CueErrors)
+	assert.Equal(t, []ValidationError{{Message: "missing help", Severity: "warning", File: "a.cue"}}, result.RegoErrors)
+	assert.Equal(t, []ValidationError{{Message: "plural unit", File: "b.cue"}}, result.Suppressed)
+	assert.Equal(t, 2, result.TotalErrors())
+
+	result.WorkspaceErrors = []ValidationError{{Message: "shared metric", Severity: "info"}}
+	assert.Equal(t, 3, result.TotalErrors())
+	assert.True(t, result.Failed("info"))
+	assert.True(t, result.Failed("error"))
+}
@@ -0,0 +1,9 @@
+// Package rules contains the built-in Rego policy pack, enabled with --rules builtin.
+package rules
+
+import "embed"
+
+// FS contains the embedded Rego rules and their tests.
+//
+//go:embed *.rego
+var FS embed.FS
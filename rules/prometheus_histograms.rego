@@ -1,18 +1,44 @@
 package PromenerPolicy
 
+# Base units of the specification, the suffixes of histograms
+base_unit_suffixes := ["_seconds", "_bytes", "_ratio", "_celsius", "_volts", "_amperes", "_joules", "_grams", "_meters"]
+
 # Histogram and Summary naming
 # They should generally end in the unit (e.g., _seconds)
 PromenerPolicy contains result if {
     metric := get_metrics_common[_]
     metric.type == "histogram"
     
-    valid_suffixes := ["_seconds", "_bytes"]
-    not ends_with_any(metric.full_name, valid_suffixes)
+    not ends_with_any(metric.full_name, base_unit_suffixes)
 
     result := {
+        "rule_id": "histogram-unit-suffix",
+        "category": "naming",
+        "docs_url": "https://prometheus.io/docs/practices/naming/#metric-names",
         "path": metric.path,
         "message": sprintf("Histogram '%s' should end with a unit suffix (e.g., _seconds, _bytes)", [metric.full_name]),
-        "severity": "error"
+        "severity": severity("error")
+    }
+}
+
+# Buckets MUST be strictly increasing
+PromenerPolicy contains result if {
+    metric := get_metrics_common[_]
+    metric.type == "histogram"
+    buckets := object.get(metric.metric, "buckets", [])
+
+    some i
+    i > 0
+    buckets[i] <= buckets[i - 1]
+
+    result := {
+        "rule_id": "bucket-order",
+        "category": "correctness",
+        "docs_url": "https://prometheus.io/docs/practices/histograms/",
+        "path": sprintf("%s.buckets[%d]", [metric.path, i]),
+        "message": sprintf("Bucket %v of histogram '%s' should be greater than the previous bucket %v", [buckets[i], metric.full_name, buckets[i - 1]]),
+        "fix": "Sort the buckets in increasing order and remove the duplicates",
+        "severity": severity("error")
     }
 }
 
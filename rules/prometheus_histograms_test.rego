@@ -10,6 +10,7 @@ test_histogram_seconds_valid if {
                         "namespace": "http",
                         "subsystem": "server",
                         "type": "histogram",
+                        "help": "Duration of the HTTP requests",
                         "name": "request_duration_seconds"
                     }
                 }
@@ -30,6 +31,7 @@ test_histogram_bytes_valid if {
                         "namespace": "http",
                         "subsystem": "server",
                         "type": "histogram",
+                        "help": "Size of the HTTP responses",
                         "name": "response_size_bytes"
                     }
                 }
@@ -49,7 +51,8 @@ test_histogram_no_unit_invalid if {
                     "request_latency": {
                         "namespace": "http",
                         "subsystem": "server",
-                        "type": "histogram"
+                        "type": "histogram",
+                        "help": "Latency of the HTTP requests"
                         # full_name will be http_server_request_latency (no unit)
                     }
                 }
@@ -90,4 +93,28 @@ test_histogram_total_invalid if {
     some i
     results[i].severity == "error"
     contains(results[i].message, "should end with a unit suffix")
-}
\ No newline at end of file
+}
+# Test buckets in increasing order
+test_bucket_order_valid if {
+    mock_input := metric_input("request_duration", {
+        "namespace": "http", "subsystem": "server", "type": "histogram", "help": "Duration of the HTTP requests",
+        "name": "request_duration_seconds", "buckets": [0.01, 0.1, 1, 10]
+    })
+
+    count(PromenerPolicy) == 0 with input as mock_input
+}
+
+# Test unsorted and duplicated buckets
+test_bucket_order_invalid if {
+    mock_input := metric_input("request_duration", {
+        "namespace": "http", "subsystem": "server", "type": "histogram", "help": "Duration of the HTTP requests",
+        "name": "request_duration_seconds", "buckets": [0.1, 0.01, 1, 1, 10]
+    })
+
+    results := rule_results("bucket-order", mock_input)
+    paths := {result.path | some result in results}
+    paths == {
+        "services[api].metrics[request_duration].buckets[1]",
+        "services[api].metrics[request_duration].buckets[3]"
+    }
+}
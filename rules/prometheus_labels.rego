@@ -7,9 +7,12 @@ PromenerPolicy contains result if {
     contains(metric.full_name, label_name)
 
     result := {
+        "rule_id": "label-in-metric-name",
+        "category": "labels",
+        "docs_url": "https://prometheus.io/docs/practices/naming/#labels",
         "path": sprintf("%s.labels[%s]", [metric.path, label_name]),
         "message": sprintf("Metric name '%s' should not contain label name '%s'", [metric.full_name, label_name]),
-        "severity": "warning"
+        "severity": severity("warning")
     }
 }
 
@@ -21,8 +24,28 @@ PromenerPolicy contains result if {
     reserved_labels[label_name]
 
     result := {
+        "rule_id": "reserved-label",
+        "category": "labels",
+        "docs_url": "https://prometheus.io/docs/concepts/jobs_instances/",
         "path": sprintf("%s.labels[%s]", [metric.path, label_name]),
         "message": sprintf("Label '%s' is reserved by Prometheus", [label_name]),
-        "severity": "error"
+        "severity": severity("error")
     }
-}
\ No newline at end of file
+}
+# Labels with unbounded values explode the number of series
+PromenerPolicy contains result if {
+    high_risk_labels := {"user_id", "email", "url", "trace_id", "span_id", "request_id", "session_id"}
+    metric := get_metrics_common[_]
+    metric.labels[label_name]
+    high_risk_labels[label_name]
+
+    result := {
+        "rule_id": "high-cardinality-label",
+        "category": "cardinality",
+        "docs_url": "https://prometheus.io/docs/practices/naming/#labels",
+        "path": sprintf("%s.labels[%s]", [metric.path, label_name]),
+        "message": sprintf("Label '%s' of metric '%s' has unbounded values and creates a series per value", [label_name, metric.full_name]),
+        "fix": "Record the value in logs or traces, or replace it with a bounded value such as a route template",
+        "severity": severity("warning")
+    }
+}
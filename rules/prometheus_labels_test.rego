@@ -0,0 +1,37 @@
+package PromenerPolicy
+
+# Test labels with unbounded values
+test_high_cardinality_label_invalid if {
+    mock_input := metric_input("requests_total", {
+        "namespace": "http", "subsystem": "server", "type": "counter", "help": "Total number of HTTP requests",
+        "labels": {"user_id": {"description": "User"}, "method": {"description": "HTTP method"}}
+    })
+
+    results := rule_results("high-cardinality-label", mock_input)
+    count(results) == 1
+    some result in results
+    result.path == "services[api].metrics[requests_total].labels[user_id]"
+    result.severity == severity("warning")
+}
+
+# Test labels shared through labelRefs
+test_high_cardinality_label_shared if {
+    mock_input := {
+        "labels": {"trace_id": {"description": "Trace"}},
+        "services": {"api": {"metrics": {"requests_total": {
+            "namespace": "http", "subsystem": "server", "type": "counter", "help": "Total number of HTTP requests",
+            "labelRefs": ["trace_id"]
+        }}}}
+    }
+
+    count(rule_results("high-cardinality-label", mock_input)) == 1
+}
+
+test_high_cardinality_label_valid if {
+    mock_input := metric_input("requests_total", {
+        "namespace": "http", "subsystem": "server", "type": "counter", "help": "Total number of HTTP requests",
+        "labels": {"route": {"description": "Route template"}}
+    })
+
+    count(PromenerPolicy) == 0 with input as mock_input
+}
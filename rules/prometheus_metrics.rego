@@ -7,8 +7,12 @@ PromenerPolicy contains result if {
     not endswith(metric.full_name, "_total")
 
     result := {
-        "severity": "error",
+        "rule_id": "counter-total-suffix",
+        "category": "naming",
+        "docs_url": "https://prometheus.io/docs/practices/naming/#metric-names",
+        "severity": severity("error"),
         "message": sprintf("Counter metric '%s' should end with '_total'", [metric.full_name]),
+        "fix": sprintf("Rename the metric to '%s_total'", [metric.full_name]),
         "path": metric.path
     }
 }
@@ -20,7 +24,10 @@ PromenerPolicy contains result if {
     endswith(metric.full_name, "_total")
 
     result := {
-        "severity": "warning",
+        "rule_id": "non-counter-total-suffix",
+        "category": "naming",
+        "docs_url": "https://prometheus.io/docs/practices/naming/#metric-names",
+        "severity": severity("warning"),
         "message": sprintf("Non-counter metric '%s' (type: %s) should not end with '_total'", [metric.full_name, metric.type]),
         "path": metric.path
     }
@@ -34,7 +41,10 @@ PromenerPolicy contains result if {
     endswith(metric.full_name, unit)
 
     result := {
-        "severity": "error",
+        "rule_id": "plural-unit",
+        "category": "naming",
+        "docs_url": "https://prometheus.io/docs/practices/naming/#base-units",
+        "severity": severity("error"),
         "message": sprintf("Metric '%s' should use plural unit (e.g. %ss)", [metric.full_name, unit]),
         "path": metric.path
     }
@@ -50,8 +60,61 @@ PromenerPolicy contains result if {
     parts[i] == parts[i+1]
 
     result := {
-        "severity": "warning",
+        "rule_id": "repeated-name-segment",
+        "category": "naming",
+        "docs_url": "https://prometheus.io/docs/practices/naming/#metric-names",
+        "severity": severity("warning"),
         "message": sprintf("Metric name '%s' contains repeated segment '%s'", [metric.full_name, parts[i]]),
         "path": metric.path
     }
-}
\ No newline at end of file
+}
+# Gauges MUST NOT use the suffixes of the histogram and summary series
+PromenerPolicy contains result if {
+    reserved_suffixes := ["_count", "_sum", "_bucket"]
+    metric := get_metrics_common[_]
+    metric.type == "gauge"
+    suffix := reserved_suffixes[_]
+    endswith(metric.full_name, suffix)
+
+    result := {
+        "rule_id": "gauge-reserved-suffix",
+        "category": "naming",
+        "docs_url": "https://github.com/OpenObservability/OpenMetrics/blob/main/specification/OpenMetrics.md#gauge",
+        "severity": severity("error"),
+        "message": sprintf("Gauge '%s' should not end with '%s', reserved for the series of histograms and summaries", [metric.full_name, suffix]),
+        "path": metric.path
+    }
+}
+
+# Metrics SHOULD have a help text
+PromenerPolicy contains result if {
+    metric := get_metrics_common[_]
+    trim_space(metric.help) == ""
+
+    result := {
+        "rule_id": "help-text",
+        "category": "documentation",
+        "docs_url": "https://prometheus.io/docs/instrumenting/writing_exporters/#help-strings",
+        "severity": severity("warning"),
+        "message": sprintf("Metric '%s' has no help text", [metric.full_name]),
+        "path": metric.path
+    }
+}
+
+# The help text SHOULD describe the metric, not repeat its name
+PromenerPolicy contains result if {
+    metric := get_metrics_common[_]
+    help := lower(replace(trim(metric.help, " ."), " ", "_"))
+    help != ""
+    help in {metric.key, object.get(metric.metric, "name", metric.key), metric.full_name}
+
+    result := {
+        "rule_id": "help-text",
+        "category": "documentation",
+        "docs_url": "https://prometheus.io/docs/instrumenting/writing_exporters/#help-strings",
+        "severity": severity("warning"),
+        "message": sprintf("Help text of metric '%s' only repeats its name", [metric.full_name]),
+        "fix": "Describe what the metric measures and when it changes",
+        "path": metric.path
+    }
+}
@@ -0,0 +1,57 @@
+package PromenerPolicy
+
+# Test gauges using the suffixes of histograms and summaries
+test_gauge_reserved_suffix_invalid if {
+    mock_input := metric_input("queue_count", {
+        "namespace": "jobs", "subsystem": "worker", "type": "gauge", "help": "Jobs in the queue"
+    })
+
+    results := rule_results("gauge-reserved-suffix", mock_input)
+    count(results) == 1
+    some result in results
+    result.severity == "error"
+    contains(result.message, "'_count'")
+}
+
+test_gauge_reserved_suffix_valid if {
+    mock_input := metric_input("queue_length", {
+        "namespace": "jobs", "subsystem": "worker", "type": "gauge", "help": "Jobs in the queue"
+    })
+
+    count(rule_results("gauge-reserved-suffix", mock_input)) == 0
+}
+
+# Test missing and empty help texts
+test_help_text_missing if {
+    mock_input := metric_input("requests_total", {
+        "namespace": "http", "subsystem": "server", "type": "counter"
+    })
+
+    count(rule_results("help-text", mock_input)) == 1
+}
+
+test_help_text_empty if {
+    mock_input := metric_input("requests_total", {
+        "namespace": "http", "subsystem": "server", "type": "counter", "help": "  "
+    })
+
+    count(rule_results("help-text", mock_input)) == 1
+}
+
+# Test help texts repeating the name of the metric
+test_help_text_metric_name if {
+    every help in ["requests_total", "Requests total.", "http_server_requests_total"] {
+        mock_input := metric_input("requests_total", {
+            "namespace": "http", "subsystem": "server", "type": "counter", "help": help
+        })
+        count(rule_results("help-text", mock_input)) == 1
+    }
+}
+
+test_help_text_valid if {
+    mock_input := metric_input("requests_total", {
+        "namespace": "http", "subsystem": "server", "type": "counter", "help": "Total number of HTTP requests"
+    })
+
+    count(PromenerPolicy) == 0 with input as mock_input
+}
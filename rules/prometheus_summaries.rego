@@ -0,0 +1,26 @@
+package PromenerPolicy
+
+# Summary objectives MUST map quantiles in (0, 1) to allowed errors in (0, 1)
+PromenerPolicy contains result if {
+    metric := get_metrics_common[_]
+    metric.type == "summary"
+    some quantile, allowed_error in object.get(metric.metric, "objectives", {})
+    not valid_objective(quantile, allowed_error)
+
+    result := {
+        "rule_id": "summary-objectives",
+        "category": "correctness",
+        "docs_url": "https://prometheus.io/docs/practices/histograms/#quantiles",
+        "path": sprintf("%s.objectives[%s]", [metric.path, quantile]),
+        "message": sprintf("Objective %s: %v of summary '%s' should have a quantile and an allowed error between 0 and 1, exclusive", [quantile, allowed_error, metric.full_name]),
+        "severity": severity("error")
+    }
+}
+
+valid_objective(quantile, allowed_error) if {
+    q := to_number(quantile)
+    q > 0
+    q < 1
+    allowed_error > 0
+    allowed_error < 1
+}
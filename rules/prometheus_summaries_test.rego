@@ -0,0 +1,33 @@
+package PromenerPolicy
+
+summary_input(objectives) := metric_input("request_duration_seconds", {
+    "namespace": "http", "subsystem": "server", "type": "summary", "help": "Duration of the HTTP requests",
+    "objectives": objectives
+})
+
+# Test objectives with quantiles and allowed errors between 0 and 1
+test_summary_objectives_valid if {
+    count(PromenerPolicy) == 0 with input as summary_input({"0.5": 0.05, "0.9": 0.01, "0.99": 0.001})
+}
+
+# Test quantiles outside (0, 1)
+test_summary_objectives_quantile_invalid if {
+    results := rule_results("summary-objectives", summary_input({"0": 0.05, "0.5": 0.05, "1": 0.001, "99": 0.001}))
+    count(results) == 3
+    paths := {result.path | some result in results}
+    paths == {
+        "services[api].metrics[request_duration_seconds].objectives[0]",
+        "services[api].metrics[request_duration_seconds].objectives[1]",
+        "services[api].metrics[request_duration_seconds].objectives[99]"
+    }
+}
+
+# Test allowed errors outside (0, 1)
+test_summary_objectives_error_invalid if {
+    count(rule_results("summary-objectives", summary_input({"0.5": 0, "0.9": 1.5}))) == 2
+}
+
+# Test quantiles that are not numbers
+test_summary_objectives_not_number if {
+    count(rule_results("summary-objectives", summary_input({"p99": 0.001}))) == 1
+}
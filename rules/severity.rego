@@ -0,0 +1,9 @@
+package PromenerPolicy
+
+# Set to true by the builtin:strict rule source
+default strict := false
+
+# Severity of a violation, every violation is an error in strict mode
+severity(level) := "error" if {
+    strict
+} else := level
@@ -12,23 +12,49 @@ get_full_name(metric, key) := name if {
     name := sprintf("%s_%s_%s", [metric.namespace, metric.subsystem, key])
 }
 
+# Graph of the metric templates, from each template to the one it extends
+template_graph[name] := [parent | parent := template.extends] if {
+    some name, template in input.metricTemplates
+}
+
+# Templates a metric extends, directly or through other templates, from the base template. A
+# template is sorted by the number of templates it reaches, which grows along the chain.
+extended_templates(metric) := [input.metricTemplates[name] | some pair in sorted; name := pair[1]] if {
+    names := graph.reachable(template_graph, {metric.extends})
+    sorted := sort([[count(graph.reachable(template_graph, {name})), name] | some name in names])
+} else := []
+
+# Metric completed with the fields of the templates it extends, the closest one first
+resolve_metric(metric) := object.union_n(array.concat(extended_templates(metric), [metric]))
+
+# Shared labels referenced by labelRefs followed by the inline labels of a metric or a template
+own_labels(metric) := object.union(shared, object.get(metric, "labels", {})) if {
+    shared := {name: label |
+        some name in object.get(metric, "labelRefs", [])
+        label := input.labels[name]
+    }
+}
+
 # Common helper to iterate over metrics with enriched data
 get_metrics_common contains res if {
     some service_name, key
     service := input.services[service_name]
-    metric := service.metrics[key]
+    metric := resolve_metric(service.metrics[key])
     
     full_name := get_full_name(metric, key)
     
-    # Handle optional labels safely
-    labels := object.get(metric, "labels", {})
+    # Labels of the templates from the base one, then the labels of the metric
+    chain := array.concat(extended_templates(service.metrics[key]), [service.metrics[key]])
+    labels := object.union_n([own_labels(m) | some m in chain])
 
     res := {
         "service_name": service_name,
         "key": key,
         "full_name": full_name,
         "type": metric.type,
+        "help": object.get(metric, "help", ""),
         "labels": labels,
+        "metric": metric,
         "path": sprintf("services[%s].metrics[%s]", [service_name, key])
     }
-}
\ No newline at end of file
+}
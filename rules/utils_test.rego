@@ -0,0 +1,72 @@
+package PromenerPolicy
+
+# Results of a rule for an input
+rule_results(rule_id, mock_input) := {r |
+    some r in PromenerPolicy with input as mock_input
+    r.rule_id == rule_id
+}
+
+# Input with a single metric in the api service
+metric_input(key, metric) := {"services": {"api": {"metrics": {key: metric}}}}
+
+# Test metrics completed with their template and shared labels
+test_get_metrics_common_templates if {
+    mock_input := {
+        "labels": {"method": {"description": "HTTP method"}},
+        "metricTemplates": {"http": {
+            "namespace": "http",
+            "subsystem": "server",
+            "help": "HTTP requests",
+            "labels": {"route": {"description": "Route template"}}
+        }},
+        "services": {"api": {"metrics": {"requests_total": {
+            "extends": "http",
+            "type": "counter",
+            "labelRefs": ["method"]
+        }}}}
+    }
+
+    metrics := get_metrics_common with input as mock_input
+    some metric in metrics
+    metric.full_name == "http_server_requests_total"
+    metric.help == "HTTP requests"
+    object.keys(metric.labels) == {"method", "route"}
+}
+
+# Test metrics completed with a chain of templates, the closest template overriding the base one
+test_get_metrics_common_template_chain if {
+    mock_input := {
+        "labels": {"method": {"description": "HTTP method"}},
+        "metricTemplates": {
+            "http": {
+                "namespace": "http",
+                "subsystem": "server",
+                "help": "HTTP requests",
+                "labels": {"route": {"description": "Route template"}}
+            },
+            "http_counter": {
+                "extends": "http",
+                "type": "counter",
+                "help": "HTTP requests handled",
+                "labels": {"status": {"description": "Status code"}}
+            }
+        },
+        "services": {"api": {"metrics": {"requests_total": {
+            "extends": "http_counter",
+            "labelRefs": ["method"]
+        }}}}
+    }
+
+    metrics := get_metrics_common with input as mock_input
+    some metric in metrics
+    metric.full_name == "http_server_requests_total"
+    metric.type == "counter"
+    metric.help == "HTTP requests handled"
+    object.keys(metric.labels) == {"method", "route", "status"}
+}
+
+# Test strict mode raising every severity to error
+test_severity_strict if {
+    severity("warning") == "warning" with strict as false
+    severity("warning") == "error" with strict as true
+}
@@ -35,19 +35,26 @@ package v1
 	annotations?: [string]: string
 }
 
+#Label: {
+	description: string
+	validations?: [...string]
+	inherited?: string
+}
+
 #Metric: {
 	// Explicit metric name. If not provided, the CUE map key will be used.
 	// The full metric name will be: namespace_subsystem_name
-	name?:      string
-	namespace:  string
+	name?: string
+	// Name of a metricTemplates entry providing the fields this metric leaves out.
+	// namespace, type and help are required once the template is applied.
+	extends?:   string
+	namespace?: string
 	subsystem?: string
-	type:       "counter" | "gauge" | "histogram" | "summary"
-	help:       string
-	labels?: [string]: {
-		description: string
-		validations?: [...string]
-		inherited?: string
-	}
+	type?:      "counter" | "gauge" | "histogram" | "summary"
+	help?:      string
+	// Names of shared labels (top-level labels) used by this metric, before its own labels
+	labelRefs?: [...string]
+	labels?: [string]: #Label
 	constLabels?: [string]: {
 		value:       string
 		description: string
@@ -58,8 +65,20 @@ package v1
 		promql?: [...#PromQLExample]
 		alerts?: [...#AlertExample]
 	}
+	// Binds the metric to generated instrumentation (e.g. gRPC interceptors with --grpc)
+	role?: #MetricRole
+	// Unit of the observed values. "seconds" generates timer helpers (Time<Metric>).
+	unit?: string
+	// What a gauge measures: "in_flight" generates Track<Metric>InFlight helpers,
+	// "timestamp" generates SetToCurrentTime<Metric> helpers.
+	semantics?: "in_flight" | "timestamp"
 }
 
+#MetricRole: "grpc_server_started" | "grpc_server_handled" | "grpc_server_handling_seconds" |
+	"grpc_server_in_flight" | "grpc_server_msg_received" | "grpc_server_msg_sent" |
+	"grpc_client_started" | "grpc_client_handled" | "grpc_client_handling_seconds" |
+	"grpc_client_in_flight" | "grpc_client_msg_received" | "grpc_client_msg_sent"
+
 // =============================================================================
 // Golden Signals definitions
 // =============================================================================
@@ -97,7 +116,24 @@ package v1
 	thresholds?: #Thresholds
 }
 
+#HTTPLabelMapping: {
+	// Label receiving the HTTP request method (e.g. "GET")
+	method?: string
+
+	// Label receiving the route template (e.g. "/orders/{id}"), never the raw path
+	route?: string
+
+	// Label receiving the response status code (e.g. "200")
+	statusCode?: string
+
+	// Label receiving the response status code class (e.g. "2xx")
+	statusClass?: string
+}
+
 #GoldenSignals: {
+	// Label mapping used by the generated HTTP middleware (--middleware)
+	http?: #HTTPLabelMapping
+
 	// Latency: How long it takes to service a request
 	latency?: #GoldenSignal
 
@@ -118,6 +154,13 @@ package v1
 #Promener: {
 	version: string | *"1.0"
 	info:    #Info
+
+	// Labels shared by several metrics through labelRefs, defined (and validated) once
+	labels?: [string]: #Label
+
+	// Partial metrics that metrics complete through extends
+	metricTemplates?: [string]: #Metric
+
 	services?: [string]: {
 		info:    #Info
 		servers?: [...#Server]
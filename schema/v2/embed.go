@@ -0,0 +1,8 @@
+package v2
+
+import _ "embed"
+
+// Schema contains the embedded CUE schema for v2 specifications.
+//
+//go:embed schema.cue
+var Schema string
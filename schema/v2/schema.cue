@@ -0,0 +1,202 @@
+package v2
+
+// =============================================================================
+// Common definitions
+// =============================================================================
+
+#Info: {
+	title:        string
+	description?: string
+	version:      string
+	package?:     string
+}
+
+#Server: {
+	url:         string
+	description: string
+}
+
+// =============================================================================
+// Metrics definitions
+// =============================================================================
+
+#PromQLExample: {
+	query:       string
+	description: string
+}
+
+#AlertExample: {
+	name:        string
+	expr:        string
+	description: string
+	for:         string
+	severity:    "info" | "warning" | "critical"
+	labels?: [string]:      string
+	annotations?: [string]: string
+}
+
+#Label: {
+	description: string
+	validations?: [...string]
+	inherited?: string
+}
+
+#Metric: {
+	// Explicit metric name. If not provided, the CUE map key will be used.
+	// The full metric name will be: namespace_subsystem_name
+	name?: string
+	// Name of a metricTemplates entry providing the fields this metric leaves out.
+	// namespace, type and help are required once the template is applied.
+	extends?:   string
+	namespace?: string
+	subsystem?: string
+	type?:      "counter" | "gauge" | "histogram" | "summary"
+	help?:      string
+	// Names of shared labels (top-level labels) used by this metric, before its own labels
+	labelRefs?: [...string]
+	labels?: [string]: #Label
+	constLabels?: [string]: {
+		value:       string
+		description: string
+	}
+	buckets?: [...number]
+	objectives?: [string]: number
+	examples?: {
+		promql?: [...#PromQLExample]
+		alerts?: [...#AlertExample]
+	}
+	// Binds the metric to generated instrumentation (e.g. gRPC interceptors with --grpc)
+	role?: #MetricRole
+	// Base unit of the observed values, exposed as OpenMetrics # UNIT metadata.
+	// The metric name must end with _<unit> (_<unit>_total for counters).
+	// "seconds" generates timer helpers (Time<Metric>).
+	unit?: #Unit
+	// What a gauge measures: "in_flight" generates Track<Metric>InFlight helpers,
+	// "timestamp" generates SetToCurrentTime<Metric> helpers.
+	semantics?: "in_flight" | "timestamp"
+	// Stability level, following the Kubernetes metrics stability framework
+	stability?: #Stability
+	// Team responsible for the metric
+	owner?: #Owner
+	// Version of the specification introducing the metric
+	since?: string
+	// Service level objective measured with the metric
+	slo?: #SLO
+}
+
+#Unit: "seconds" | "bytes" | "ratio" | "celsius" | "volts" | "amperes" | "joules" | "grams" | "meters"
+
+// alpha: can change or be removed at any time
+// beta: name and labels only change after a deprecation period
+// stable: name and labels are guaranteed not to change
+#Stability: "alpha" | "beta" | "stable"
+
+#Owner: {
+	team:     string
+	contact?: string
+}
+
+#SLO: {
+	// Target ratio of good events, e.g. 0.999
+	objective: number & >0 & <1
+	// Compliance window as a Prometheus duration, e.g. "30d"
+	window: =~"^([0-9]+(ms|s|m|h|d|w|y))+$"
+	description?: string
+}
+
+#MetricRole: "grpc_server_started" | "grpc_server_handled" | "grpc_server_handling_seconds" |
+	"grpc_server_in_flight" | "grpc_server_msg_received" | "grpc_server_msg_sent" |
+	"grpc_client_started" | "grpc_client_handled" | "grpc_client_handling_seconds" |
+	"grpc_client_in_flight" | "grpc_client_msg_received" | "grpc_client_msg_sent"
+
+// =============================================================================
+// Golden Signals definitions
+// =============================================================================
+
+#RecordingRule: {
+	// Name of the recording rule (will be used as metric name)
+	name: string
+
+	// PromQL query
+	query: string
+}
+
+#Thresholds: {
+	// Value considered good (green)
+	good: string
+
+	// Value considered warning (yellow)
+	warning?: string
+
+	// Value considered critical (red)
+	critical: string
+}
+
+#GoldenSignal: {
+	// What this signal measures
+	description: string
+
+	// References to metric names defined in this service
+	metrics: [...string]
+
+	// Pre-computed recording rules for dashboards
+	recordingRules?: [...#RecordingRule]
+
+	// Thresholds for dashboard visualization
+	thresholds?: #Thresholds
+}
+
+#HTTPLabelMapping: {
+	// Label receiving the HTTP request method (e.g. "GET")
+	method?: string
+
+	// Label receiving the route template (e.g. "/orders/{id}"), never the raw path
+	route?: string
+
+	// Label receiving the response status code (e.g. "200")
+	statusCode?: string
+
+	// Label receiving the response status code class (e.g. "2xx")
+	statusClass?: string
+}
+
+#GoldenSignals: {
+	// Label mapping used by the generated HTTP middleware (--middleware)
+	http?: #HTTPLabelMapping
+
+	// Latency: How long it takes to service a request
+	latency?: #GoldenSignal
+
+	// Errors: The rate of requests that fail
+	errors?: #GoldenSignal
+
+	// Traffic: How much demand is being placed on your system
+	traffic?: #GoldenSignal
+
+	// Saturation: How "full" your service is
+	saturation?: #GoldenSignal
+}
+
+// =============================================================================
+// Root schema
+// =============================================================================
+
+#Promener: {
+	version: string | *"2.0"
+	info:    #Info
+
+	// Labels shared by several metrics through labelRefs, defined (and validated) once
+	labels?: [string]: #Label
+
+	// Partial metrics that metrics complete through extends
+	metricTemplates?: [string]: #Metric
+
+	services?: [string]: {
+		info:    #Info
+		servers?: [...#Server]
+		metrics: [string]: #Metric
+
+		// Golden Signals by topic (http, database, cache, queue, etc.)
+		goldenSignals?: [string]: #GoldenSignals
+	}
+}
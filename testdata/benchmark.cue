@@ -0,0 +1,139 @@
+package main
+
+// Metrics benchmarked by the generated code regression suite (internal/generator/benchmark_test.go).
+// Every metric type comes without labels, with labels, and with validated labels.
+
+version: "1.0.0"
+
+info: {
+	title:   "Generated Code Benchmarks"
+	version: "1.0.0"
+}
+
+#Labels: {
+	method: description: "HTTP method"
+	status: description: "HTTP status code"
+}
+
+#ValidatedLabels: {
+	method: {
+		description: "HTTP method"
+		validations: ["value in ['GET', 'POST', 'PUT', 'DELETE', 'PATCH', 'HEAD', 'OPTIONS']"]
+	}
+	status: {
+		description: "HTTP status code"
+		validations: ["value.matches('^[1-5][0-9]{2}$')"]
+	}
+	path: {
+		description: "Route template"
+		validations: ["value.startsWith('/')", "size(value) <= 200"]
+	}
+}
+
+services: {
+	bench: {
+		info: {
+			title:   "Benchmarks"
+			version: "1.0.0"
+		}
+
+		metrics: {
+			events_total: {
+				namespace: "bench"
+				subsystem: "counter"
+				type:      "counter"
+				help:      "Counter without labels"
+			}
+			labeled_events_total: {
+				namespace: "bench"
+				subsystem: "counter"
+				type:      "counter"
+				help:      "Counter with labels"
+				labels:    #Labels
+			}
+			validated_events_total: {
+				namespace: "bench"
+				subsystem: "counter"
+				type:      "counter"
+				help:      "Counter with validated labels"
+				labels:    #ValidatedLabels
+			}
+			tenant_events_total: {
+				namespace: "bench"
+				subsystem: "counter"
+				type:      "counter"
+				help:      "Counter with a label validated by CEL"
+				labels: tenant: {
+					description: "Tenant identifier"
+					validations: ["!value.contains(' ')"]
+				}
+			}
+
+			queue_depth: {
+				namespace: "bench"
+				subsystem: "gauge"
+				type:      "gauge"
+				help:      "Gauge without labels"
+			}
+			labeled_queue_depth: {
+				namespace: "bench"
+				subsystem: "gauge"
+				type:      "gauge"
+				help:      "Gauge with labels"
+				labels:    #Labels
+			}
+			validated_queue_depth: {
+				namespace: "bench"
+				subsystem: "gauge"
+				type:      "gauge"
+				help:      "Gauge with validated labels"
+				labels:    #ValidatedLabels
+			}
+
+			duration_seconds: {
+				namespace: "bench"
+				subsystem: "histogram"
+				type:      "histogram"
+				help:      "Histogram without labels"
+				buckets: [0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10]
+			}
+			labeled_duration_seconds: {
+				namespace: "bench"
+				subsystem: "histogram"
+				type:      "histogram"
+				help:      "Histogram with labels"
+				labels:    #Labels
+				buckets: [0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10]
+			}
+			validated_duration_seconds: {
+				namespace: "bench"
+				subsystem: "histogram"
+				type:      "histogram"
+				help:      "Histogram with validated labels"
+				labels:    #ValidatedLabels
+				buckets: [0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10]
+			}
+
+			size_bytes: {
+				namespace: "bench"
+				subsystem: "summary"
+				type:      "summary"
+				help:      "Summary without labels"
+			}
+			labeled_size_bytes: {
+				namespace: "bench"
+				subsystem: "summary"
+				type:      "summary"
+				help:      "Summary with labels"
+				labels:    #Labels
+			}
+			validated_size_bytes: {
+				namespace: "bench"
+				subsystem: "summary"
+				type:      "summary"
+				help:      "Summary with validated labels"
+				labels:    #ValidatedLabels
+			}
+		}
+	}
+}
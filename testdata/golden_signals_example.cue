@@ -104,6 +104,7 @@ services: {
 				namespace: "http"
 				subsystem: "server"
 				type:      "histogram"
+				unit:      "seconds"
 				help:      "HTTP request duration in seconds"
 				buckets: [0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10]
 				labels: {
@@ -144,6 +145,7 @@ services: {
 				namespace: "http"
 				subsystem: "server"
 				type:      "gauge"
+				semantics: "in_flight"
 				help:      "Current number of HTTP requests being processed"
 				examples: {
 					promql: [
@@ -245,6 +247,7 @@ services: {
 				namespace: "db"
 				subsystem: "postgres"
 				type:      "histogram"
+				unit:      "seconds"
 				help:      "Database query duration in seconds"
 				buckets: [0.0001, 0.0005, 0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5]
 				labels: {
@@ -360,6 +363,7 @@ services: {
 			}
 
 			cache_operation_duration_seconds: {
+				name:      "duration_seconds"
 				namespace: "cache"
 				subsystem: "redis"
 				type:      "histogram"
@@ -371,6 +375,7 @@ services: {
 			}
 
 			cache_keys_total: {
+				name:      "keys"
 				namespace: "cache"
 				subsystem: "redis"
 				type:      "gauge"
@@ -399,6 +404,7 @@ services: {
 			// Business metrics
 			// =========================================
 			orders_created_total: {
+				name:      "created_total"
 				namespace: "business"
 				subsystem: "orders"
 				type:      "counter"
@@ -432,6 +438,7 @@ services: {
 			}
 
 			orders_value_total: {
+				name:      "value_total"
 				namespace: "business"
 				subsystem: "orders"
 				type:      "counter"
@@ -456,9 +463,11 @@ services: {
 			}
 
 			orders_processing_duration_seconds: {
+				name:      "processing_duration_seconds"
 				namespace: "business"
 				subsystem: "orders"
 				type:      "histogram"
+				unit:      "seconds"
 				help:      "Time taken to process an order from creation to completion"
 				buckets: [0.1, 0.5, 1, 2, 5, 10, 30, 60, 120, 300]
 				labels: {
@@ -466,10 +475,126 @@ services: {
 				}
 			}
 
+			orders_last_processed_timestamp_seconds: {
+				name:      "last_processed_timestamp_seconds"
+				namespace: "business"
+				subsystem: "orders"
+				type:      "gauge"
+				unit:      "seconds"
+				semantics: "timestamp"
+				help:      "Unix time of the last processed order"
+			}
+
+			// =========================================
+			// gRPC metrics (recorded by the generated interceptors with --grpc)
+			// =========================================
+			grpc_server_started_total: {
+				name:      "started_total"
+				namespace: "grpc"
+				subsystem: "server"
+				type:      "counter"
+				help:      "Total number of RPCs started on the server"
+				role:      "grpc_server_started"
+				labels: {
+					grpc_type:    {description: "Type of the RPC (unary, client_stream, server_stream, bidi_stream)"}
+					grpc_service: {description: "Fully qualified gRPC service name"}
+					grpc_method:  {description: "gRPC method name"}
+				}
+			}
+
+			grpc_server_handled_total: {
+				name:      "handled_total"
+				namespace: "grpc"
+				subsystem: "server"
+				type:      "counter"
+				help:      "Total number of RPCs completed on the server, regardless of success or failure"
+				role:      "grpc_server_handled"
+				labels: {
+					grpc_type:    {description: "Type of the RPC (unary, client_stream, server_stream, bidi_stream)"}
+					grpc_service: {description: "Fully qualified gRPC service name"}
+					grpc_method:  {description: "gRPC method name"}
+					grpc_code: {
+						description: "gRPC status code"
+						validations: [
+							"value.matches('^[A-Z][A-Za-z]+$')",
+						]
+					}
+				}
+			}
+
+			grpc_server_handling_seconds: {
+				name:      "handling_seconds"
+				namespace: "grpc"
+				subsystem: "server"
+				type:      "histogram"
+				unit:      "seconds"
+				help:      "Response latency of RPCs handled by the server"
+				role:      "grpc_server_handling_seconds"
+				buckets: [0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5]
+				labels: {
+					grpc_type:    {description: "Type of the RPC (unary, client_stream, server_stream, bidi_stream)"}
+					grpc_service: {description: "Fully qualified gRPC service name"}
+					grpc_method:  {description: "gRPC method name"}
+				}
+			}
+
+			grpc_server_in_flight: {
+				name:      "in_flight"
+				namespace: "grpc"
+				subsystem: "server"
+				type:      "gauge"
+				semantics: "in_flight"
+				help:      "Number of RPCs currently handled by the server"
+				role:      "grpc_server_in_flight"
+				labels: {
+					grpc_service: {description: "Fully qualified gRPC service name"}
+				}
+			}
+
+			grpc_server_msg_received_total: {
+				name:      "msg_received_total"
+				namespace: "grpc"
+				subsystem: "server"
+				type:      "counter"
+				help:      "Total number of RPC stream messages received on the server"
+				role:      "grpc_server_msg_received"
+				labels: {
+					grpc_type:    {description: "Type of the RPC (unary, client_stream, server_stream, bidi_stream)"}
+					grpc_service: {description: "Fully qualified gRPC service name"}
+					grpc_method:  {description: "gRPC method name"}
+				}
+			}
+
+			grpc_client_handled_total: {
+				name:      "handled_total"
+				namespace: "grpc"
+				subsystem: "client"
+				type:      "counter"
+				help:      "Total number of RPCs completed by the client, regardless of success or failure"
+				role:      "grpc_client_handled"
+				labels: {
+					grpc_service: {description: "Fully qualified gRPC service name"}
+					grpc_method:  {description: "gRPC method name"}
+					grpc_code:    {description: "gRPC status code"}
+				}
+			}
+
+			grpc_client_msg_sent_total: {
+				name:      "msg_sent_total"
+				namespace: "grpc"
+				subsystem: "client"
+				type:      "counter"
+				help:      "Total number of gRPC stream messages sent by the client"
+				role:      "grpc_client_msg_sent"
+				labels: {
+					grpc_method: {description: "gRPC method name"}
+				}
+			}
+
 			// =========================================
 			// Deprecated metric example
 			// =========================================
-			http_request_count: {
+			http_request_count_total: {
 				namespace: "http"
 				subsystem: "server"
 				type:      "counter"
@@ -491,6 +616,12 @@ services: {
 			// HTTP Golden Signals
 			// =========================================
 			"http/server": {
+				// Labels filled by the generated HTTP middleware (--middleware)
+				http: {
+					method:     "method"
+					route:      "path"
+					statusCode: "status"
+				}
 				latency: {
 					description: "How long HTTP requests take to complete. Key indicator of user experience."
 					metrics: ["http_request_duration_seconds"]
@@ -690,15 +821,15 @@ services: {
 					recordingRules: [
 						{
 							name:  "cache:redis:latency:p50:5m"
-							query: "histogram_quantile(0.50, sum(rate(cache_redis_operation_duration_seconds_bucket[5m])) by (le))"
+							query: "histogram_quantile(0.50, sum(rate(cache_redis_duration_seconds_bucket[5m])) by (le))"
 						},
 						{
 							name:  "cache:redis:latency:p95:5m"
-							query: "histogram_quantile(0.95, sum(rate(cache_redis_operation_duration_seconds_bucket[5m])) by (le))"
+							query: "histogram_quantile(0.95, sum(rate(cache_redis_duration_seconds_bucket[5m])) by (le))"
 						},
 						{
 							name:  "cache:redis:latency:p99:5m"
-							query: "histogram_quantile(0.99, sum(rate(cache_redis_operation_duration_seconds_bucket[5m])) by (le))"
+							query: "histogram_quantile(0.99, sum(rate(cache_redis_duration_seconds_bucket[5m])) by (le))"
 						},
 					]
 					thresholds: {
@@ -753,8 +884,8 @@ services: {
 							query: "cache_redis_memory_bytes"
 						},
 						{
-							name:  "cache:redis:saturation:keys_total"
-							query: "cache_redis_keys_total"
+							name:  "cache:redis:saturation:keys"
+							query: "cache_redis_keys"
 						},
 					]
 					thresholds: {
@@ -0,0 +1,91 @@
+package main
+
+// Schema v2 example: units, stability levels, ownership and SLOs
+
+version: "2.0.0"
+
+info: {
+	title:   "Payment Service Metrics"
+	version: "2.3.0"
+}
+
+services: {
+	payment_service: {
+		info: {
+			title:   "Payment Service"
+			version: "2.3.0"
+		}
+
+		metrics: {
+			payments_total: {
+				namespace: "payments"
+				subsystem: "api"
+				type:      "counter"
+				help:      "Total number of payment attempts"
+				stability: "stable"
+				owner: {
+					team:    "payments"
+					contact: "#payments-oncall"
+				}
+				since: "1.0.0"
+				labels: {
+					provider: description: "Payment provider"
+					outcome: {
+						description: "Outcome of the attempt"
+						validations: ["value in ['success', 'declined', 'error']"]
+					}
+				}
+				constLabels: {
+					region: {
+						value:       "${REGION:eu-west-1}"
+						description: "Deployment region"
+					}
+				}
+				slo: {
+					objective:   0.999
+					window:      "30d"
+					description: "Payment attempts not failing with an error"
+				}
+			}
+			payment_duration_seconds: {
+				namespace: "payments"
+				subsystem: "api"
+				type:      "histogram"
+				unit:      "seconds"
+				help:      "Duration of payment authorizations"
+				stability: "beta"
+				owner: team: "payments"
+				since: "2.1.0"
+				labels: provider: description: "Payment provider"
+				buckets: [0.05, 0.1, 0.25, 0.5, 1, 2.5]
+				slo: {
+					objective:   0.99
+					window:      "7d"
+					description: "Authorizations faster than 500ms"
+				}
+			}
+			payload_size_bytes: {
+				namespace: "payments"
+				subsystem: "api"
+				type:      "summary"
+				unit:      "bytes"
+				help:      "Size of the payment requests"
+				stability: "alpha"
+				owner: team: "platform"
+			}
+			fraud_score_ratio: {
+				namespace: "payments"
+				subsystem: "fraud"
+				type:      "gauge"
+				unit:      "ratio"
+				help:      "Latest fraud score of the payment flow"
+				stability: "alpha"
+				owner: {
+					team:    "risk"
+					contact: "risk@example.com"
+				}
+				since: "2.3.0"
+			}
+		}
+	}
+}